@@ -0,0 +1,103 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/trungng1992/gofish/redfish"
+)
+
+// SessionExpiredError is returned when the service rejects the session token
+// and the client cannot, or failed to, create a new session. This happens for
+// clients built from ClientConfig.Session alone, since they do not know the
+// credentials needed to log in again.
+type SessionExpiredError struct {
+	// Err is the error returned by the service.
+	Err error
+}
+
+func (e *SessionExpiredError) Error() string {
+	return "session expired: " + e.Err.Error()
+}
+
+// Unwrap returns the error returned by the service.
+func (e *SessionExpiredError) Unwrap() error {
+	return e.Err
+}
+
+// sessionlessClient sends requests without the client's credentials. It is
+// used to log in again once the current session token has been rejected.
+type sessionlessClient struct {
+	*APIClient
+}
+
+// Post performs a Post request without sending any credentials.
+func (s sessionlessClient) Post(url string, payload interface{}) (*http.Response, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.sendRequest(s.ctx, http.MethodPost, url, bytes.NewReader(body), applicationJSON, nil, false)
+}
+
+// getAuth returns the current auth information.
+func (c *APIClient) getAuth() *redfish.AuthToken {
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+	return c.auth
+}
+
+// isSessionExpired reports whether the response rejects the session token
+// that was sent with the request.
+func isSessionExpired(auth *redfish.AuthToken, resp *http.Response) bool {
+	return auth != nil && auth.Token != "" &&
+		resp != nil && resp.StatusCode == http.StatusUnauthorized
+}
+
+// canReauthenticate reports whether the client knows the credentials needed
+// to create a new session.
+func (c *APIClient) canReauthenticate() bool {
+	return c.username != "" && c.Service != nil && c.Service.sessions != ""
+}
+
+// reauthenticate creates a new session to replace the expired one. If another
+// request already renewed the session, the new one is kept as is.
+func (c *APIClient) reauthenticate(expired *redfish.AuthToken) error {
+	c.authMu.Lock()
+	if c.auth != expired {
+		c.authMu.Unlock()
+		return nil
+	}
+
+	auth, err := redfish.CreateSession(sessionlessClient{c}, c.Service.sessions, c.username, c.password)
+	if err == nil {
+		c.auth = auth
+	}
+	hook := c.reauthHook
+	c.authMu.Unlock()
+
+	// Notify outside of the lock so the hook can use the client
+	if hook != nil {
+		var session *Session
+		if err == nil {
+			session = &Session{ID: auth.Session, Token: auth.Token}
+		}
+		hook(session, err)
+	}
+
+	return err
+}
+
+// SetReauthenticateHook sets the function called every time the client tries
+// to create a new session after the previous one expired.
+func (c *APIClient) SetReauthenticateHook(hook func(session *Session, err error)) {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	c.reauthHook = hook
+}
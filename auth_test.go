@@ -0,0 +1,152 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/trungng1992/gofish/common"
+)
+
+const authServiceRoot = `{
+	"@odata.id": "/redfish/v1/",
+	"Id": "RootService",
+	"Name": "Root Service",
+	"RedfishVersion": "1.6.0",
+	"Links": {
+		"Sessions": {
+			"@odata.id": "/redfish/v1/SessionService/Sessions"
+		}
+	}
+}`
+
+// sessionServer is a minimal service that hands out tokens and can expire
+// them on request.
+type sessionServer struct {
+	mu       sync.Mutex
+	sessions int
+	valid    map[string]bool
+}
+
+func (s *sessionServer) expireAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.valid = map[string]bool{}
+}
+
+func (s *sessionServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case r.URL.Path == "/redfish/v1/":
+		w.Write([]byte(authServiceRoot)) // nolint
+	case r.Method == http.MethodPost && r.URL.Path == "/redfish/v1/SessionService/Sessions":
+		if r.Header.Get("X-Auth-Token") != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.sessions++
+		token := fmt.Sprintf("token-%d", s.sessions)
+		s.valid[token] = true
+		w.Header().Set("X-Auth-Token", token)
+		w.Header().Set("Location", fmt.Sprintf("/redfish/v1/SessionService/Sessions/%d", s.sessions))
+		w.WriteHeader(http.StatusCreated)
+	case !s.valid[r.Header.Get("X-Auth-Token")]:
+		w.WriteHeader(http.StatusUnauthorized)
+	default:
+		w.Write([]byte(`{"Id": "1"}`)) // nolint
+	}
+}
+
+// TestReauthenticateOnExpiredSession tests the session is renewed and the
+// request replayed when the token expires.
+func TestReauthenticateOnExpiredSession(t *testing.T) {
+	server := &sessionServer{valid: map[string]bool{}}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	var hookCalls int32
+	client, err := Connect(ClientConfig{
+		Endpoint:   ts.URL,
+		HTTPClient: ts.Client(),
+		Username:   "admin",
+		Password:   "secret",
+		ReauthenticateHook: func(session *Session, err error) {
+			atomic.AddInt32(&hookCalls, 1)
+			if err != nil || session.Token != "token-2" {
+				t.Errorf("Unexpected re-login result: %#v %v", session, err)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	server.expireAll()
+
+	// Several concurrent requests should only cause a single re-login
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get("/redfish/v1/Systems/1")
+			if err != nil {
+				t.Errorf("Request should have been replayed: %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if hookCalls != 1 || server.sessions != 2 {
+		t.Errorf("Expected a single re-login, got %d hook calls and %d sessions", hookCalls, server.sessions)
+	}
+
+	session, _ := client.GetSession()
+	if session.Token != "token-2" {
+		t.Errorf("Expected renewed token, got %s", session.Token)
+	}
+}
+
+// TestSessionOnlyClientExpired tests clients without credentials return a
+// typed error.
+func TestSessionOnlyClientExpired(t *testing.T) {
+	server := &sessionServer{valid: map[string]bool{}}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	client, err := Connect(ClientConfig{
+		Endpoint:   ts.URL,
+		HTTPClient: ts.Client(),
+		Session:    &Session{ID: "/redfish/v1/SessionService/Sessions/1", Token: "stale"},
+	})
+	if err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	_, err = client.Get("/redfish/v1/Systems/1") // nolint:bodyclose
+	var expired *SessionExpiredError
+	if !errors.As(err, &expired) {
+		t.Fatalf("Expected SessionExpiredError, got: %v", err)
+	}
+
+	var rfErr *common.Error
+	if !errors.As(err, &rfErr) || rfErr.HTTPReturnedStatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected wrapped 401 error, got: %v", err)
+	}
+
+	if server.sessions != 0 {
+		t.Errorf("Client without credentials should not log in, got %d sessions", server.sessions)
+	}
+}
@@ -0,0 +1,187 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultCircuitBreakerCoolDown is how long a circuit breaker stays open when
+// CoolDown is not set.
+const DefaultCircuitBreakerCoolDown = 30 * time.Second
+
+// CircuitBreakerPolicy makes a client fail fast when its service stopped
+// responding, instead of waiting for every request to time out.
+type CircuitBreakerPolicy struct {
+	// FailureThreshold is the number of consecutive transport failures
+	// (connection errors and timeouts, not HTTP error statuses) after which
+	// the breaker opens.
+	FailureThreshold int
+	// CoolDown is how long requests fail fast once the breaker opened. A
+	// single request is then let through to probe the service: the breaker
+	// closes if it succeeds and opens again otherwise. Zero uses
+	// DefaultCircuitBreakerCoolDown.
+	CoolDown time.Duration
+}
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState string
+
+const (
+	// CircuitClosed lets requests through.
+	CircuitClosed CircuitState = "Closed"
+	// CircuitOpen fails requests without sending them.
+	CircuitOpen CircuitState = "Open"
+	// CircuitHalfOpen lets a single request through to probe the service.
+	CircuitHalfOpen CircuitState = "HalfOpen"
+)
+
+// CircuitBreakerState describes the health of a service as seen by the
+// circuit breaker of its client.
+type CircuitBreakerState struct {
+	// State is the current state of the breaker.
+	State CircuitState
+	// ConsecutiveFailures is the number of transport failures since the
+	// last successful request.
+	ConsecutiveFailures int
+	// OpenUntil is when the breaker lets a probe request through, if it is
+	// open.
+	OpenUntil time.Time
+}
+
+// CircuitOpenError is returned for requests refused because the circuit
+// breaker of the client is open.
+type CircuitOpenError struct {
+	// Endpoint is the service the request was for.
+	Endpoint string
+	// OpenUntil is when the breaker lets a probe request through.
+	OpenUntil time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker open for %s until %s", e.Endpoint, e.OpenUntil.Format(time.RFC3339))
+}
+
+// circuitBreaker implements a CircuitBreakerPolicy.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	endpoint  string
+	threshold int
+	coolDown  time.Duration
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+// newCircuitBreaker creates the breaker of a policy, or nil if there is none.
+func newCircuitBreaker(endpoint string, policy *CircuitBreakerPolicy) *circuitBreaker {
+	if policy == nil || policy.FailureThreshold <= 0 {
+		return nil
+	}
+
+	coolDown := policy.CoolDown
+	if coolDown <= 0 {
+		coolDown = DefaultCircuitBreakerCoolDown
+	}
+
+	return &circuitBreaker{
+		endpoint:  endpoint,
+		threshold: policy.FailureThreshold,
+		coolDown:  coolDown,
+	}
+}
+
+// state returns the state of the breaker. The lock must be held.
+func (b *circuitBreaker) state() CircuitState {
+	switch {
+	case b.failures < b.threshold:
+		return CircuitClosed
+	case time.Now().Before(b.openUntil) || b.probing:
+		return CircuitOpen
+	}
+	return CircuitHalfOpen
+}
+
+// allow reports whether a request can be sent.
+func (b *circuitBreaker) allow() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state() {
+	case CircuitOpen:
+		return &CircuitOpenError{Endpoint: b.endpoint, OpenUntil: b.openUntil}
+	case CircuitHalfOpen:
+		b.probing = true
+	}
+	return nil
+}
+
+// done records the outcome of a request allowed by the breaker. Requests
+// cancelled by the caller are not held against the service.
+func (b *circuitBreaker) done(ctx context.Context, err error) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+
+	switch {
+	case err == nil:
+		b.failures = 0
+	case ctx.Err() != nil:
+		// Not the service's fault, let another request probe it
+	default:
+		b.failures++
+		if b.failures >= b.threshold {
+			b.openUntil = time.Now().Add(b.coolDown)
+		}
+	}
+}
+
+// abort records a request allowed by the breaker was not sent after all.
+func (b *circuitBreaker) abort() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// snapshot returns the current state of the breaker.
+func (b *circuitBreaker) snapshot() CircuitBreakerState {
+	if b == nil {
+		return CircuitBreakerState{State: CircuitClosed}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	result := CircuitBreakerState{
+		State:               b.state(),
+		ConsecutiveFailures: b.failures,
+	}
+	if result.State != CircuitClosed {
+		result.OpenUntil = b.openUntil
+	}
+	return result
+}
+
+// CircuitBreakerState returns the state of the client's circuit breaker. It
+// is always closed if the client has no CircuitBreakerPolicy.
+func (c *APIClient) CircuitBreakerState() CircuitBreakerState {
+	return c.breaker.snapshot()
+}
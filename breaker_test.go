@@ -0,0 +1,94 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestCircuitBreaker tests the breaker opens after repeated transport
+// failures, fails fast, and closes again once a probe succeeds.
+func TestCircuitBreaker(t *testing.T) {
+	var failing int32 = 1
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if atomic.LoadInt32(&failing) == 1 {
+			// Drop the connection without answering
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		w.Write([]byte(retryServiceRoot)) // nolint
+	}))
+	defer ts.Close()
+
+	client := newRetryTestClient(ts, nil)
+	client.breaker = newCircuitBreaker(ts.URL, &CircuitBreakerPolicy{FailureThreshold: 2, CoolDown: 20 * time.Millisecond})
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Get("/redfish/v1/"); err == nil { // nolint:bodyclose
+			t.Fatal("Request should have failed")
+		}
+	}
+
+	state := client.CircuitBreakerState()
+	if state.State != CircuitOpen || state.ConsecutiveFailures != 2 {
+		t.Errorf("Expected the breaker to be open, got: %+v", state)
+	}
+
+	sent := atomic.LoadInt32(&calls)
+	_, err := client.Get("/redfish/v1/") // nolint:bodyclose
+	var openErr *CircuitOpenError
+	if !errors.As(err, &openErr) {
+		t.Errorf("Expected a circuit open error, got: %v", err)
+	}
+	if atomic.LoadInt32(&calls) != sent {
+		t.Error("No request should be sent while the breaker is open")
+	}
+
+	time.Sleep(25 * time.Millisecond)
+	if state := client.CircuitBreakerState(); state.State != CircuitHalfOpen {
+		t.Errorf("Expected the breaker to be half open, got: %+v", state)
+	}
+
+	atomic.StoreInt32(&failing, 0)
+	resp, err := client.Get("/redfish/v1/")
+	if err != nil {
+		t.Fatalf("Probe request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if state := client.CircuitBreakerState(); state.State != CircuitClosed || state.ConsecutiveFailures != 0 {
+		t.Errorf("Expected the breaker to be closed, got: %+v", state)
+	}
+}
+
+// TestCircuitBreakerIgnoresHTTPErrors tests error statuses do not count as
+// transport failures.
+func TestCircuitBreakerIgnoresHTTPErrors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	client := newRetryTestClient(ts, nil)
+	client.breaker = newCircuitBreaker(ts.URL, &CircuitBreakerPolicy{FailureThreshold: 1})
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.Get("/redfish/v1/"); err == nil { // nolint:bodyclose
+			t.Fatal("Request should have failed")
+		}
+	}
+
+	if state := client.CircuitBreakerState(); state.State != CircuitClosed {
+		t.Errorf("Expected the breaker to stay closed, got: %+v", state)
+	}
+}
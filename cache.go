@@ -0,0 +1,277 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cacheEntry is a GET response stored in the ResponseCache.
+type cacheEntry struct {
+	body        []byte
+	header      http.Header
+	etag        string
+	validatedAt time.Time
+}
+
+// response builds a new response serving the cached body.
+func (e *cacheEntry) response() *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+	}
+}
+
+// cacheKey identifies a cached resource of a service, as read by a user.
+type cacheKey struct {
+	// origin is the scheme and host of the service.
+	origin string
+	// identity is who the resource was read as, so that clients are never
+	// served resources read with other credentials.
+	identity string
+	uri      string
+}
+
+// cacheOrigin returns the scheme and host of an endpoint, which identify the
+// service in the cache.
+func cacheOrigin(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return strings.TrimSuffix(endpoint, "/")
+	}
+	return strings.ToLower(u.Scheme + "://" + u.Host)
+}
+
+// ResponseCache stores the responses to GET requests so they can be
+// revalidated with If-None-Match instead of being downloaded again. Entries
+// younger than their TTL are served without contacting the service at all.
+// Any other request sent through the client to a resource invalidates the
+// cached resource and its parent collection.
+//
+// Entries are kept per service endpoint and per user, so a cache can be
+// shared by the clients of several services, such as those of a Fleet,
+// without a client being served what it may not be allowed to read. Clients
+// using a Session given in their ClientConfig are told apart by session.
+// Clients authenticating by other means, such as an Interceptor or custom
+// headers, must not share a cache.
+type ResponseCache struct {
+	mu         sync.Mutex
+	entries    map[cacheKey]*cacheEntry
+	ttls       map[string]time.Duration
+	defaultTTL time.Duration
+}
+
+// NewResponseCache creates a cache. Entries are served without revalidation
+// for defaultTTL, zero means they are always revalidated.
+func NewResponseCache(defaultTTL time.Duration) *ResponseCache {
+	return &ResponseCache{
+		entries:    make(map[cacheKey]*cacheEntry),
+		ttls:       make(map[string]time.Duration),
+		defaultTTL: defaultTTL,
+	}
+}
+
+// SetTTL sets the TTL of the resource at uri and of all the resources below
+// it, overriding the default TTL. The most specific uri wins, so for example
+// a Thermal resource can be given a shorter TTL than the rest of a Chassis.
+func (rc *ResponseCache) SetTTL(uri string, ttl time.Duration) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.ttls[strings.TrimSuffix(uri, "/")] = ttl
+}
+
+// ttl returns the TTL of the resource at uri. The lock must be held.
+func (rc *ResponseCache) ttl(uri string) time.Duration {
+	ttl := rc.defaultTTL
+	longest := -1
+	p := strings.TrimSuffix(stripQuery(uri), "/")
+	for prefix, prefixTTL := range rc.ttls {
+		if (p == prefix || strings.HasPrefix(p, prefix+"/")) && len(prefix) > longest {
+			ttl = prefixTTL
+			longest = len(prefix)
+		}
+	}
+	return ttl
+}
+
+// Invalidate removes the resource at uri from the cache, including any
+// cached requests of it with query parameters, for all the services.
+func (rc *ResponseCache) Invalidate(uri string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.invalidate("", uri)
+}
+
+// invalidate removes a resource of the service at origin from the cache, or
+// of all the services if origin is empty. The lock must be held.
+func (rc *ResponseCache) invalidate(origin, uri string) {
+	target := strings.TrimSuffix(stripQuery(uri), "/")
+	for key := range rc.entries {
+		if (origin == "" || key.origin == origin) && strings.TrimSuffix(stripQuery(key.uri), "/") == target {
+			delete(rc.entries, key)
+		}
+	}
+}
+
+// invalidateModified removes a resource that is being modified from the
+// cache, along with its parent collection. For actions, the resource the
+// action belongs to is removed.
+func (rc *ResponseCache) invalidateModified(origin, uri string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	target := strings.TrimSuffix(stripQuery(uri), "/")
+	if i := strings.Index(target, "/Actions/"); i >= 0 {
+		target = target[:i]
+	}
+
+	rc.invalidate(origin, target)
+	if parent := path.Dir(target); parent != "." && parent != "/" {
+		rc.invalidate(origin, parent)
+	}
+}
+
+// Clear removes all the entries from the cache.
+func (rc *ResponseCache) Clear() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.entries = make(map[cacheKey]*cacheEntry)
+}
+
+// lookup returns the entry for key and whether it is fresh enough to be
+// served without revalidation.
+func (rc *ResponseCache) lookup(key cacheKey) (*cacheEntry, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry, ok := rc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	ttl := rc.ttl(key.uri)
+	return entry, ttl > 0 && time.Since(entry.validatedAt) < ttl
+}
+
+// store saves a response body. Responses without an ETag are only kept if
+// they can be served from a TTL.
+func (rc *ResponseCache) store(key cacheKey, header http.Header, body []byte) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	etag := header.Get("ETag")
+	if etag == "" && rc.ttl(key.uri) <= 0 {
+		delete(rc.entries, key)
+		return
+	}
+
+	rc.entries[key] = &cacheEntry{
+		body:        body,
+		header:      header.Clone(),
+		etag:        etag,
+		validatedAt: time.Now(),
+	}
+}
+
+// revalidated marks an entry as confirmed current by the service.
+func (rc *ResponseCache) revalidated(entry *cacheEntry) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	entry.validatedAt = time.Now()
+}
+
+// stripQuery removes the query string from a uri.
+func stripQuery(uri string) string {
+	if i := strings.IndexByte(uri, '?'); i >= 0 {
+		return uri[:i]
+	}
+	return uri
+}
+
+// hasHeader reports whether the custom headers set the given header.
+func hasHeader(customHeaders map[string]string, name string) bool {
+	for k := range customHeaders {
+		if strings.EqualFold(k, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// cacheKey returns the key of a resource read by the client.
+func (c *APIClient) cacheKey(uri string) cacheKey {
+	key := cacheKey{origin: cacheOrigin(c.endpoint), uri: uri}
+	auth := c.getAuth()
+	switch {
+	case c.username != "":
+		key.identity = "user:" + c.username
+	case auth != nil && auth.Username != "":
+		key.identity = "user:" + auth.Username
+	case auth != nil && auth.Session != "":
+		key.identity = "session:" + auth.Session
+	}
+	return key
+}
+
+// cachedGet performs a GET request through the response cache.
+func (c *APIClient) cachedGet(ctx context.Context, url string, customHeaders map[string]string) (*http.Response, error) {
+	key := c.cacheKey(url)
+	entry, fresh := c.cache.lookup(key)
+	if fresh {
+		return entry.response(), nil
+	}
+
+	// Only revalidate requests the caller did not make conditional already
+	conditional := entry != nil && entry.etag != "" && !hasHeader(customHeaders, "If-None-Match")
+	if conditional {
+		headers := make(map[string]string, len(customHeaders)+1)
+		for k, v := range customHeaders {
+			headers[k] = v
+		}
+		headers["If-None-Match"] = entry.etag
+		customHeaders = headers
+	}
+
+	resp, err := c.sendRequest(ctx, http.MethodGet, url, nil, applicationJSON, customHeaders, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		if !conditional {
+			// The caller asked for it, let them handle the 304
+			return resp, nil
+		}
+		discardResponse(resp)
+		c.cache.revalidated(entry)
+		return entry.response(), nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	c.cache.store(key, resp.Header, body)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// ResponseCache returns the response cache of the client, or nil if caching
+// is disabled.
+func (c *APIClient) ResponseCache() *ResponseCache {
+	return c.cache
+}
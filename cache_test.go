@@ -0,0 +1,235 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/trungng1992/gofish/redfish"
+)
+
+// etagServer serves resources with ETags and counts full responses.
+type etagServer struct {
+	mu        sync.Mutex
+	version   int
+	full      int
+	notModify int
+}
+
+func (s *etagServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Method != http.MethodGet {
+		s.version++
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	etag := `W/"` + string(rune('a'+s.version)) + `"`
+	if r.Header.Get("If-None-Match") == etag {
+		s.notModify++
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	s.full++
+	w.Header().Set("ETag", etag)
+	w.Write([]byte(`{"Id": "` + r.URL.Path + `"}`)) // nolint
+}
+
+func readBody(t *testing.T, client *APIClient, url string) string {
+	resp, err := client.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected 200, got %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Error reading body: %v", err)
+	}
+	return string(body)
+}
+
+// TestResponseCacheRevalidate tests cached resources are revalidated with
+// If-None-Match and invalidated by modifications.
+func TestResponseCacheRevalidate(t *testing.T) {
+	server := &etagServer{}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	client := newRetryTestClient(ts, nil)
+	client.cache = NewResponseCache(0)
+
+	const thermal = "/redfish/v1/Chassis/1/Thermal"
+	for i := 0; i < 3; i++ {
+		if body := readBody(t, client, thermal); body != `{"Id": "`+thermal+`"}` {
+			t.Errorf("Unexpected body: %s", body)
+		}
+	}
+
+	if server.full != 1 || server.notModify != 2 {
+		t.Errorf("Expected 1 full response and 2 revalidations, got %d and %d", server.full, server.notModify)
+	}
+
+	resp, err := client.Patch(thermal, map[string]string{"Name": "New"})
+	if err != nil {
+		t.Fatalf("PATCH failed: %v", err)
+	}
+	resp.Body.Close()
+
+	readBody(t, client, thermal)
+	if server.full != 2 {
+		t.Errorf("Modified resource should be fetched again, got %d full responses", server.full)
+	}
+}
+
+// TestResponseCacheTTL tests fresh entries are served without requests.
+func TestResponseCacheTTL(t *testing.T) {
+	server := &etagServer{}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	cache := NewResponseCache(time.Hour)
+	cache.SetTTL("/redfish/v1/Chassis/1/Power", 0)
+
+	client := newRetryTestClient(ts, nil)
+	client.cache = cache
+
+	readBody(t, client, "/redfish/v1/Chassis/1")
+	readBody(t, client, "/redfish/v1/Chassis/1")
+	if server.full+server.notModify != 1 {
+		t.Errorf("Fresh entry should be served from the cache, got %d requests", server.full+server.notModify)
+	}
+
+	readBody(t, client, "/redfish/v1/Chassis/1/Power")
+	readBody(t, client, "/redfish/v1/Chassis/1/Power")
+	if server.notModify != 1 {
+		t.Errorf("Resource with zero TTL should be revalidated, got %d revalidations", server.notModify)
+	}
+
+	// Deleting a member invalidates the parent collection
+	readBody(t, client, "/redfish/v1/Chassis")
+	resp, err := client.Delete("/redfish/v1/Chassis/2")
+	if err != nil {
+		t.Fatalf("DELETE failed: %v", err)
+	}
+	resp.Body.Close()
+
+	full := server.full
+	readBody(t, client, "/redfish/v1/Chassis")
+	if server.full != full+1 {
+		t.Error("Parent collection should have been invalidated")
+	}
+
+	cache.Invalidate("/redfish/v1/Chassis/1")
+	readBody(t, client, "/redfish/v1/Chassis/1")
+	if server.full != full+2 {
+		t.Error("Invalidated entry should be fetched again")
+	}
+}
+
+// TestResponseCacheShared tests a cache shared by the clients of two services
+// keeps their resources apart.
+func TestResponseCacheShared(t *testing.T) {
+	newServer := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			w.Header().Set("ETag", `W/"1"`)
+			w.Write([]byte(`{"Name": "` + name + `"}`)) // nolint
+		}))
+	}
+	first, second := newServer("first"), newServer("second")
+	defer first.Close()
+	defer second.Close()
+
+	cache := NewResponseCache(time.Hour)
+	firstClient, secondClient := newRetryTestClient(first, nil), newRetryTestClient(second, nil)
+	firstClient.cache, secondClient.cache = cache, cache
+
+	const system = "/redfish/v1/Systems/1"
+	if body := readBody(t, firstClient, system); body != `{"Name": "first"}` {
+		t.Errorf("Unexpected body from the first service: %s", body)
+	}
+	if body := readBody(t, secondClient, system); body != `{"Name": "second"}` {
+		t.Errorf("Expected the second service not to be served the first one's resource, got: %s", body)
+	}
+
+	// Changes only invalidate the resources of their own service
+	resp, err := secondClient.Patch(system, map[string]string{"AssetTag": "new"})
+	if err != nil {
+		t.Fatalf("PATCH failed: %v", err)
+	}
+	resp.Body.Close()
+	if _, fresh := cache.lookup(firstClient.cacheKey(system)); !fresh {
+		t.Error("Expected the first service's resource to stay cached")
+	}
+	if entry, _ := cache.lookup(secondClient.cacheKey(system)); entry != nil {
+		t.Error("Expected the second service's resource to be invalidated")
+	}
+}
+
+// TestResponseCacheUsers tests a cache shared by clients authenticated as
+// different users never serves one the resources read by the other.
+func TestResponseCacheUsers(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, _, ok := r.BasicAuth()
+		if !ok {
+			user = r.Header.Get("X-Auth-Token")
+		}
+		w.Header().Set("ETag", `W/"1"`)
+		w.Write([]byte(`{"Name": "` + user + `"}`)) // nolint
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		name          string
+		first, second *redfish.AuthToken
+	}{
+		{
+			name:   "basic auth",
+			first:  &redfish.AuthToken{Username: "admin", Password: "secret", BasicAuth: true},
+			second: &redfish.AuthToken{Username: "operator", Password: "secret", BasicAuth: true},
+		},
+		{
+			name:   "sessions",
+			first:  &redfish.AuthToken{Session: "/redfish/v1/SessionService/Sessions/1", Token: "admin"},
+			second: &redfish.AuthToken{Session: "/redfish/v1/SessionService/Sessions/2", Token: "operator"},
+		},
+	}
+
+	const account = "/redfish/v1/AccountService/Accounts/1"
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cache := NewResponseCache(time.Hour)
+			first, second := newRetryTestClient(ts, nil), newRetryTestClient(ts, nil)
+			first.cache, second.cache = cache, cache
+			first.auth, second.auth = test.first, test.second
+
+			if body := readBody(t, first, account); body != `{"Name": "admin"}` {
+				t.Errorf("Unexpected body for the first user: %s", body)
+			}
+			if body := readBody(t, second, account); body != `{"Name": "operator"}` {
+				t.Errorf("Expected the second user not to be served the first one's resource, got: %s", body)
+			}
+			if _, fresh := cache.lookup(first.cacheKey(account)); !fresh {
+				t.Error("Expected the first user's resource to stay cached")
+			}
+		})
+	}
+}
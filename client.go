@@ -8,18 +8,16 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
-	"mime/multipart"
 	"net/http"
-	"net/http/httputil"
-	"os"
-	"path/filepath"
 	"strconv"
 
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/trungng1992/gofish/common"
@@ -47,8 +45,45 @@ type APIClient struct {
 	// Auth information saved for later to be able to log out
 	auth *redfish.AuthToken
 
+	// authMu guards auth, which is replaced when the session is renewed.
+	authMu sync.RWMutex
+
+	// username and password are kept to create a new session when the
+	// current one expires. They are only set for session based auth.
+	username string
+	password string
+
+	// reauthHook is called after each attempt to renew an expired session.
+	reauthHook func(session *Session, err error)
+
+	// closeConnections disables keep-alive, closing the connection after
+	// each request.
+	closeConnections bool
+
+	// collectionConcurrency is the number of collection members fetched at
+	// the same time.
+	collectionConcurrency int
+
+	// expandMode controls whether collections are fetched using $expand.
+	expandMode common.ExpandMode
+
 	// dumpWriter will receive HTTP dumps if non-nil.
 	dumpWriter io.Writer
+
+	// interceptors run around every request, the first one outermost.
+	interceptors []Interceptor
+
+	// retryPolicy controls retries of failed requests. Nil disables retries.
+	retryPolicy *RetryPolicy
+
+	// cache stores GET responses for conditional requests. Nil disables
+	// caching.
+	cache *ResponseCache
+
+	// limiter and breaker protect the service from too many requests. They
+	// are shared by clones of the client. Nil disables them.
+	limiter *rateLimiter
+	breaker *circuitBreaker
 }
 
 // Session holds the session ID and auth token needed to identify an
@@ -74,20 +109,113 @@ type ClientConfig struct {
 	Session *Session
 
 	// Insecure controls whether to enforce SSL certificate validity.
+	// Public key pins are still checked.
 	Insecure bool
 
+	// CACertFile is the optional path to a PEM file with the CAs to trust
+	// instead of the system ones. It is only used when HTTPClient is not set.
+	CACertFile string
+
+	// CACertPool is the optional pool of CAs to trust instead of the system
+	// ones. It cannot be used together with CACertFile. It is only used when
+	// HTTPClient is not set.
+	CACertPool *x509.CertPool
+
+	// ClientCertFile and ClientKeyFile are the optional paths to the PEM
+	// encoded certificate and private key used to authenticate to services
+	// requiring mutual TLS. They are only used when HTTPClient is not set.
+	ClientCertFile string
+	ClientKeyFile  string
+
+	// ClientCertificates are additional certificates used for mutual TLS.
+	// They are only used when HTTPClient is not set.
+	ClientCertificates []tls.Certificate
+
+	// PinnedPublicKeys optionally restricts the service certificate chain to
+	// one holding one of these public keys, given as the base64 encoded
+	// SHA-256 hash of the SubjectPublicKeyInfo (see PublicKeyPin). They are
+	// only used when HTTPClient is not set.
+	PinnedPublicKeys []string
+
+	// TrustOnFirstUse trusts the certificate presented by the service on the
+	// first connection, recording its fingerprint in CertificateStore, and
+	// then fails with a CertificateChangedError if the service presents
+	// another one. It replaces the verification against the trusted CAs and
+	// is meant for services with self-signed certificates. It is only used
+	// when HTTPClient is not set.
+	TrustOnFirstUse bool
+
+	// CertificateStore records the certificates trusted on first use. If
+	// nil, a FileCertificateStore at DefaultCertificateStorePath is used.
+	CertificateStore CertificateStore
+
 	// Controls TLS handshake timeout
 	TLSHandshakeTimeout int
 
+	// MaxConnsPerHost limits the number of connections opened to the
+	// service, including idle ones. Zero means no limit. It is only used when
+	// HTTPClient is not set.
+	MaxConnsPerHost int
+
+	// IdleConnTimeout is the number of seconds an idle keep-alive connection
+	// is kept open before being closed. Zero uses the Go default. It is only
+	// used when HTTPClient is not set.
+	IdleConnTimeout int
+
+	// CloseConnections closes the connection after each request instead of
+	// reusing it. Some BMCs mishandle keep-alive and need this.
+	CloseConnections bool
+
+	// CollectionConcurrency is the number of collection members fetched at
+	// the same time by the ListReferenced helpers. Zero uses
+	// common.DefaultCollectionConcurrency, set it to 1 for BMCs that cannot
+	// handle parallel requests.
+	CollectionConcurrency int
+
+	// ExpandMode controls whether collections are fetched using the $expand
+	// query parameter. By default it is used when the service advertises it
+	// in ProtocolFeaturesSupported.
+	ExpandMode common.ExpandMode
+
 	// HTTPClient is the optional client to connect with.
 	HTTPClient *http.Client
 
 	// DumpWriter is an optional io.Writer to receive dumps of HTTP
-	// requests and responses.
+	// requests and responses, with credentials redacted. It is the same as
+	// adding a DumpInterceptor after all the Interceptors.
 	DumpWriter io.Writer
 
+	// Interceptors run around every request sent to the service, including
+	// retries. The first one sees the request first and the response last.
+	Interceptors []Interceptor
+
 	// BasicAuth tells the APIClient if basic auth should be used (true) or token based auth must be used (false)
 	BasicAuth bool
+
+	// ReauthenticateHook is an optional function called every time the client
+	// tries to create a new session after the previous one expired. It
+	// receives the new session, or the error if the login failed.
+	ReauthenticateHook func(session *Session, err error)
+
+	// RetryPolicy is the optional policy used to retry requests that failed
+	// because the service was busy or the connection was reset. If nil,
+	// requests are attempted only once.
+	RetryPolicy *RetryPolicy
+
+	// RateLimit optionally limits the rate of requests and the number of
+	// requests outstanding at the same time.
+	RateLimit *RateLimit
+
+	// CircuitBreaker is the optional policy making requests fail fast after
+	// repeated transport failures, until the service recovers.
+	CircuitBreaker *CircuitBreakerPolicy
+
+	// ResponseCache is an optional cache of GET responses. Cached resources
+	// are revalidated using their ETag, or served directly while their TTL
+	// has not expired. A cache can be shared by clients of different
+	// services and users, it keeps their resources apart, but not by
+	// clients authenticating through Interceptors or custom headers.
+	ResponseCache *ResponseCache
 }
 
 // setupClientWithConfig setups the client using the client config
@@ -97,9 +225,19 @@ func setupClientWithConfig(ctx context.Context, config *ClientConfig) (c *APICli
 	}
 
 	client := &APIClient{
-		endpoint:   config.Endpoint,
-		dumpWriter: config.DumpWriter,
-		ctx:        ctx,
+		endpoint:    config.Endpoint,
+		dumpWriter:  config.DumpWriter,
+		retryPolicy: config.RetryPolicy,
+		reauthHook:  config.ReauthenticateHook,
+		cache:       config.ResponseCache,
+		ctx:         ctx,
+
+		closeConnections:      config.CloseConnections,
+		collectionConcurrency: config.CollectionConcurrency,
+		expandMode:            config.ExpandMode,
+		interceptors:          config.Interceptors,
+		limiter:               newRateLimiter(config.RateLimit),
+		breaker:               newCircuitBreaker(config.Endpoint, config.CircuitBreaker),
 	}
 
 	if config.TLSHandshakeTimeout == 0 {
@@ -107,17 +245,29 @@ func setupClientWithConfig(ctx context.Context, config *ClientConfig) (c *APICli
 	}
 
 	if config.HTTPClient == nil {
+		tlsConfig, err := buildTLSConfig(config)
+		if err != nil {
+			return nil, err
+		}
+
 		defaultTransport := http.DefaultTransport.(*http.Transport)
 		transport := &http.Transport{
 			Proxy:                 defaultTransport.Proxy,
 			DialContext:           defaultTransport.DialContext,
 			MaxIdleConns:          defaultTransport.MaxIdleConns,
+			MaxIdleConnsPerHost:   http.DefaultMaxIdleConnsPerHost,
+			MaxConnsPerHost:       config.MaxConnsPerHost,
 			IdleConnTimeout:       defaultTransport.IdleConnTimeout,
 			ExpectContinueTimeout: defaultTransport.ExpectContinueTimeout,
 			TLSHandshakeTimeout:   time.Duration(config.TLSHandshakeTimeout) * time.Second,
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: config.Insecure,
-			},
+			DisableKeepAlives:     config.CloseConnections,
+			TLSClientConfig:       tlsConfig,
+		}
+		if config.MaxConnsPerHost > 0 {
+			transport.MaxIdleConnsPerHost = config.MaxConnsPerHost
+		}
+		if config.IdleConnTimeout > 0 {
+			transport.IdleConnTimeout = time.Duration(config.IdleConnTimeout) * time.Second
 		}
 		client.HTTPClient = &http.Client{Transport: transport}
 	} else {
@@ -175,6 +325,8 @@ func (c *APIClient) setupClientAuth(config *ClientConfig) error {
 			if err != nil {
 				return err
 			}
+			c.username = config.Username
+			c.password = config.Password
 		}
 
 		c.auth = auth
@@ -226,104 +378,225 @@ func (c *APIClient) GetService() *Service {
 
 // CloneWithSession will create a new Client with a session instead of basic auth.
 func (c *APIClient) CloneWithSession() (*APIClient, error) {
-	if c.auth.Session != "" {
+	auth := c.getAuth()
+	if auth.Session != "" {
 		return nil, fmt.Errorf("client already has a session")
 	}
 
-	newClient := *c
-	newClient.HTTPClient = c.HTTPClient
-	service, err := ServiceRoot(&newClient)
+	newClient := &APIClient{
+		ctx:         c.ctx,
+		endpoint:    c.endpoint,
+		HTTPClient:  c.HTTPClient,
+		auth:        auth,
+		dumpWriter:  c.dumpWriter,
+		retryPolicy: c.retryPolicy,
+		reauthHook:  c.reauthHook,
+		cache:       c.cache,
+
+		closeConnections:      c.closeConnections,
+		collectionConcurrency: c.collectionConcurrency,
+		expandMode:            c.expandMode,
+		interceptors:          c.interceptors,
+		limiter:               c.limiter,
+		breaker:               c.breaker,
+	}
+	service, err := ServiceRoot(newClient)
 	if err != nil {
 		return nil, err
 	}
 	newClient.Service = service
 
-	auth, err := newClient.Service.CreateSession(
-		newClient.auth.Username,
-		newClient.auth.Password)
+	newAuth, err := newClient.Service.CreateSession(
+		auth.Username,
+		auth.Password)
 	if err != nil {
 		return nil, err
 	}
-	newClient.auth = auth
+	newClient.auth = newAuth
+	newClient.username = auth.Username
+	newClient.password = auth.Password
 
-	return &newClient, err
+	return newClient, err
 }
 
 // GetSession retrieves the session data from an initialized APIClient. An error
 // is returned if the client is not authenticated.
 func (c *APIClient) GetSession() (*Session, error) {
-	if c.auth.Session == "" {
+	auth := c.getAuth()
+	if auth == nil || auth.Session == "" {
 		return nil, fmt.Errorf("client not authenticated")
 	}
 	return &Session{
-		ID:    c.auth.Session,
-		Token: c.auth.Token,
+		ID:    auth.Session,
+		Token: auth.Token,
 	}, nil
 }
 
+// CollectionConcurrency returns the number of collection members fetched at
+// the same time.
+func (c *APIClient) CollectionConcurrency() int {
+	return c.collectionConcurrency
+}
+
+// CollectionExpandQuery returns the $expand value the service supports for
+// collections, or an empty string if it does not support it.
+func (c *APIClient) CollectionExpandQuery() string {
+	if c.Service == nil {
+		return ""
+	}
+	return c.Service.ProtocolFeaturesSupported.ExpandQuery.CollectionQuery()
+}
+
+// SupportedQueryFeatures returns the query parameters supported by the
+// service.
+func (c *APIClient) SupportedQueryFeatures() common.QueryFeatures {
+	if c.Service == nil {
+		return common.QueryFeatures{}
+	}
+	return c.Service.ProtocolFeaturesSupported.QueryFeatures()
+}
+
+// ExpandMode returns whether collections are fetched using $expand.
+func (c *APIClient) ExpandMode() common.ExpandMode {
+	return c.expandMode
+}
+
 // Get performs a GET request against the Redfish service.
 func (c *APIClient) Get(url string) (*http.Response, error) {
-	return c.GetWithHeaders(url, nil)
+	return c.GetWithHeadersContext(c.ctx, url, nil)
 }
 
 // GetWithHeaders performs a GET request against the Redfish service but allowing custom headers
 func (c *APIClient) GetWithHeaders(url string, customHeaders map[string]string) (*http.Response, error) {
+	return c.GetWithHeadersContext(c.ctx, url, customHeaders)
+}
+
+// GetContext performs a GET request against the Redfish service using the
+// given context instead of the client's.
+func (c *APIClient) GetContext(ctx context.Context, url string) (*http.Response, error) {
+	return c.GetWithHeadersContext(ctx, url, nil)
+}
+
+// GetWithHeadersContext performs a GET request against the Redfish service
+// using the given context but allowing custom headers
+func (c *APIClient) GetWithHeadersContext(ctx context.Context, url string, customHeaders map[string]string) (*http.Response, error) {
 	relativePath := url
 	if relativePath == "" {
 		relativePath = common.DefaultServiceRoot
 	}
 
-	return c.runRequestWithHeaders(http.MethodGet, relativePath, nil, customHeaders)
+	return c.runRequestWithHeaders(ctx, http.MethodGet, relativePath, nil, customHeaders)
 }
 
 // Post performs a Post request against the Redfish service.
 func (c *APIClient) Post(url string, payload interface{}) (*http.Response, error) {
-	return c.PostWithHeaders(url, payload, nil)
+	return c.PostWithHeadersContext(c.ctx, url, payload, nil)
 }
 
 // PostWithHeaders performs a Post request against the Redfish service but allowing custom headers
 func (c *APIClient) PostWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
-	return c.runRequestWithHeaders(http.MethodPost, url, payload, customHeaders)
+	return c.PostWithHeadersContext(c.ctx, url, payload, customHeaders)
+}
+
+// PostContext performs a Post request against the Redfish service using the
+// given context instead of the client's.
+func (c *APIClient) PostContext(ctx context.Context, url string, payload interface{}) (*http.Response, error) {
+	return c.PostWithHeadersContext(ctx, url, payload, nil)
+}
+
+// PostWithHeadersContext performs a Post request against the Redfish service
+// using the given context but allowing custom headers
+func (c *APIClient) PostWithHeadersContext(ctx context.Context, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return c.runRequestWithHeaders(ctx, http.MethodPost, url, payload, customHeaders)
 }
 
 // PostMultipart performs a Post request against the Redfish service with multipart payload.
 func (c *APIClient) PostMultipart(url string, payload map[string]io.Reader) (*http.Response, error) {
-	return c.PostMultipartWithHeaders(url, payload, nil)
+	return c.PostMultipartWithHeadersContext(c.ctx, url, payload, nil)
 }
 
 // PostMultipartWithHeadersperforms a Post request against the Redfish service with multipart payload but allowing custom headers
 func (c *APIClient) PostMultipartWithHeaders(url string, payload map[string]io.Reader, customHeaders map[string]string) (*http.Response, error) {
-	return c.runRequestWithMultipartPayloadWithHeaders(http.MethodPost, url, payload, customHeaders)
+	return c.PostMultipartWithHeadersContext(c.ctx, url, payload, customHeaders)
+}
+
+// PostMultipartContext performs a Post request against the Redfish service
+// with multipart payload using the given context instead of the client's.
+func (c *APIClient) PostMultipartContext(ctx context.Context, url string, payload map[string]io.Reader) (*http.Response, error) {
+	return c.PostMultipartWithHeadersContext(ctx, url, payload, nil)
+}
+
+// PostMultipartWithHeadersContext performs a Post request against the
+// Redfish service with multipart payload using the given context but
+// allowing custom headers
+func (c *APIClient) PostMultipartWithHeadersContext(ctx context.Context, url string, payload map[string]io.Reader, customHeaders map[string]string) (*http.Response, error) {
+	return c.runRequestWithMultipartPayloadWithHeaders(ctx, http.MethodPost, url, payload, customHeaders)
 }
 
 // Put performs a Put request against the Redfish service.
 func (c *APIClient) Put(url string, payload interface{}) (*http.Response, error) {
-	return c.PutWithHeaders(url, payload, nil)
+	return c.PutWithHeadersContext(c.ctx, url, payload, nil)
 }
 
 // PutWithHeaders performs a Put request against the Redfish service but allowing custom headers
 func (c *APIClient) PutWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
-	return c.runRequestWithHeaders(http.MethodPut, url, payload, customHeaders)
+	return c.PutWithHeadersContext(c.ctx, url, payload, customHeaders)
+}
+
+// PutContext performs a Put request against the Redfish service using the
+// given context instead of the client's.
+func (c *APIClient) PutContext(ctx context.Context, url string, payload interface{}) (*http.Response, error) {
+	return c.PutWithHeadersContext(ctx, url, payload, nil)
+}
+
+// PutWithHeadersContext performs a Put request against the Redfish service
+// using the given context but allowing custom headers
+func (c *APIClient) PutWithHeadersContext(ctx context.Context, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return c.runRequestWithHeaders(ctx, http.MethodPut, url, payload, customHeaders)
 }
 
 // Patch performs a Patch request against the Redfish service.
 func (c *APIClient) Patch(url string, payload interface{}) (*http.Response, error) {
-	return c.PatchWithHeaders(url, payload, nil)
+	return c.PatchWithHeadersContext(c.ctx, url, payload, nil)
 }
 
 // PatchWithHeaders performs a Patch request against the Redfish service but allowing custom headers
 func (c *APIClient) PatchWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
-	return c.runRequestWithHeaders(http.MethodPatch, url, payload, customHeaders)
+	return c.PatchWithHeadersContext(c.ctx, url, payload, customHeaders)
+}
+
+// PatchContext performs a Patch request against the Redfish service using
+// the given context instead of the client's.
+func (c *APIClient) PatchContext(ctx context.Context, url string, payload interface{}) (*http.Response, error) {
+	return c.PatchWithHeadersContext(ctx, url, payload, nil)
+}
+
+// PatchWithHeadersContext performs a Patch request against the Redfish
+// service using the given context but allowing custom headers
+func (c *APIClient) PatchWithHeadersContext(ctx context.Context, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return c.runRequestWithHeaders(ctx, http.MethodPatch, url, payload, customHeaders)
 }
 
 // Delete performs a Delete request against the Redfish service
 func (c *APIClient) Delete(url string) (*http.Response, error) {
-	return c.DeleteWithHeaders(url, nil)
+	return c.DeleteWithHeadersContext(c.ctx, url, nil)
 }
 
 // DeleteWithHeaders performs a Delete request against the Redfish service but allowing custom headers
 func (c *APIClient) DeleteWithHeaders(url string, customHeaders map[string]string) (*http.Response, error) {
-	resp, err := c.runRequestWithHeaders(http.MethodDelete, url, nil, customHeaders)
+	return c.DeleteWithHeadersContext(c.ctx, url, customHeaders)
+}
+
+// DeleteContext performs a Delete request against the Redfish service using
+// the given context instead of the client's.
+func (c *APIClient) DeleteContext(ctx context.Context, url string) (*http.Response, error) {
+	return c.DeleteWithHeadersContext(ctx, url, nil)
+}
+
+// DeleteWithHeadersContext performs a Delete request against the Redfish
+// service using the given context but allowing custom headers
+func (c *APIClient) DeleteWithHeadersContext(ctx context.Context, url string, customHeaders map[string]string) (*http.Response, error) {
+	resp, err := c.runRequestWithHeaders(ctx, http.MethodDelete, url, nil, customHeaders)
 	if err != nil {
 		return nil, err
 	}
@@ -334,7 +607,7 @@ func (c *APIClient) DeleteWithHeaders(url string, customHeaders map[string]strin
 }
 
 // runRequestWithHeaders performs JSON REST calls but allowing custom headers
-func (c *APIClient) runRequestWithHeaders(method, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+func (c *APIClient) runRequestWithHeaders(ctx context.Context, method, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
 	if url == "" {
 		return nil, fmt.Errorf("unable to execute request, no target provided")
 	}
@@ -348,58 +621,103 @@ func (c *APIClient) runRequestWithHeaders(method, url string, payload interface{
 		payloadBuffer = bytes.NewReader(body)
 	}
 
-	return c.runRawRequestWithHeaders(method, url, payloadBuffer, applicationJSON, customHeaders)
+	return c.runRawRequestWithHeaders(ctx, method, url, payloadBuffer, applicationJSON, customHeaders)
 }
 
 // runRequestWithMultipartPayloadWithHeaders performs REST calls with a multipart payload but allowing custom headers
-func (c *APIClient) runRequestWithMultipartPayloadWithHeaders(method, url string, payload map[string]io.Reader, customHeaders map[string]string) (*http.Response, error) {
-	if url == "" {
-		return nil, fmt.Errorf("unable to execute request, no target provided")
-	}
-
-	var payloadBuffer bytes.Buffer
-	var err error
-	payloadWriter := multipart.NewWriter(&payloadBuffer)
-	for key, reader := range payload {
-		var partWriter io.Writer
-		if file, ok := reader.(*os.File); ok {
-			// Add a file stream
-			if partWriter, err = payloadWriter.CreateFormFile(key, filepath.Base(file.Name())); err != nil {
-				return nil, err
-			}
-		} else {
-			// Add other fields
-			if partWriter, err = payloadWriter.CreateFormField(key); err != nil {
-				return nil, err
-			}
-		}
-		if _, err = io.Copy(partWriter, reader); err != nil {
-			return nil, err
-		}
-	}
-	payloadWriter.Close()
-
-	return c.runRawRequestWithHeaders(method, url, bytes.NewReader(payloadBuffer.Bytes()), payloadWriter.FormDataContentType(), customHeaders)
+func (c *APIClient) runRequestWithMultipartPayloadWithHeaders(ctx context.Context, method, url string, payload map[string]io.Reader, customHeaders map[string]string) (*http.Response, error) {
+	return c.runMultipartRequest(ctx, method, url, multipartParts(payload), nil, customHeaders)
 }
 
 // runRawRequest actually performs the REST calls
 func (c *APIClient) runRawRequest(method, url string, payloadBuffer io.ReadSeeker, contentType string) (*http.Response, error) {
-	return c.runRawRequestWithHeaders(method, url, payloadBuffer, contentType, nil)
+	return c.runRawRequestWithHeaders(c.ctx, method, url, payloadBuffer, contentType, nil)
 }
 
 // RunRawRequestWithHeaders actually performs the REST calls but allowing custom headers
 func (c *APIClient) RunRawRequestWithHeaders(method, url string, payloadBuffer io.ReadSeeker, contentType string, customHeaders map[string]string) (*http.Response, error) {
-	return c.runRawRequestWithHeaders(method, url, payloadBuffer, contentType, customHeaders)
+	return c.runRawRequestWithHeaders(c.ctx, method, url, payloadBuffer, contentType, customHeaders)
 }
 
 // runRawRequestWithHeaders actually performs the REST calls but allowing custom headers
-func (c *APIClient) runRawRequestWithHeaders(method, url string, payloadBuffer io.ReadSeeker, contentType string, customHeaders map[string]string) (*http.Response, error) {
+func (c *APIClient) runRawRequestWithHeaders(ctx context.Context, method, url string, payloadBuffer io.ReadSeeker, contentType string, customHeaders map[string]string) (*http.Response, error) {
+	if c.cache != nil && url != "" {
+		if method == http.MethodGet && payloadBuffer == nil {
+			return c.cachedGet(ctx, url, customHeaders)
+		}
+		// Whatever the outcome, the cached copy may no longer be current
+		defer c.cache.invalidateModified(cacheOrigin(c.endpoint), url)
+	}
+
+	return c.sendRequest(ctx, method, url, payloadBuffer, contentType, customHeaders, true)
+}
+
+// sendRequest performs the REST call, retrying it according to the retry
+// policy and renewing the session if it expired. If authenticate is false
+// the request is sent without any credentials.
+func (c *APIClient) sendRequest(ctx context.Context, method, url string, payloadBuffer io.ReadSeeker, contentType string, customHeaders map[string]string, authenticate bool) (*http.Response, error) {
 	if url == "" {
 		return nil, common.ConstructError(0, []byte("unable to execute request, no target provided"))
 	}
 
+	reauthenticated := false
+	for attempt := 1; ; attempt++ {
+		// Rewind the payload so it is sent in full again
+		if (attempt > 1 || reauthenticated) && payloadBuffer != nil {
+			if _, err := payloadBuffer.Seek(0, io.SeekStart); err != nil {
+				return nil, common.ConstructError(0, []byte(fmt.Sprintf("unable to rewind payload for retry: %v", err)))
+			}
+		}
+
+		var auth *redfish.AuthToken
+		if authenticate {
+			auth = c.getAuth()
+		}
+
+		resp, err := c.doRawRequest(ctx, auth, method, url, payloadBuffer, contentType, customHeaders)
+
+		if isSessionExpired(auth, resp) {
+			if reauthenticated || !c.canReauthenticate() {
+				_, err = c.handleResponse(resp, nil, attempt, customHeaders) // nolint:bodyclose
+				return nil, &SessionExpiredError{Err: err}
+			}
+
+			discardResponse(resp)
+			if err := c.reauthenticate(auth); err != nil {
+				return nil, err
+			}
+
+			// Replay the request once with the new session
+			reauthenticated = true
+			attempt--
+			continue
+		}
+
+		wait, retry := c.retryPolicy.nextAttempt(attempt, method, resp, err)
+		if !retry {
+			return c.handleResponse(resp, err, attempt, customHeaders)
+		}
+
+		discardResponse(resp)
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// discardResponse drains and closes a response that will not be returned.
+func discardResponse(resp *http.Response) {
+	if resp != nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+}
+
+// doRawRequest builds and sends a single request, returning the raw response
+// whatever its status code.
+func (c *APIClient) doRawRequest(ctx context.Context, auth *redfish.AuthToken, method, url string, payloadBuffer io.ReadSeeker, contentType string, customHeaders map[string]string) (*http.Response, error) {
 	endpoint := fmt.Sprintf("%s%s", c.endpoint, url)
-	req, err := http.NewRequestWithContext(c.ctx, method, endpoint, payloadBuffer)
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, payloadBuffer)
 	if err != nil {
 		return nil, err
 	}
@@ -434,87 +752,107 @@ func (c *APIClient) runRawRequestWithHeaders(method, url string, payloadBuffer i
 	}
 
 	// Add auth info if authenticated
-	if c.auth != nil {
-		if c.auth.Token != "" {
-			req.Header.Set("X-Auth-Token", c.auth.Token)
-			req.Header.Set("Cookie", fmt.Sprintf("sessionKey=%s", c.auth.Token))
-			req.Header.Set("Cookie", fmt.Sprintf("-http-session-=%s", c.auth.Token))
-		} else if c.auth.BasicAuth && c.auth.Username != "" && c.auth.Password != "" {
-			encodedAuth := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%v:%v", c.auth.Username, c.auth.Password)))
+	if auth != nil {
+		if auth.Token != "" {
+			req.Header.Set("X-Auth-Token", auth.Token)
+			req.Header.Set("Cookie", fmt.Sprintf("sessionKey=%s", auth.Token))
+			req.Header.Set("Cookie", fmt.Sprintf("-http-session-=%s", auth.Token))
+		} else if auth.BasicAuth && auth.Username != "" && auth.Password != "" {
+			encodedAuth := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%v:%v", auth.Username, auth.Password)))
 			req.Header.Set("Authorization", fmt.Sprintf("Basic %v", encodedAuth))
 		}
 	}
-	req.Close = true
+	req.Close = c.closeConnections
 
-	// Dump request if needed.
-	if c.dumpWriter != nil {
-		if err := c.dumpRequest(req); err != nil {
-			return nil, err
-		}
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
 	}
 
-	resp, err := c.HTTPClient.Do(req)
+	release, err := c.limiter.acquire(ctx)
 	if err != nil {
+		c.breaker.abort()
 		return nil, err
 	}
 
-	// Dump response if needed.
-	if c.dumpWriter != nil {
-		if err := c.dumpResponse(resp); err != nil {
-			defer resp.Body.Close()
-			return nil, err
-		}
-	}
+	resp, err := chainInterceptors(c.requestInterceptors(), c.do)(req)
+	c.breaker.done(ctx, err)
+	release()
+	return resp, err
+}
 
-	if resp.StatusCode != 200 && resp.StatusCode != 201 && resp.StatusCode != 202 && resp.StatusCode != 204 {
-		payload, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, common.ConstructError(0, []byte(err.Error()))
-		}
-		defer resp.Body.Close()
-		return nil, common.ConstructError(resp.StatusCode, payload)
+// requestInterceptors returns the interceptors to run around a request. The
+// dump writer, if any, is the innermost one so it sees the request as sent.
+func (c *APIClient) requestInterceptors() []Interceptor {
+	if c.dumpWriter == nil {
+		return c.interceptors
 	}
 
-	return resp, err
+	interceptors := make([]Interceptor, 0, len(c.interceptors)+1)
+	interceptors = append(interceptors, c.interceptors...)
+	return append(interceptors, DumpInterceptor(c.dumpWriter))
 }
 
-// dumpRequest writes outgoing client requests to dumpWriter
-func (c *APIClient) dumpRequest(req *http.Request) error {
-	d, err := httputil.DumpRequestOut(req, true)
+// do sends a request using the HTTP client.
+func (c *APIClient) do(req *http.Request) (*http.Response, error) {
+	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return common.ConstructError(0, []byte(err.Error()))
+		return nil, err
 	}
+	resp.Body = &drainingBody{ReadCloser: resp.Body}
+	return resp, nil
+}
 
-	d = append(d, '\n')
-	_, err = c.dumpWriter.Write(d)
-	if err != nil {
-		panic(err)
-	}
+// maxDrainBytes is the most that is read from an unfinished response body
+// on close to allow the connection to be reused.
+const maxDrainBytes = 64 * 1024
 
-	return nil
+// drainingBody reads what is left of a response body when it is closed.
+// The HTTP transport only reuses a connection once its body was fully read,
+// and JSON decoding usually stops short of the end.
+type drainingBody struct {
+	io.ReadCloser
+}
+
+// Close drains and closes the body.
+func (b *drainingBody) Close() error {
+	_, _ = io.CopyN(io.Discard, b.ReadCloser, maxDrainBytes)
+	return b.ReadCloser.Close()
 }
 
-// dumpRequest writes incoming responses to dumpWriter
-func (c *APIClient) dumpResponse(resp *http.Response) error {
-	d, err := httputil.DumpResponse(resp, true)
+// handleResponse turns the outcome of the last attempt into the value returned
+// to the caller, converting error status codes into a common.Error. A 304 is
+// only an error if the request was not conditional.
+func (c *APIClient) handleResponse(resp *http.Response, err error, attempts int, customHeaders map[string]string) (*http.Response, error) {
 	if err != nil {
-		return common.ConstructError(0, []byte(err.Error()))
+		if _, ok := err.(*common.Error); ok || attempts == 1 {
+			return nil, err
+		}
+		return nil, common.ConstructTransportError(err, attempts)
 	}
 
-	d = append(d, '\n')
-	_, err = c.dumpWriter.Write(d)
-	if err != nil {
-		panic(err)
+	if resp.StatusCode == http.StatusNotModified && hasHeader(customHeaders, "If-None-Match") {
+		return resp, nil
 	}
 
-	return nil
+	if resp.StatusCode != 200 && resp.StatusCode != 201 && resp.StatusCode != 202 && resp.StatusCode != 204 {
+		payload, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, common.ConstructError(0, []byte(err.Error()))
+		}
+		defer resp.Body.Close()
+		respErr := common.ConstructError(resp.StatusCode, payload)
+		respErr.(*common.Error).Attempts = attempts
+		return nil, respErr
+	}
+
+	return resp, nil
 }
 
 // Logout will delete any active session. Useful to defer logout when creating
 // a new connection.
 func (c *APIClient) Logout() {
-	if c.Service != nil && c.auth != nil {
-		_ = c.Service.DeleteSession(c.auth.Session)
+	if auth := c.getAuth(); c.Service != nil && auth != nil {
+		_ = c.Service.DeleteSession(auth.Session)
 	}
 }
 
@@ -522,3 +860,9 @@ func (c *APIClient) Logout() {
 func (c *APIClient) SetDumpWriter(writer io.Writer) {
 	c.dumpWriter = writer
 }
+
+// Context returns the context the client makes its requests with, unless
+// another one is passed to the Context variants of the request methods.
+func (c *APIClient) Context() context.Context {
+	return c.ctx
+}
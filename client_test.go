@@ -8,8 +8,10 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -126,6 +128,32 @@ func TestConnectContextTimeout(t *testing.T) {
 	}
 }
 
+// TestGetContextDeadline tests a per-call deadline applies to a single
+// request while the client keeps working.
+func TestGetContextDeadline(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redfish/v1/Slow" {
+			time.Sleep(100 * time.Millisecond)
+		}
+		w.Write([]byte(retryServiceRoot)) // nolint
+	}))
+	defer ts.Close()
+
+	client := newRetryTestClient(ts, fastRetryPolicy())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := client.GetContext(ctx, "/redfish/v1/Slow") // nolint:bodyclose
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Request should have timed out, got: %v", err)
+	}
+
+	_, err = ServiceRoot(common.WithContext(context.Background(), client))
+	if err != nil {
+		t.Errorf("Request with a new context failed: %v", err)
+	}
+}
+
 func TestServiceGetter(t *testing.T) {
 	type serviceGetter interface {
 		GetService() *Service
@@ -200,3 +228,89 @@ func TestClientRunRawRequestNoURL(t *testing.T) {
 		t.Errorf("Unexpected error response: %s", err.Error())
 	}
 }
+
+// newConnCountingServer starts a TLS server that counts new connections.
+func newConnCountingServer(body string) (*httptest.Server, *int32) {
+	var conns int32
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body)) // nolint
+	}))
+	ts.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	ts.StartTLS()
+	return ts, &conns
+}
+
+// TestConnectionReuse tests keep-alive connections are reused by default.
+func TestConnectionReuse(t *testing.T) {
+	ts, conns := newConnCountingServer(`{"@odata.id": "/redfish/v1/", "Id": "RootService"}`)
+	defer ts.Close()
+
+	client, err := Connect(ClientConfig{Endpoint: ts.URL, Insecure: true, MaxConnsPerHost: 2})
+	if err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if _, err := ServiceRoot(client); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+	}
+
+	if n := atomic.LoadInt32(conns); n != 1 {
+		t.Errorf("Expected a single reused connection, got %d", n)
+	}
+}
+
+// TestCloseConnections tests connections are not reused when requested.
+func TestCloseConnections(t *testing.T) {
+	ts, conns := newConnCountingServer(`{"@odata.id": "/redfish/v1/", "Id": "RootService"}`)
+	defer ts.Close()
+
+	client, err := Connect(ClientConfig{Endpoint: ts.URL, Insecure: true, CloseConnections: true})
+	if err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if _, err := ServiceRoot(client); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+	}
+
+	if n := atomic.LoadInt32(conns); n != 6 {
+		t.Errorf("Expected one connection per request, got %d", n)
+	}
+}
+
+func benchmarkGet(b *testing.B, closeConnections bool) {
+	ts, _ := newConnCountingServer(`{"@odata.id": "/redfish/v1/Systems/1", "Id": "1"}`)
+	defer ts.Close()
+
+	client, err := Connect(ClientConfig{Endpoint: ts.URL, Insecure: true, CloseConnections: closeConnections})
+	if err != nil {
+		b.Fatalf("Connect failed: %v", err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resp, err := client.Get("/redfish/v1/Systems/1")
+		if err != nil {
+			b.Fatalf("Request failed: %v", err)
+		}
+		resp.Body.Close()
+	}
+}
+
+// BenchmarkGetKeepAlive measures GETs reusing the TLS connection.
+func BenchmarkGetKeepAlive(b *testing.B) {
+	benchmarkGet(b, false)
+}
+
+// BenchmarkGetCloseConnections measures GETs with a new TLS handshake each time.
+func BenchmarkGetCloseConnections(b *testing.B) {
+	benchmarkGet(b, true)
+}
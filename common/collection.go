@@ -6,13 +6,23 @@ package common
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"strings"
 )
 
 // Collection represents a collection of entity references.
 type Collection struct {
 	Name      string `json:"Name"`
 	ItemLinks []string
+	// NextLink is the URI of the next page of members when the service
+	// returns the collection in several pages.
+	NextLink string `json:"Members@odata.nextLink"`
+	// expanded holds the bodies of the members inlined using $expand, keyed
+	// by their link.
+	expanded map[string][]byte
 }
 
 // UnmarshalJSON unmarshals a collection from the raw JSON.
@@ -36,27 +46,239 @@ func (c *Collection) UnmarshalJSON(b []byte) error {
 
 	// Swordfish has them at the root
 	if len(c.ItemLinks) == 0 &&
-		(t.Count > 0 || t.ODataCount > 0) {
+		(t.Count > 0 || t.ODataCount > 0 || c.NextLink != "") {
 		c.ItemLinks = t.Members.ToStrings()
 	}
 
 	return nil
 }
 
-// GetCollection retrieves a collection from the service.
-func GetCollection(c Client, uri string) (*Collection, error) {
+// DefaultMaxCollectionPages is the maximum number of pages followed when
+// reading a collection, to protect against services returning endless
+// next links.
+const DefaultMaxCollectionPages = 1000
+
+// CollectionOption customizes how GetCollection retrieves a collection.
+type CollectionOption func(*collectionOptions)
+
+// collectionOptions holds the settings applied by CollectionOptions.
+type collectionOptions struct {
+	expand   ExpandMode
+	query    *Query
+	maxPages int
+}
+
+// Expand forces the use of the $expand query parameter on or off for the
+// request, instead of relying on what the service advertises.
+func Expand(mode ExpandMode) CollectionOption {
+	return func(o *collectionOptions) {
+		o.expand = mode
+	}
+}
+
+// MaxPages sets the maximum number of pages followed when reading a
+// collection. It defaults to DefaultMaxCollectionPages.
+func MaxPages(pages int) CollectionOption {
+	return func(o *collectionOptions) {
+		o.maxPages = pages
+	}
+}
+
+// GetCollection retrieves a collection from the service, following the
+// Members@odata.nextLink of each page until all members are read. If the
+// service supports $expand, the members are inlined in the response and
+// FetchCollection will not request them again.
+func GetCollection(c Client, uri string, opts ...CollectionOption) (*Collection, error) {
+	pager := NewCollectionPager(c, uri, opts...)
+
+	var result *Collection
+	for pager.Next() {
+		page := pager.Page()
+		if result == nil {
+			result = page
+			continue
+		}
+
+		result.ItemLinks = append(result.ItemLinks, page.ItemLinks...)
+		if result.expanded == nil && len(page.expanded) > 0 {
+			result.expanded = make(map[string][]byte)
+		}
+		for link, body := range page.expanded {
+			result.expanded[link] = body
+		}
+	}
+
+	if err := pager.Err(); err != nil {
+		return nil, err
+	}
+
+	result.ItemLinks = UniqueLink(result.ItemLinks)
+	result.NextLink = ""
+	return result, nil
+}
+
+// CollectionPager reads a collection one page at a time, following the
+// Members@odata.nextLink of each page. It lets callers stop early without
+// reading the whole collection:
+//
+//	pager := common.NewCollectionPager(c, uri)
+//	for pager.Next() {
+//		for _, link := range pager.Page().ItemLinks {
+//			...
+//		}
+//	}
+//	if err := pager.Err(); err != nil {
+//		...
+//	}
+type CollectionPager struct {
+	client   Client
+	options  collectionOptions
+	next     string
+	started  bool
+	expanded bool
+	pages    int
+	visited  map[string]bool
+	page     *Collection
+	err      error
+}
+
+// NewCollectionPager creates a pager for the collection at uri. No request
+// is made until Next is called.
+func NewCollectionPager(c Client, uri string, opts ...CollectionOption) *CollectionPager {
+	var options collectionOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.maxPages <= 0 {
+		options.maxPages = DefaultMaxCollectionPages
+	}
+
+	return &CollectionPager{
+		client:  c,
+		options: options,
+		next:    uri,
+		visited: make(map[string]bool),
+	}
+}
+
+// Next fetches the next page. It returns false when there are no more pages
+// or an error occurred, which is then returned by Err.
+func (p *CollectionPager) Next() bool {
+	if p.err != nil || (p.started && p.next == "") {
+		return false
+	}
+
+	if p.pages >= p.options.maxPages {
+		p.err = fmt.Errorf("collection has more than %d pages", p.options.maxPages)
+		return false
+	}
+
+	if p.visited[p.next] {
+		p.err = fmt.Errorf("collection next link loops back to %s", p.next)
+		return false
+	}
+	p.visited[p.next] = true
+
+	var page *Collection
+	if !p.started {
+		page, p.expanded, p.err = getFirstPage(p.client, p.next, &p.options)
+		p.started = true
+	} else {
+		page, p.err = getCollection(p.client, p.next, p.expanded)
+	}
+	if p.err != nil {
+		p.page = nil
+		return false
+	}
+
+	p.pages++
+	p.page = page
+	p.next = page.NextLink
+	return true
+}
+
+// Page returns the page fetched by the last call to Next. It can be passed to
+// FetchCollection to get the members of that page.
+func (p *CollectionPager) Page() *Collection {
+	return p.page
+}
+
+// Err returns the error that stopped the pager, if any.
+func (p *CollectionPager) Err() error {
+	return p.err
+}
+
+// getFirstPage requests the first page of a collection, applying the query
+// and $expand options. It also reports whether the members were expanded.
+func getFirstPage(c Client, uri string, options *collectionOptions) (*Collection, bool, error) {
+	uri, err := ApplyQuery(c, uri, options.query)
+	if err != nil {
+		return nil, false, err
+	}
+	if hasOnlyQuery(uri) {
+		return nil, false, errOnlyCollection
+	}
+
+	if query := expandQuery(c, options.expand); query != "" {
+		collection, err := getCollection(c, withQuery(uri, "$expand", query), true)
+		if err == nil {
+			return collection, true, nil
+		}
+
+		// Fall back to a plain request if the service rejected $expand
+		if e, ok := err.(*Error); !ok ||
+			(e.HTTPReturnedStatusCode != http.StatusBadRequest &&
+				e.HTTPReturnedStatusCode != http.StatusNotImplemented) {
+			return nil, false, err
+		}
+	}
+
+	collection, err := getCollection(c, uri, false)
+	return collection, false, err
+}
+
+// errOnlyCollection is returned when a collection is requested with the only
+// query parameter, which returns its single member instead.
+var errOnlyCollection = errors.New("the only query parameter returns a member, not a collection")
+
+// hasOnlyQuery reports whether the uri has the only query parameter.
+func hasOnlyQuery(uri string) bool {
+	i := strings.IndexByte(uri, '?')
+	if i < 0 {
+		return false
+	}
+	for _, param := range strings.Split(uri[i+1:], "&") {
+		if param == "only" || strings.HasPrefix(param, "only=") {
+			return true
+		}
+	}
+	return false
+}
+
+// getCollection performs the request for a collection.
+func getCollection(c Client, uri string, expanded bool) (*Collection, error) {
 	resp, err := c.Get(uri)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
 	var result Collection
-	err = json.NewDecoder(resp.Body).Decode(&result)
+	err = json.Unmarshal(body, &result)
 	if err != nil {
 		return nil, err
 	}
 	result.ItemLinks = UniqueLink(result.ItemLinks)
+
+	if expanded {
+		result.expanded = expandedMembers(body)
+	}
+
 	return &result, nil
 }
 
@@ -7,6 +7,7 @@ package common
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
 	"testing"
 )
@@ -55,3 +56,106 @@ func TestCollection(t *testing.T) {
 		}
 	}
 }
+
+// pageBody builds a collection page holding the given members.
+func pageBody(next string, members ...int) string {
+	var links []string
+	for _, member := range members {
+		links = append(links, fmt.Sprintf(`{"@odata.id": "/redfish/v1/Entries/%d"}`, member))
+	}
+
+	nextLink := ""
+	if next != "" {
+		nextLink = fmt.Sprintf(`"Members@odata.nextLink": "%s",`, next)
+	}
+
+	return fmt.Sprintf(`{
+		"Name": "Log Entries",
+		"Members@odata.count": 5,
+		%s
+		"Members": [%s]
+	}`, nextLink, strings.Join(links, ","))
+}
+
+// TestGetCollectionNextLink tests all pages of a collection are read.
+func TestGetCollectionNextLink(t *testing.T) {
+	client := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(pageBody("/redfish/v1/Entries?$skip=2", 1, 2)),
+				testResponse(pageBody("/redfish/v1/Entries?$skip=4", 3, 4)),
+				testResponse(pageBody("", 5)),
+			},
+		},
+	}
+
+	collection, err := GetCollection(client, "/redfish/v1/Entries")
+	if err != nil {
+		t.Fatalf("Error getting collection: %s", err)
+	}
+
+	if len(collection.ItemLinks) != 5 || collection.ItemLinks[4] != "/redfish/v1/Entries/5" {
+		t.Errorf("Expected all 5 members, got: %v", collection.ItemLinks)
+	}
+
+	calls := client.CapturedCalls()
+	if len(calls) != 3 || calls[2].URL != "/redfish/v1/Entries?$skip=4" {
+		t.Errorf("Unexpected requests: %#v", calls)
+	}
+}
+
+// TestGetCollectionMaxPages tests the page limit stops endless collections.
+func TestGetCollectionMaxPages(t *testing.T) {
+	client := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(pageBody("/redfish/v1/Entries?page=2", 1)),
+				testResponse(pageBody("/redfish/v1/Entries?page=3", 2)),
+			},
+		},
+	}
+
+	_, err := GetCollection(client, "/redfish/v1/Entries", MaxPages(2))
+	if err == nil {
+		t.Error("Reading more than the maximum number of pages should fail")
+	}
+
+	client.Reset()
+	client.CustomReturnForActions[http.MethodGet] = []interface{}{
+		testResponse(pageBody("/redfish/v1/Entries", 1)),
+	}
+	_, err = GetCollection(client, "/redfish/v1/Entries")
+	if err == nil {
+		t.Error("A next link pointing back to a read page should fail")
+	}
+}
+
+// TestCollectionPager tests reading a collection page by page and stopping
+// early.
+func TestCollectionPager(t *testing.T) {
+	client := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(pageBody("/redfish/v1/Entries?$skip=2", 1, 2)),
+				testResponse(pageBody("/redfish/v1/Entries?$skip=4", 3, 4)),
+			},
+		},
+	}
+
+	pager := NewCollectionPager(client, "/redfish/v1/Entries")
+	var links []string
+	for pager.Next() {
+		links = append(links, pager.Page().ItemLinks...)
+		if len(links) >= 3 {
+			break
+		}
+	}
+
+	if err := pager.Err(); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	if len(links) != 4 || len(client.CapturedCalls()) != 2 {
+		t.Errorf("Expected to stop after 2 pages, got %v", links)
+	}
+}
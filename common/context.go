@@ -0,0 +1,167 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// ContextProvider can be implemented by a Client to give the context its
+// requests are made with.
+type ContextProvider interface {
+	// Context returns the context used for requests.
+	Context() context.Context
+}
+
+// clientContext returns the context requests made with the client use.
+func clientContext(c Client) context.Context {
+	if provider, ok := c.(ContextProvider); ok {
+		return provider.Context()
+	}
+	return context.Background()
+}
+
+// contextClient is a Client making all its requests with a given context.
+type contextClient struct {
+	Client
+	ctx context.Context
+}
+
+// WithContext returns a Client that makes its requests with ctx and otherwise
+// behaves like c. It can be passed to any of the entity getters and
+// ListReferenced helpers to set a deadline on, or cancel, that call:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//	systems, err := redfish.ListReferencedComputerSystems(common.WithContext(ctx, c), link)
+//
+// The entities returned keep the context, so it also bounds the requests made
+// through them, such as a crawl of their linked resources. Call SetClient with
+// c on an entity to use it without the context.
+func WithContext(ctx context.Context, c Client) Client {
+	return &contextClient{Client: unwrapContext(c), ctx: ctx}
+}
+
+// unwrapContext returns the client a context client wraps.
+func unwrapContext(c Client) Client {
+	if wrapped, ok := c.(*contextClient); ok {
+		return wrapped.Client
+	}
+	return c
+}
+
+// Context returns the context of the client.
+func (c *contextClient) Context() context.Context {
+	return c.ctx
+}
+
+// CollectionConcurrency returns the concurrency limit of the wrapped client.
+func (c *contextClient) CollectionConcurrency() int {
+	return collectionConcurrency(c.Client)
+}
+
+// CollectionExpandQuery returns the $expand value supported by the wrapped
+// client.
+func (c *contextClient) CollectionExpandQuery() string {
+	if supporter, ok := c.Client.(ExpandQuerySupporter); ok {
+		return supporter.CollectionExpandQuery()
+	}
+	return ""
+}
+
+// ExpandMode returns the expansion mode of the wrapped client.
+func (c *contextClient) ExpandMode() ExpandMode {
+	if provider, ok := c.Client.(ExpandModeProvider); ok {
+		return provider.ExpandMode()
+	}
+	return ExpandAuto
+}
+
+// SupportedQueryFeatures returns the query parameters supported by the
+// wrapped client.
+func (c *contextClient) SupportedQueryFeatures() QueryFeatures {
+	return supportedQueryFeatures(c.Client)
+}
+
+// PostBinary performs a Post request with a raw body using the wrapped client.
+func (c *contextClient) PostBinary(ctx context.Context, url string, r io.Reader, contentType string, progress ProgressFunc, customHeaders map[string]string) (*http.Response, error) {
+	uploader, err := clientUploader(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	return uploader.PostBinary(ctx, url, r, contentType, progress, customHeaders)
+}
+
+// PostMultipartParts performs a multipart/form-data Post request using the
+// wrapped client.
+func (c *contextClient) PostMultipartParts(ctx context.Context, url string, parts []MultipartPart, progress ProgressFunc, customHeaders map[string]string) (*http.Response, error) {
+	uploader, err := clientUploader(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	return uploader.PostMultipartParts(ctx, url, parts, progress, customHeaders)
+}
+
+// Get performs a GET request with the client's context.
+func (c *contextClient) Get(url string) (*http.Response, error) {
+	return c.Client.GetContext(c.ctx, url)
+}
+
+// GetWithHeaders performs a GET request with the client's context.
+func (c *contextClient) GetWithHeaders(url string, customHeaders map[string]string) (*http.Response, error) {
+	return c.Client.GetWithHeadersContext(c.ctx, url, customHeaders)
+}
+
+// Post performs a Post request with the client's context.
+func (c *contextClient) Post(url string, payload interface{}) (*http.Response, error) {
+	return c.Client.PostContext(c.ctx, url, payload)
+}
+
+// PostWithHeaders performs a Post request with the client's context.
+func (c *contextClient) PostWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return c.Client.PostWithHeadersContext(c.ctx, url, payload, customHeaders)
+}
+
+// PostMultipart performs a Post request with the client's context.
+func (c *contextClient) PostMultipart(url string, payload map[string]io.Reader) (*http.Response, error) {
+	return c.Client.PostMultipartContext(c.ctx, url, payload)
+}
+
+// PostMultipartWithHeaders performs a Post request with the client's context.
+func (c *contextClient) PostMultipartWithHeaders(url string, payload map[string]io.Reader, customHeaders map[string]string) (*http.Response, error) {
+	return c.Client.PostMultipartWithHeadersContext(c.ctx, url, payload, customHeaders)
+}
+
+// Patch performs a Patch request with the client's context.
+func (c *contextClient) Patch(url string, payload interface{}) (*http.Response, error) {
+	return c.Client.PatchContext(c.ctx, url, payload)
+}
+
+// PatchWithHeaders performs a Patch request with the client's context.
+func (c *contextClient) PatchWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return c.Client.PatchWithHeadersContext(c.ctx, url, payload, customHeaders)
+}
+
+// Put performs a Put request with the client's context.
+func (c *contextClient) Put(url string, payload interface{}) (*http.Response, error) {
+	return c.Client.PutContext(c.ctx, url, payload)
+}
+
+// PutWithHeaders performs a Put request with the client's context.
+func (c *contextClient) PutWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return c.Client.PutWithHeadersContext(c.ctx, url, payload, customHeaders)
+}
+
+// Delete performs a Delete request with the client's context.
+func (c *contextClient) Delete(url string) (*http.Response, error) {
+	return c.Client.DeleteContext(c.ctx, url)
+}
+
+// DeleteWithHeaders performs a Delete request with the client's context.
+func (c *contextClient) DeleteWithHeaders(url string, customHeaders map[string]string) (*http.Response, error) {
+	return c.Client.DeleteWithHeadersContext(c.ctx, url, customHeaders)
+}
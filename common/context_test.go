@@ -0,0 +1,122 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestWithContextCancelled tests no request is made once the context is
+// cancelled.
+func TestWithContextCancelled(t *testing.T) {
+	testClient := &TestClient{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := getTestEntity(WithContext(ctx, testClient), "/redfish/v1/Systems/1")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a cancellation error, got: %v", err)
+	}
+
+	if len(testClient.CapturedCalls()) != 0 {
+		t.Errorf("No call should have been made: %v", testClient.CapturedCalls())
+	}
+}
+
+// TestWithContextEntityClient tests entities keep the context they were
+// retrieved with, until another client is set.
+func TestWithContextEntityClient(t *testing.T) {
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {testResponse(`{"@odata.id": "/redfish/v1/Systems/1", "Id": "1"}`)},
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	entity, err := getTestEntity(WithContext(ctx, testClient), "/redfish/v1/Systems/1")
+	if err != nil {
+		t.Fatalf("Error getting entity: %v", err)
+	}
+	if clientContext(entity.(*Entity).Client) != ctx {
+		t.Errorf("Entity should keep the context, got: %#v", entity.(*Entity).Client)
+	}
+
+	cancel()
+	_, err = getTestEntity(entity.(*Entity).Client, "/redfish/v1/Systems/1/Bios")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected requests through the entity to be cancelled, got: %v", err)
+	}
+
+	entity.(*Entity).SetClient(testClient)
+	if entity.(*Entity).Client != testClient {
+		t.Errorf("Entity should use the client set, got: %#v", entity.(*Entity).Client)
+	}
+}
+
+// TestFetchCollectionMembersCancelled tests a collection crawl stops once
+// the context is cancelled.
+func TestFetchCollectionMembersCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	client := WithContext(ctx, &TestClient{})
+
+	links := []string{"/redfish/v1/Systems/1", "/redfish/v1/Systems/2", "/redfish/v1/Systems/3"}
+	var fetched []string
+	members, err := FetchCollectionMembers(client, links, func(link string) (interface{}, error) {
+		fetched = append(fetched, link)
+		cancel()
+		return link, nil
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a cancellation error, got: %v", err)
+	}
+
+	if len(fetched) != 1 || len(members) != 1 {
+		t.Errorf("Expected the crawl to stop after the first member, fetched: %v", fetched)
+	}
+}
+
+// TestWithExpandModeContext tests forcing the expansion mode keeps the
+// context of the client.
+func TestWithExpandModeContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client := WithExpandMode(WithContext(ctx, &TestClient{}), ExpandNever)
+
+	if clientContext(client) != ctx {
+		t.Errorf("Expected the context of the wrapped client, got: %v", clientContext(client))
+	}
+	_, err := FetchCollectionMembers(client, []string{"/redfish/v1/Systems/1"}, func(link string) (interface{}, error) {
+		return link, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a cancellation error, got: %v", err)
+	}
+}
+
+// TestWithContextUpload tests uploads go through the wrapped client.
+func TestWithContextUpload(t *testing.T) {
+	uploader := &featureClient{TestClient: &TestClient{}}
+	for _, client := range []Client{WithContext(context.Background(), uploader), WithExpandMode(uploader, ExpandNever)} {
+		resp, err := client.(Uploader).PostBinary(context.Background(), "/redfish/v1/UpdateService/push", strings.NewReader("image"), "", nil, nil)
+		if err != nil {
+			t.Fatalf("Error uploading: %v", err)
+		}
+		resp.Body.Close()
+	}
+	if calls := uploader.CapturedCalls(); len(calls) != 2 {
+		t.Errorf("Expected the uploads to be sent by the wrapped client, got: %+v", calls)
+	}
+
+	client := WithContext(context.Background(), &TestClient{})
+	_, err := client.(Uploader).PostMultipartParts(context.Background(), "/redfish/v1/UpdateService/upload", nil, nil, nil) // nolint:bodyclose
+	if err != errUploadNotSupported {
+		t.Errorf("Expected uploads to fail when the wrapped client does not support them, got: %v", err)
+	}
+}
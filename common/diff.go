@@ -0,0 +1,283 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"reflect"
+	"strings"
+)
+
+var (
+	linkType  = reflect.TypeOf(Link(""))
+	linksType = reflect.TypeOf(Links{})
+)
+
+// patchObject is the PATCH payload of the changed properties of an object.
+type patchObject map[string]interface{}
+
+// patchArray is the PATCH payload of an array of objects, with an element
+// for each element of the array.
+type patchArray []interface{}
+
+// changedProperty is a property found to be different between the original
+// and current state of an object.
+type changedProperty struct {
+	// path is the dotted path of the property, such as Boot.BootOrder.
+	// Array elements share the path of the array.
+	path string
+	// removal is set when the value has been removed rather than set.
+	removal bool
+}
+
+// updateDiff collects the differences between two versions of an object.
+type updateDiff struct {
+	changes []changedProperty
+}
+
+// structPayload compares the exported fields of two structs of the same type
+// and returns the properties that differ as a PATCH payload. Embedded
+// structs are flattened the same way encoding/json does.
+func (d *updateDiff) structPayload(path string, original, current reflect.Value) patchObject {
+	payload := make(patchObject)
+	d.diffFields(path, original, current, payload)
+	return payload
+}
+
+func (d *updateDiff) diffFields(path string, original, current reflect.Value, payload patchObject) {
+	for i := 0; i < original.NumField(); i++ {
+		field := original.Type().Field(i)
+		if field.PkgPath != "" {
+			// Private field or something that we can't access
+			continue
+		}
+		name, ok := jsonFieldName(field)
+		if !ok {
+			continue
+		}
+		if isEmbedded(field) {
+			d.diffFields(path, original.Field(i), current.Field(i), payload)
+			continue
+		}
+
+		if value, changed := d.diff(joinPath(path, name), original.Field(i), current.Field(i)); changed {
+			payload[name] = value
+		}
+	}
+}
+
+// diff compares two values of the same type and returns the payload needed
+// to turn original into current, and whether they differ at all.
+func (d *updateDiff) diff(path string, original, current reflect.Value) (interface{}, bool) {
+	switch {
+	case original.Kind() == reflect.Ptr:
+		switch {
+		case original.IsNil() && current.IsNil():
+			return nil, false
+		case current.IsNil():
+			d.changes = append(d.changes, changedProperty{path: path, removal: true})
+			return nil, true
+		case original.IsNil():
+			if value, set := d.added(path, current.Elem()); set {
+				return value, true
+			}
+			if current.Elem().Kind() == reflect.Struct {
+				return patchObject{}, true
+			}
+			d.changes = append(d.changes, changedProperty{path: path})
+			return patchValue(current.Elem()), true
+		}
+		return d.diff(path, original.Elem(), current.Elem())
+	case original.Kind() == reflect.Struct && hasExportedFields(original.Type()):
+		payload := d.structPayload(path, original, current)
+		return payload, len(payload) > 0
+	case original.Kind() == reflect.Slice && isStructSlice(original.Type()):
+		return d.diffSlice(path, original, current)
+	case original.Kind() == reflect.Slice && original.Len() == 0 && current.Len() == 0:
+		return nil, false
+	}
+
+	if reflect.DeepEqual(original.Interface(), current.Interface()) {
+		return nil, false
+	}
+	d.changes = append(d.changes, changedProperty{path: path})
+	return patchValue(current), true
+}
+
+// diffSlice compares arrays of objects element by element following the
+// Redfish array PATCH semantics: unchanged elements are sent as an empty
+// object, changed elements as their own differences, and removed elements
+// as null.
+func (d *updateDiff) diffSlice(path string, original, current reflect.Value) (interface{}, bool) {
+	length := current.Len()
+	if original.Len() > length {
+		length = original.Len()
+	}
+
+	changed := false
+	payload := make(patchArray, length)
+	for i := 0; i < length; i++ {
+		switch {
+		case i >= current.Len():
+			d.changes = append(d.changes, changedProperty{path: path, removal: true})
+			payload[i] = nil
+			changed = true
+		case i >= original.Len():
+			value, set := d.added(path, current.Index(i))
+			if !set {
+				value = patchObject{}
+			}
+			payload[i] = value
+			changed = true
+		default:
+			value, elementChanged := d.diff(path, original.Index(i), current.Index(i))
+			if !elementChanged {
+				value = patchObject{}
+			}
+			payload[i] = value
+			changed = changed || elementChanged
+		}
+	}
+
+	return payload, changed
+}
+
+// added records the properties of a value that did not exist before and
+// returns the payload creating it, and whether anything is set. Only the
+// properties that are set count as changes and are sent, so a new object may
+// leave its read only properties empty.
+func (d *updateDiff) added(path string, value reflect.Value) (interface{}, bool) {
+	switch {
+	case value.Kind() == reflect.Ptr:
+		if value.IsNil() {
+			return nil, false
+		}
+		return d.added(path, value.Elem())
+	case value.Kind() == reflect.Struct && hasExportedFields(value.Type()):
+		payload := make(patchObject)
+		d.addedFields(path, value, payload)
+		return payload, len(payload) > 0
+	case value.Kind() == reflect.Slice && isStructSlice(value.Type()):
+		if value.Len() == 0 {
+			return nil, false
+		}
+		payload := make(patchArray, value.Len())
+		for i := range payload {
+			element, set := d.added(path, value.Index(i))
+			if !set {
+				element = patchObject{}
+			}
+			payload[i] = element
+		}
+		return payload, true
+	case !value.IsZero():
+		d.changes = append(d.changes, changedProperty{path: path})
+		return patchValue(value), true
+	}
+	return nil, false
+}
+
+// addedFields adds the properties that are set in a new struct to payload.
+func (d *updateDiff) addedFields(path string, value reflect.Value, payload patchObject) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name, ok := jsonFieldName(field)
+		if !ok {
+			continue
+		}
+		if isEmbedded(field) {
+			d.addedFields(path, value.Field(i), payload)
+			continue
+		}
+		if element, set := d.added(joinPath(path, name), value.Field(i)); set {
+			payload[name] = element
+		}
+	}
+}
+
+// readOnly returns the first change that is not allowed by allowedUpdates.
+// An allowed path also allows everything nested under it, and a removal is
+// allowed when any property of the removed value may be updated.
+func (d *updateDiff) readOnly(allowedUpdates []string) (string, bool) {
+	for _, change := range d.changes {
+		allowed := false
+		for _, name := range allowedUpdates {
+			if name == change.path ||
+				strings.HasPrefix(change.path, name+".") ||
+				(change.removal && strings.HasPrefix(name, change.path+".")) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return change.path, true
+		}
+	}
+	return "", false
+}
+
+// jsonFieldName gets the name a struct field has in JSON, or false if the
+// field is not serialized.
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	if name := strings.Split(tag, ",")[0]; name != "" {
+		return name, true
+	}
+	return field.Name, true
+}
+
+// isEmbedded reports whether the fields of an embedded struct are promoted
+// into the JSON object of the struct embedding it.
+func isEmbedded(field reflect.StructField) bool {
+	return field.Anonymous && field.Type.Kind() == reflect.Struct &&
+		strings.Split(field.Tag.Get("json"), ",")[0] == ""
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// hasExportedFields reports whether a struct is compared field by field,
+// rather than as a whole like time.Time.
+func hasExportedFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).PkgPath == "" {
+			return true
+		}
+	}
+	return false
+}
+
+func isStructSlice(t reflect.Type) bool {
+	elem := t.Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	return elem.Kind() == reflect.Struct && hasExportedFields(elem)
+}
+
+// patchValue gets the value to send for a property, turning links back into
+// the reference objects the service expects.
+func patchValue(value reflect.Value) interface{} {
+	switch value.Type() {
+	case linkType:
+		return map[string]string{"@odata.id": value.String()}
+	case linksType:
+		links := make([]map[string]string, value.Len())
+		for i := range links {
+			links[i] = map[string]string{"@odata.id": value.Index(i).String()}
+		}
+		return links
+	}
+	return value.Interface()
+}
@@ -0,0 +1,186 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type diffAddress struct {
+	Address string
+	Origin  string `json:",omitempty"`
+	Gateway string
+}
+
+type diffWatchdog struct {
+	Enabled bool
+	Action  string
+	Status  Status
+}
+
+type diffObject struct {
+	Entity
+	AssetTag  string
+	Boot      struct{ BootNext, BootMode string }
+	Limit     *int
+	Watchdog  *diffWatchdog
+	Addresses []diffAddress
+	Servers   []string
+	Chassis   Link
+	hidden    string
+}
+
+func diffPayload(t *testing.T, original, current *diffObject) (string, *updateDiff) {
+	var d updateDiff
+	payload := d.structPayload("", reflect.ValueOf(original).Elem(), reflect.ValueOf(current).Elem())
+	b, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("Error marshaling the payload: %v", err)
+	}
+	return string(b), &d
+}
+
+func newDiffObject() *diffObject {
+	limit := 5
+	object := &diffObject{
+		Entity:    Entity{ODataID: "/redfish/v1/Objects/1", Client: &TestClient{}},
+		AssetTag:  "tag",
+		Limit:     &limit,
+		Addresses: []diffAddress{{Address: "10.0.0.1", Origin: "Static"}, {Address: "10.0.0.2"}},
+		Servers:   []string{"1.1.1.1"},
+		Chassis:   "/redfish/v1/Chassis/1",
+	}
+	object.Boot.BootNext = "Pxe"
+	return object
+}
+
+// TestUpdateDiff tests nested objects, pointers and arrays produce minimal
+// payloads.
+func TestUpdateDiff(t *testing.T) {
+	original := newDiffObject()
+
+	current := newDiffObject()
+	current.Client = nil
+	current.hidden = "ignored"
+	if payload, _ := diffPayload(t, original, current); payload != `{}` {
+		t.Errorf("Expected no changes, got: %s", payload)
+	}
+
+	current.Boot.BootNext = "Hdd"
+	current.Addresses[1].Address = "10.0.0.3"
+	current.Addresses = append(current.Addresses, diffAddress{Address: "10.0.0.4"})
+	current.Limit = nil
+	current.Servers = append(current.Servers, "8.8.8.8")
+	current.Chassis = "/redfish/v1/Chassis/2"
+	payload, d := diffPayload(t, original, current)
+	expected := `{"Addresses":[{},{"Address":"10.0.0.3"},{"Address":"10.0.0.4"}],` +
+		`"Boot":{"BootNext":"Hdd"},"Chassis":{"@odata.id":"/redfish/v1/Chassis/2"},` +
+		`"Limit":null,"Servers":["1.1.1.1","8.8.8.8"]}`
+	if payload != expected {
+		t.Errorf("Unexpected payload: %s", payload)
+	}
+	if len(d.changes) != 6 {
+		t.Errorf("Expected 6 changed properties, got: %+v", d.changes)
+	}
+
+	current = newDiffObject()
+	current.Addresses = current.Addresses[:1]
+	limit := 5
+	original.Limit = nil
+	current.Limit = &limit
+	if payload, _ := diffPayload(t, original, current); payload != `{"Addresses":[{},null],"Limit":5}` {
+		t.Errorf("Unexpected payload: %s", payload)
+	}
+}
+
+// TestUpdateDiffAdded tests new objects are sent with only the properties
+// that are set, leaving out their empty read only properties.
+func TestUpdateDiffAdded(t *testing.T) {
+	tests := []struct {
+		name     string
+		change   func(*diffObject)
+		expected string
+		changes  int
+	}{
+		{"element", func(o *diffObject) {
+			o.Addresses = append(o.Addresses, diffAddress{Address: "10.0.0.4"})
+		}, `{"Addresses":[{},{},{"Address":"10.0.0.4"}]}`, 1},
+		{"empty element", func(o *diffObject) {
+			o.Addresses = append(o.Addresses, diffAddress{})
+		}, `{"Addresses":[{},{},{}]}`, 0},
+		{"array", func(o *diffObject) {
+			o.Addresses = append(o.Addresses, diffAddress{Address: "10.0.0.4", Gateway: "10.0.0.254"}, diffAddress{})
+		}, `{"Addresses":[{},{},{"Address":"10.0.0.4","Gateway":"10.0.0.254"},{}]}`, 2},
+		{"pointer", func(o *diffObject) {
+			o.Watchdog = &diffWatchdog{Enabled: true}
+		}, `{"Watchdog":{"Enabled":true}}`, 1},
+		{"empty pointer", func(o *diffObject) {
+			o.Watchdog = &diffWatchdog{}
+		}, `{"Watchdog":{}}`, 0},
+		{"scalar pointer", func(o *diffObject) {
+			zero := 0
+			o.Limit = &zero
+		}, `{"Limit":0}`, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			original := newDiffObject()
+			original.Limit = nil
+			current := newDiffObject()
+			current.Limit = nil
+			test.change(current)
+
+			payload, d := diffPayload(t, original, current)
+			if payload != test.expected {
+				t.Errorf("Expected %s, got: %s", test.expected, payload)
+			}
+			if len(d.changes) != test.changes {
+				t.Errorf("Expected %d changed properties, got: %+v", test.changes, d.changes)
+			}
+		})
+	}
+}
+
+// TestUpdateReadOnly tests allowed updates are checked on nested properties.
+func TestUpdateReadOnly(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(*diffObject)
+		allowed []string
+		err     string
+	}{
+		{"nested", func(o *diffObject) { o.Boot.BootMode = "UEFI" }, []string{"Boot.BootNext"}, "Boot.BootMode field is read only"},
+		{"nested allowed", func(o *diffObject) { o.Boot.BootNext = "Hdd" }, []string{"Boot.BootNext"}, ""},
+		{"parent allowed", func(o *diffObject) { o.Boot.BootMode = "UEFI" }, []string{"Boot"}, ""},
+		{"element", func(o *diffObject) { o.Addresses[0].Origin = "DHCP" }, []string{"Addresses.Address"}, "Addresses.Origin field is read only"},
+		{"new element", func(o *diffObject) {
+			o.Addresses = append(o.Addresses, diffAddress{Address: "10.0.0.5"})
+		}, []string{"Addresses.Address"}, ""},
+		{"removed element", func(o *diffObject) { o.Addresses = nil }, []string{"Addresses.Address"}, ""},
+		{"removed read only", func(o *diffObject) { o.Limit = nil }, []string{"AssetTag"}, "Limit field is read only"},
+		{"entity", func(o *diffObject) { o.Name = "new" }, []string{"AssetTag"}, "Name field is read only"},
+	}
+
+	for _, test := range tests {
+		original := newDiffObject()
+		current := newDiffObject()
+		testClient := &TestClient{}
+		current.Client = testClient
+		test.change(current)
+
+		err := current.Update(reflect.ValueOf(original).Elem(), reflect.ValueOf(current).Elem(), test.allowed)
+		switch {
+		case test.err == "" && err != nil:
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		case test.err == "" && len(testClient.CapturedCalls()) != 1:
+			t.Errorf("%s: expected the changes to be sent", test.name)
+		case test.err != "" && (err == nil || err.Error() != test.err):
+			t.Errorf("%s: expected error %q, got: %v", test.name, test.err, err)
+		}
+	}
+}
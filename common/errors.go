@@ -0,0 +1,350 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// asError finds the Error in the chain of err.
+func asError(err error) (*Error, bool) {
+	var e *Error
+	if errors.As(err, &e) && e != nil {
+		return e, true
+	}
+	return nil, false
+}
+
+// hasStatus reports whether err is an Error with the status code.
+func hasStatus(err error, statusCode int) bool {
+	e, ok := asError(err)
+	return ok && e.HTTPReturnedStatusCode == statusCode
+}
+
+// IsNotFound reports whether err is a 404 Not Found error from the service.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports whether err is a 401 Unauthorized error from the
+// service, such as when a session expired.
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsPreconditionFailed reports whether err is a 412 Precondition Failed error
+// from the service, returned when the ETag given in If-Match no longer matches
+// the resource.
+func IsPreconditionFailed(err error) bool {
+	return hasStatus(err, http.StatusPreconditionFailed)
+}
+
+// ConflictError is returned when a change is refused because the resource
+// was modified on the service since it was read, so its ETag no longer
+// matches.
+type ConflictError struct {
+	// URI is the resource the change was sent to.
+	URI string
+	// ETag is the ETag the change was sent with.
+	ETag string
+	// Err is the error returned by the service.
+	Err error
+}
+
+// Error returns the description of the conflict.
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s was modified since it was read (ETag %s): %v", e.URI, e.ETag, e.Err)
+}
+
+// Unwrap returns the error returned by the service.
+func (e *ConflictError) Unwrap() error {
+	return e.Err
+}
+
+// IsConflict reports whether err is a *ConflictError.
+func IsConflict(err error) bool {
+	var conflict *ConflictError
+	return errors.As(err, &conflict)
+}
+
+// IsServiceUnavailable reports whether err is a 503 Service Unavailable error
+// from the service.
+func IsServiceUnavailable(err error) bool {
+	return hasStatus(err, http.StatusServiceUnavailable)
+}
+
+// IsRetryable reports whether err is a transient failure that may go away if
+// the request is sent again: a 429 Too Many Requests, 502 Bad Gateway, 503
+// Service Unavailable or 504 Gateway Timeout error from the service, or a
+// network error reaching it. Context errors are not retryable.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if e, ok := asError(err); ok && e.cause == nil {
+		switch e.HTTPReturnedStatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway,
+			http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// IsActionNotSupported reports whether err is an error from the service with
+// the ActionNotSupported message of the Base registry.
+func IsActionNotSupported(err error) bool {
+	return HasMessage(err, "ActionNotSupported")
+}
+
+// HasMessage reports whether err is an error from the service with a message
+// of the given key, such as PropertyValueNotInList, whatever the registry
+// version.
+func HasMessage(err error, key string) bool {
+	e, ok := asError(err)
+	if !ok {
+		return false
+	}
+
+	if MessageKey(e.Code) == key {
+		return true
+	}
+	for i := range e.ExtendedInfos {
+		if MessageKey(e.ExtendedInfos[i].MessageID) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// MessageKey returns the key of a message in its registry, the last segment of
+// a MessageId such as Base.1.8.ActionNotSupported.
+func MessageKey(messageID string) string {
+	return messageID[strings.LastIndex(messageID, ".")+1:]
+}
+
+// MessageRegistryName returns the registry of a MessageId, such as Base.1.8 for
+// Base.1.8.ActionNotSupported.
+func MessageRegistryName(messageID string) string {
+	if i := strings.LastIndex(messageID, "."); i >= 0 {
+		return messageID[:i]
+	}
+	return ""
+}
+
+// ExtendedInfos returns the messages in err, or nil if it is not an error from
+// the service. The top level message of the error is returned if it has no
+// extended information.
+func ExtendedInfos(err error) []ErrExtendedInfo {
+	e, ok := asError(err)
+	if !ok {
+		return nil
+	}
+
+	if len(e.ExtendedInfos) > 0 {
+		return e.ExtendedInfos
+	}
+	if e.Code != "" {
+		return []ErrExtendedInfo{{MessageID: e.Code, Message: e.Message}}
+	}
+	return nil
+}
+
+// ResponseExtendedInfos returns the messages annotating a successful response,
+// such as the properties a PATCH could not update when the rest of it
+// succeeded with 200 OK. Both the messages about the whole resource and those
+// about single properties are returned. The body of the response is left
+// unread for the caller.
+func ResponseExtendedInfos(resp *http.Response) ([]ErrExtendedInfo, error) {
+	if resp == nil || resp.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil || len(body) == 0 {
+		return nil, err
+	}
+
+	var annotations map[string]json.RawMessage
+	if err := json.Unmarshal(body, &annotations); err != nil {
+		// Not a JSON object, so there is nothing to report
+		return nil, nil
+	}
+
+	var keys []string
+	for key := range annotations {
+		if strings.HasSuffix(key, "@Message.ExtendedInfo") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var result []ErrExtendedInfo
+	for _, key := range keys {
+		var infos []ErrExtendedInfo
+		if err := json.Unmarshal(annotations[key], &infos); err != nil {
+			return result, err
+		}
+
+		property := strings.TrimSuffix(key, "@Message.ExtendedInfo")
+		for i := range infos {
+			if property != "" && len(infos[i].RelatedProperties) == 0 {
+				infos[i].RelatedProperties = []string{"#/" + property}
+			}
+		}
+		result = append(result, infos...)
+	}
+	return result, nil
+}
+
+// RegistryMessage is the definition of a message in a message registry.
+type RegistryMessage struct {
+	// Registry is the registry defining the message, such as Base.1.8.1.
+	Registry string
+	// Message is the message, with %1, %2... marking where the arguments go.
+	Message string
+	// Severity is the severity of the message.
+	Severity string
+	// Resolution is the recommended way to resolve the condition reported.
+	Resolution string
+}
+
+// MessageRegistryLookup finds the definition of messages in the message
+// registries of a service.
+type MessageRegistryLookup interface {
+	// LookupMessage returns the definition of the message with the MessageId.
+	LookupMessage(messageID string) (*RegistryMessage, error)
+}
+
+// ResolvedMessage is a message from the service completed with the
+// definition from its registry.
+type ResolvedMessage struct {
+	// MessageID is the MessageId of the message.
+	MessageID string
+	// Registry is the registry of the message, such as Base.1.8.1, or the
+	// registry named by the MessageId if it was not found.
+	Registry string
+	// Message is the message with its arguments.
+	Message string
+	// MessageArgs are the arguments of the message.
+	MessageArgs []string
+	// RelatedProperties are JSON pointers to the properties the message is
+	// about.
+	RelatedProperties []string
+	// Severity is the severity of the message.
+	Severity string
+	// Resolution is the recommended way to resolve the condition reported.
+	Resolution string
+	// LookupErr is the error finding the message in its registry, if any. The
+	// message is then resolved from what the service sent alone.
+	LookupErr error
+}
+
+// String formats the message with its registry, severity and resolution.
+func (m *ResolvedMessage) String() string {
+	var msg strings.Builder
+	if m.Registry != "" {
+		msg.WriteString(m.Registry)
+		msg.WriteString(" ")
+	}
+	if m.Severity != "" {
+		msg.WriteString(m.Severity)
+		msg.WriteString(": ")
+	}
+	msg.WriteString(m.Message)
+	if len(m.RelatedProperties) > 0 {
+		fmt.Fprintf(&msg, " (%s)", strings.Join(m.RelatedProperties, ", "))
+	}
+	if m.Resolution != "" {
+		msg.WriteString(" Resolution: ")
+		msg.WriteString(m.Resolution)
+	}
+	return msg.String()
+}
+
+// formatMessage substitutes the arguments of a message. Arguments are
+// substituted from the last so that %1 does not replace the start of %10.
+func formatMessage(message string, args []string) string {
+	for i := len(args); i > 0; i-- {
+		message = strings.ReplaceAll(message, "%"+strconv.Itoa(i), args[i-1])
+	}
+	return message
+}
+
+// ResolveMessage completes a message with its definition from the registry.
+// What the service sent takes precedence over the registry, which only fills
+// the blanks. lookup may be nil to only format the message.
+func ResolveMessage(info *ErrExtendedInfo, lookup MessageRegistryLookup) ResolvedMessage {
+	result := ResolvedMessage{
+		MessageID:         info.MessageID,
+		Registry:          MessageRegistryName(info.MessageID),
+		Message:           info.Message,
+		MessageArgs:       info.MessageArgs,
+		RelatedProperties: info.RelatedProperties,
+		Severity:          info.Severity,
+		Resolution:        info.Resolution,
+	}
+
+	if lookup == nil || info.MessageID == "" {
+		return result
+	}
+
+	definition, err := lookup.LookupMessage(info.MessageID)
+	if err != nil {
+		result.LookupErr = err
+		return result
+	}
+
+	if definition.Registry != "" {
+		result.Registry = definition.Registry
+	}
+	if result.Message == "" {
+		result.Message = formatMessage(definition.Message, info.MessageArgs)
+	}
+	if result.Severity == "" {
+		result.Severity = definition.Severity
+	}
+	if result.Resolution == "" {
+		result.Resolution = definition.Resolution
+	}
+	return result
+}
+
+// ResolveMessages completes the messages with their definition from the
+// registry.
+func ResolveMessages(infos []ErrExtendedInfo, lookup MessageRegistryLookup) []ResolvedMessage {
+	result := make([]ResolvedMessage, 0, len(infos))
+	for i := range infos {
+		result = append(result, ResolveMessage(&infos[i], lookup))
+	}
+	return result
+}
+
+// ResolveError completes the messages of an error from the service with their
+// definition from the registry. It returns nil if err is not an error from
+// the service.
+func ResolveError(err error, lookup MessageRegistryLookup) []ResolvedMessage {
+	infos := ExtendedInfos(err)
+	if infos == nil {
+		return nil
+	}
+	return ResolveMessages(infos, lookup)
+}
@@ -0,0 +1,167 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+var actionNotSupportedBody = `{
+	"error": {
+		"code": "Base.1.8.GeneralError",
+		"message": "A general error has occurred. See ExtendedInfo for more information.",
+		"@Message.ExtendedInfo": [
+			{
+				"MessageId": "Base.1.8.ActionNotSupported",
+				"MessageArgs": ["#ComputerSystem.Reset"]
+			}
+		]
+	}
+}`
+
+// TestErrorPredicates tests the predicates see through wrapped errors.
+func TestErrorPredicates(t *testing.T) {
+	tests := []struct {
+		err       error
+		predicate func(error) bool
+	}{
+		{ConstructError(http.StatusNotFound, nil), IsNotFound},
+		{ConstructError(http.StatusUnauthorized, nil), IsUnauthorized},
+		{ConstructError(http.StatusPreconditionFailed, nil), IsPreconditionFailed},
+		{ConstructError(http.StatusServiceUnavailable, nil), IsServiceUnavailable},
+		{ConstructError(http.StatusBadRequest, []byte(actionNotSupportedBody)), IsActionNotSupported},
+	}
+
+	for i, test := range tests {
+		if !test.predicate(test.err) {
+			t.Errorf("Test %d: predicate should match %v", i, test.err)
+		}
+		if !test.predicate(fmt.Errorf("wrapped: %w", test.err)) {
+			t.Errorf("Test %d: predicate should match the wrapped error", i)
+		}
+		if test.predicate(ConstructError(http.StatusInternalServerError, nil)) {
+			t.Errorf("Test %d: predicate should not match a 500", i)
+		}
+	}
+
+	if IsNotFound(nil) || IsNotFound(errors.New("404")) {
+		t.Error("Only errors from the service should match")
+	}
+}
+
+// TestIsRetryable tests transient failures are told apart from final ones.
+func TestIsRetryable(t *testing.T) {
+	reset := &url.Error{Op: "Get", URL: "https://bmc.example.com/redfish/v1/", Err: syscall.ECONNRESET}
+	tests := []struct {
+		err       error
+		retryable bool
+	}{
+		{ConstructError(http.StatusTooManyRequests, nil), true},
+		{ConstructError(http.StatusBadGateway, nil), true},
+		{ConstructError(http.StatusServiceUnavailable, nil), true},
+		{ConstructError(http.StatusGatewayTimeout, nil), true},
+		{fmt.Errorf("wrapped: %w", ConstructError(http.StatusServiceUnavailable, nil)), true},
+		{reset, true},
+		{ConstructTransportError(reset, 3), true},
+		{io.ErrUnexpectedEOF, true},
+		{nil, false},
+		{ConstructError(http.StatusNotFound, nil), false},
+		{ConstructError(http.StatusInternalServerError, nil), false},
+		{ConstructError(0, []byte("unable to execute request, no target provided")), false},
+		{context.Canceled, false},
+		{&url.Error{Op: "Get", URL: "https://bmc.example.com/redfish/v1/", Err: context.DeadlineExceeded}, false},
+		{errors.New("invalid payload"), false},
+	}
+
+	for i, test := range tests {
+		if IsRetryable(test.err) != test.retryable {
+			t.Errorf("Test %d: expected IsRetryable(%v) to be %t", i, test.err, test.retryable)
+		}
+	}
+}
+
+// testLookup is a registry with a single message.
+type testLookup struct{}
+
+func (testLookup) LookupMessage(messageID string) (*RegistryMessage, error) {
+	if MessageKey(messageID) != "ActionNotSupported" {
+		return nil, errors.New("message not found")
+	}
+	return &RegistryMessage{
+		Registry:   "Base.1.8.1",
+		Message:    "The action %1 is not supported by the resource.",
+		Severity:   "Critical",
+		Resolution: "The action supplied cannot be resubmitted to the implementation.",
+	}, nil
+}
+
+// TestResolveError tests messages are completed from their registry.
+func TestResolveError(t *testing.T) {
+	err := fmt.Errorf("reset failed: %w", ConstructError(http.StatusBadRequest, []byte(actionNotSupportedBody)))
+
+	messages := ResolveError(err, testLookup{})
+	if len(messages) != 1 {
+		t.Fatalf("Expected one message, got %d", len(messages))
+	}
+
+	expected := "Base.1.8.1 Critical: The action #ComputerSystem.Reset is not supported by the resource. " +
+		"Resolution: The action supplied cannot be resubmitted to the implementation."
+	if messages[0].String() != expected {
+		t.Errorf("Unexpected message: %s", messages[0].String())
+	}
+
+	// Without extended information the top level message is resolved
+	messages = ResolveError(ConstructError(http.StatusNotFound, []byte(`{"error": {"code": "Base.1.8.ResourceMissingAtURI", "message": "Not here."}}`)), testLookup{})
+	if len(messages) != 1 || messages[0].LookupErr == nil || messages[0].String() != "Base.1.8 Not here." {
+		t.Errorf("Unexpected messages: %+v", messages)
+	}
+
+	if ResolveError(errors.New("connection refused"), testLookup{}) != nil {
+		t.Error("Transport errors have no messages")
+	}
+}
+
+// TestFormatMessage tests arguments past the ninth are substituted.
+func TestFormatMessage(t *testing.T) {
+	args := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}
+	if result := formatMessage("%1 %10 %2", args); result != "a j b" {
+		t.Errorf("Unexpected message: %s", result)
+	}
+}
+
+// TestResponseExtendedInfos tests the messages of a partial success are
+// found both on the resource and its properties.
+func TestResponseExtendedInfos(t *testing.T) {
+	body := `{
+		"@odata.id": "/redfish/v1/Systems/1",
+		"AssetTag": "Rack 1",
+		"@Message.ExtendedInfo": [{"MessageId": "Base.1.8.Success"}],
+		"Boot": {},
+		"HostName@Message.ExtendedInfo": [{"MessageId": "Base.1.8.PropertyNotWritable", "MessageArgs": ["HostName"], "Severity": "Warning"}]
+	}`
+	resp := &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}
+
+	infos, err := ResponseExtendedInfos(resp)
+	if err != nil {
+		t.Fatalf("Error reading messages: %v", err)
+	}
+	if len(infos) != 2 || infos[0].MessageID != "Base.1.8.Success" ||
+		infos[1].MessageID != "Base.1.8.PropertyNotWritable" || infos[1].RelatedProperties[0] != "#/HostName" {
+		t.Errorf("Unexpected messages: %+v", infos)
+	}
+
+	// The body is still there for the caller
+	if rest, _ := io.ReadAll(resp.Body); string(rest) != body {
+		t.Errorf("The body should be left unread, got: %s", rest)
+	}
+}
@@ -0,0 +1,106 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import "net/http"
+
+// UpdateOption customizes how a change to an entity is sent to the service.
+type UpdateOption func(*updateOptions)
+
+// updateOptions holds the settings applied by UpdateOptions.
+type updateOptions struct {
+	skipETagMatch bool
+}
+
+// SkipETagMatch sends the change without the If-Match header, so it is
+// applied even if the resource was modified since it was read.
+func SkipETagMatch() UpdateOption {
+	return func(o *updateOptions) {
+		o.skipETagMatch = true
+	}
+}
+
+// Patch sends payload as a PATCH request to uri. Unless SkipETagMatch is
+// given, the request carries the entity ETag in an If-Match header so the
+// service refuses it with a *ConflictError if the resource changed since it
+// was read.
+func (e *Entity) Patch(uri string, payload interface{}, opts ...UpdateOption) error {
+	resp, err := e.sendMatching(e.Client.PatchWithHeaders, uri, payload, opts)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Post sends payload as a POST request to uri, such as an action of the
+// entity, with the If-Match header handled as for Patch.
+func (e *Entity) Post(uri string, payload interface{}, opts ...UpdateOption) error {
+	resp, err := e.PostWithResponse(uri, payload, opts...)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// PostWithResponse is the same as Post, but returns the response of the
+// service, such as the task monitor of an action, for the caller to close.
+func (e *Entity) PostWithResponse(uri string, payload interface{}, opts ...UpdateOption) (*http.Response, error) {
+	return e.sendMatching(e.Client.PostWithHeaders, uri, payload, opts)
+}
+
+// sendMatching sends a request with the entity ETag in an If-Match header,
+// unless SkipETagMatch is given, and reports a refusal as a *ConflictError.
+func (e *Entity) sendMatching(send func(string, interface{}, map[string]string) (*http.Response, error),
+	uri string, payload interface{}, opts []UpdateOption) (*http.Response, error) {
+	var options updateOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	header := make(map[string]string)
+	if e.etag != "" && !options.skipETagMatch {
+		header["If-Match"] = e.etag
+	}
+
+	resp, err := send(uri, payload, header)
+	if err != nil {
+		if IsPreconditionFailed(err) {
+			return nil, &ConflictError{URI: uri, ETag: e.etag, Err: err}
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpdatableObject is implemented by pointers to the objects that can be
+// updated.
+type UpdatableObject[T any] interface {
+	SchemaObject[T]
+	Update(opts ...UpdateOption) error
+}
+
+// RetryOnConflict applies change to object and updates it. If the update is
+// refused because the resource was modified on the service in the meantime,
+// the object is read again and the change reapplied, up to attempts times in
+// total:
+//
+//	err := common.RetryOnConflict(account, 3, func(a *redfish.ManagerAccount) {
+//		a.Enabled = false
+//	})
+//
+// On return object holds the state the change was last applied to.
+func RetryOnConflict[T any, PT UpdatableObject[T]](object PT, attempts int, change func(PT)) error {
+	for attempt := 1; ; attempt++ {
+		change(object)
+		err := object.Update()
+		if !IsConflict(err) || attempt >= attempts {
+			return err
+		}
+
+		if err := object.entity().refresh(object); err != nil {
+			return err
+		}
+	}
+}
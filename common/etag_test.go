@@ -0,0 +1,120 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+var preconditionFailedBody = `{
+	"error": {
+		"code": "Base.1.8.PreconditionFailed",
+		"message": "The ETag supplied did not match the ETag required to change this resource."
+	}
+}`
+
+func preconditionFailedResponse() *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusPreconditionFailed,
+		Body:       io.NopCloser(strings.NewReader(preconditionFailedBody)),
+	}
+}
+
+type etagObject struct {
+	Entity
+	Value string
+}
+
+func (o *etagObject) Update(opts ...UpdateOption) error {
+	return o.Patch(o.ODataID, map[string]string{"Value": o.Value}, opts...)
+}
+
+// TestEntityPatch tests changes are sent with the entity ETag.
+func TestEntityPatch(t *testing.T) {
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPatch: {nil, nil, preconditionFailedResponse()},
+		},
+	}
+	entity := &Entity{ODataID: "/redfish/v1/Objects/1", Client: testClient}
+	entity.SetETag(`W/"1"`)
+
+	if err := entity.Patch(entity.ODataID, nil); err != nil {
+		t.Fatalf("Error sending the change: %v", err)
+	}
+	if err := entity.Patch(entity.ODataID, nil, SkipETagMatch()); err != nil {
+		t.Fatalf("Error sending the change: %v", err)
+	}
+	calls := testClient.CapturedCalls()
+	if calls[0].CustomHeaders["If-Match"] != `W/"1"` {
+		t.Errorf("Expected the ETag to be sent, got: %v", calls[0].CustomHeaders)
+	}
+	if _, ok := calls[1].CustomHeaders["If-Match"]; ok {
+		t.Errorf("Expected no If-Match header, got: %v", calls[1].CustomHeaders)
+	}
+
+	err := entity.Patch(entity.ODataID, nil)
+	if !IsConflict(err) || !IsPreconditionFailed(err) {
+		t.Errorf("Expected a conflict error, got: %v", err)
+	}
+}
+
+// TestGetObjectODataETag tests the @odata.etag property is used when the
+// service sends no ETag header.
+func TestGetObjectODataETag(t *testing.T) {
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {objectResponse("", `{"@odata.id": "/redfish/v1/Objects/1", "@odata.etag": "W/\"2\""}`)},
+		},
+	}
+
+	object, err := GetObject[etagObject](testClient, "/redfish/v1/Objects/1")
+	if err != nil {
+		t.Fatalf("Error getting the object: %v", err)
+	}
+	if object.ETag() != `W/"2"` {
+		t.Errorf("Unexpected ETag: %q", object.ETag())
+	}
+}
+
+// TestRetryOnConflict tests a conflicting change is applied again to the
+// refreshed object.
+func TestRetryOnConflict(t *testing.T) {
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPatch: {preconditionFailedResponse(), nil},
+			http.MethodGet:   {objectResponse(`W/"2"`, `{"@odata.id": "/redfish/v1/Objects/1", "Value": "theirs"}`)},
+		},
+	}
+	object := &etagObject{Entity: Entity{ODataID: "/redfish/v1/Objects/1", Client: testClient}}
+	object.SetETag(`W/"1"`)
+
+	applied := 0
+	err := RetryOnConflict(object, 2, func(o *etagObject) {
+		applied++
+		o.Value = "ours"
+	})
+	if err != nil {
+		t.Fatalf("Error updating the object: %v", err)
+	}
+	calls := testClient.CapturedCalls()
+	if applied != 2 || len(calls) != 3 || calls[2].CustomHeaders["If-Match"] != `W/"2"` {
+		t.Errorf("Expected the change to be retried with the new ETag, got: %+v", calls)
+	}
+	if object.Value != "ours" || object.ETag() != `W/"2"` {
+		t.Errorf("Unexpected object state: %+v", object)
+	}
+
+	testClient.Reset()
+	testClient.CustomReturnForActions = map[string][]interface{}{
+		http.MethodPatch: {preconditionFailedResponse()},
+	}
+	if err := RetryOnConflict(object, 1, func(o *etagObject) {}); !IsConflict(err) {
+		t.Errorf("Expected the conflict to be returned, got: %v", err)
+	}
+}
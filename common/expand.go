@@ -0,0 +1,263 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DefaultExpandQuery is the $expand value used when expansion is forced on.
+// It inlines the members of a collection, one level deep.
+const DefaultExpandQuery = ".($levels=1)"
+
+// ExpandMode controls whether collections are fetched using the OData $expand
+// query parameter.
+type ExpandMode int
+
+const (
+	// ExpandAuto uses $expand when the service advertises support for it.
+	ExpandAuto ExpandMode = iota
+	// ExpandAlways always uses $expand, even if the service does not
+	// advertise it.
+	ExpandAlways
+	// ExpandNever never uses $expand and fetches each member on its own.
+	ExpandNever
+)
+
+// ExpandQuerySupporter can be implemented by a Client to tell which $expand
+// value the service supports for collections.
+type ExpandQuerySupporter interface {
+	// CollectionExpandQuery returns the value to use for the $expand query
+	// parameter, or an empty string if the service does not support it.
+	CollectionExpandQuery() string
+}
+
+// ExpandModeProvider can be implemented by a Client to override whether
+// collections are expanded.
+type ExpandModeProvider interface {
+	// ExpandMode returns the expansion mode to use for collections.
+	ExpandMode() ExpandMode
+}
+
+// expandModeClient is a Client that forces the expansion mode.
+type expandModeClient struct {
+	Client
+	mode ExpandMode
+}
+
+// ExpandMode returns the forced expansion mode.
+func (c *expandModeClient) ExpandMode() ExpandMode {
+	return c.mode
+}
+
+// CollectionExpandQuery returns the $expand value supported by the wrapped
+// client.
+func (c *expandModeClient) CollectionExpandQuery() string {
+	if supporter, ok := c.Client.(ExpandQuerySupporter); ok {
+		return supporter.CollectionExpandQuery()
+	}
+	return ""
+}
+
+// SupportedQueryFeatures returns the query parameters supported by the
+// wrapped client.
+func (c *expandModeClient) SupportedQueryFeatures() QueryFeatures {
+	return supportedQueryFeatures(c.Client)
+}
+
+// PostBinary performs a Post request with a raw body using the wrapped client.
+func (c *expandModeClient) PostBinary(ctx context.Context, url string, r io.Reader, contentType string, progress ProgressFunc, customHeaders map[string]string) (*http.Response, error) {
+	uploader, err := clientUploader(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	return uploader.PostBinary(ctx, url, r, contentType, progress, customHeaders)
+}
+
+// PostMultipartParts performs a multipart/form-data Post request using the
+// wrapped client.
+func (c *expandModeClient) PostMultipartParts(ctx context.Context, url string, parts []MultipartPart, progress ProgressFunc, customHeaders map[string]string) (*http.Response, error) {
+	uploader, err := clientUploader(c.Client)
+	if err != nil {
+		return nil, err
+	}
+	return uploader.PostMultipartParts(ctx, url, parts, progress, customHeaders)
+}
+
+// CollectionConcurrency returns the concurrency limit of the wrapped client.
+func (c *expandModeClient) CollectionConcurrency() int {
+	return collectionConcurrency(c.Client)
+}
+
+// Context returns the context of the wrapped client.
+func (c *expandModeClient) Context() context.Context {
+	return clientContext(c.Client)
+}
+
+// WithExpandMode returns a Client that uses the given expansion mode for
+// collections and otherwise behaves like c. It can be passed to any of the
+// ListReferenced helpers to force expansion on or off for that call.
+func WithExpandMode(c Client, mode ExpandMode) Client {
+	if wrapped, ok := c.(*expandModeClient); ok {
+		c = wrapped.Client
+	}
+	return &expandModeClient{Client: c, mode: mode}
+}
+
+// expandQuery returns the $expand value to use for the client, or an empty
+// string if the collection should not be expanded.
+func expandQuery(c Client, mode ExpandMode) string {
+	if provider, ok := c.(ExpandModeProvider); ok && mode == ExpandAuto {
+		mode = provider.ExpandMode()
+	}
+
+	var query string
+	if supporter, ok := c.(ExpandQuerySupporter); ok {
+		query = supporter.CollectionExpandQuery()
+	}
+
+	switch mode {
+	case ExpandNever:
+		return ""
+	case ExpandAlways:
+		if query == "" {
+			query = DefaultExpandQuery
+		}
+	}
+
+	return query
+}
+
+// withQuery appends a query parameter to the uri.
+func withQuery(uri, key, value string) string {
+	separator := "?"
+	if strings.Contains(uri, "?") {
+		separator = "&"
+	}
+	return uri + separator + key + "=" + value
+}
+
+// expandedMembers extracts the bodies of the members inlined in a collection.
+// Members only holding a link are left out.
+func expandedMembers(b []byte) map[string][]byte {
+	var t struct {
+		Members []json.RawMessage
+		Links   struct {
+			Members []json.RawMessage
+		}
+	}
+	if err := json.Unmarshal(b, &t); err != nil {
+		return nil
+	}
+
+	members := t.Members
+	if len(members) == 0 {
+		members = t.Links.Members
+	}
+
+	result := make(map[string][]byte)
+	for _, member := range members {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(member, &fields); err != nil || len(fields) < 2 {
+			continue
+		}
+
+		var link Link
+		if err := json.Unmarshal(member, &link); err != nil || link == "" {
+			continue
+		}
+		result[string(link)] = member
+	}
+
+	return result
+}
+
+// inlineClient serves GET requests for the members inlined in a collection
+// and passes everything else to the wrapped client.
+type inlineClient struct {
+	Client
+	bodies map[string][]byte
+}
+
+// inlineResponse returns a response holding the inlined body of the uri.
+func (c *inlineClient) inlineResponse(uri string) (*http.Response, bool) {
+	body, ok := c.bodies[uri]
+	if !ok {
+		return nil, false
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}, true
+}
+
+// Get returns the inlined member body, or performs the GET request.
+func (c *inlineClient) Get(url string) (*http.Response, error) {
+	if resp, ok := c.inlineResponse(url); ok {
+		return resp, nil
+	}
+	return c.Client.Get(url)
+}
+
+// GetWithHeaders returns the inlined member body, or performs the GET request.
+func (c *inlineClient) GetWithHeaders(url string, customHeaders map[string]string) (*http.Response, error) {
+	if resp, ok := c.inlineResponse(url); ok {
+		return resp, nil
+	}
+	return c.Client.GetWithHeaders(url, customHeaders)
+}
+
+// GetContext returns the inlined member body, or performs the GET request.
+func (c *inlineClient) GetContext(ctx context.Context, url string) (*http.Response, error) {
+	if resp, ok := c.inlineResponse(url); ok {
+		return resp, nil
+	}
+	return c.Client.GetContext(ctx, url)
+}
+
+// GetWithHeadersContext returns the inlined member body, or performs the GET
+// request.
+func (c *inlineClient) GetWithHeadersContext(ctx context.Context, url string, customHeaders map[string]string) (*http.Response, error) {
+	if resp, ok := c.inlineResponse(url); ok {
+		return resp, nil
+	}
+	return c.Client.GetWithHeadersContext(ctx, url, customHeaders)
+}
+
+// clientSetter is implemented by all entities.
+type clientSetter interface {
+	SetClient(c Client)
+}
+
+// FetchCollection gets the members of a collection returned by
+// GetCollection, calling fetch for each of them like
+// FetchCollectionMembers. Members that were inlined using $expand are read
+// from the collection body instead of being requested again.
+func FetchCollection(c Client, collection *Collection, fetch func(c Client, link string) (interface{}, error)) ([]interface{}, error) {
+	if len(collection.expanded) == 0 {
+		return FetchCollectionMembers(c, collection.ItemLinks, func(link string) (interface{}, error) {
+			return fetch(c, link)
+		})
+	}
+
+	inline := &inlineClient{Client: c, bodies: collection.expanded}
+	return FetchCollectionMembers(c, collection.ItemLinks, func(link string) (interface{}, error) {
+		member, err := fetch(inline, link)
+		if setter, ok := member.(clientSetter); ok && err == nil {
+			// Do not keep the inline client around once decoded
+			setter.SetClient(c)
+		}
+		return member, err
+	})
+}
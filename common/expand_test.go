@@ -0,0 +1,171 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+var expandedCollectionBody = `{
+		"@odata.id": "/redfish/v1/Systems",
+		"Name": "Computer System Collection",
+		"Members@odata.count": 2,
+		"Members": [
+			{
+				"@odata.id": "/redfish/v1/Systems/1",
+				"Id": "1",
+				"Name": "System One"
+			},
+			{
+				"@odata.id": "/redfish/v1/Systems/2",
+				"Id": "2",
+				"Name": "System Two"
+			}
+		]
+	}`
+
+// expandClient is a TestClient that advertises $expand support.
+type expandClient struct {
+	TestClient
+}
+
+func (c *expandClient) CollectionExpandQuery() string {
+	return DefaultExpandQuery
+}
+
+// getTestEntity decodes an Entity the same way the GetX functions do.
+func getTestEntity(c Client, uri string) (interface{}, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var entity Entity
+	err = json.NewDecoder(resp.Body).Decode(&entity)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.SetClient(c)
+	return &entity, nil
+}
+
+func testResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+// TestGetCollectionExpanded tests the members inlined by $expand are decoded
+// without further requests.
+func TestGetCollectionExpanded(t *testing.T) {
+	client := &expandClient{}
+	client.CustomReturnForActions = map[string][]interface{}{
+		http.MethodGet: {testResponse(expandedCollectionBody)},
+	}
+
+	collection, err := GetCollection(client, "/redfish/v1/Systems")
+	if err != nil {
+		t.Fatalf("Error getting collection: %s", err)
+	}
+
+	members, err := FetchCollection(client, collection, getTestEntity)
+	if err != nil {
+		t.Fatalf("Error fetching members: %s", err)
+	}
+
+	calls := client.CapturedCalls()
+	if len(calls) != 1 || calls[0].URL != "/redfish/v1/Systems?$expand=.($levels=1)" {
+		t.Errorf("Expected a single expanded request, got: %#v", calls)
+	}
+
+	if len(members) != 2 {
+		t.Fatalf("Expected 2 members, got %d", len(members))
+	}
+
+	system := members[1].(*Entity)
+	if system.Name != "System Two" || system.ODataID != "/redfish/v1/Systems/2" {
+		t.Errorf("Unexpected member: %#v", system)
+	}
+
+	if system.Client != client {
+		t.Errorf("Member should use the original client")
+	}
+}
+
+// TestGetCollectionExpandNever tests expansion can be turned off per call.
+func TestGetCollectionExpandNever(t *testing.T) {
+	client := &expandClient{}
+	client.CustomReturnForActions = map[string][]interface{}{
+		http.MethodGet: {testResponse(expandedCollectionBody)},
+	}
+
+	_, err := GetCollection(client, "/redfish/v1/Systems", Expand(ExpandNever))
+	if err != nil {
+		t.Fatalf("Error getting collection: %s", err)
+	}
+
+	calls := client.CapturedCalls()
+	if calls[0].URL != "/redfish/v1/Systems" {
+		t.Errorf("Expected a plain request, got: %s", calls[0].URL)
+	}
+
+	client.Reset()
+	client.CustomReturnForActions[http.MethodGet] = []interface{}{testResponse(expandedCollectionBody)}
+	_, err = GetCollection(WithExpandMode(client, ExpandNever), "/redfish/v1/Systems")
+	if err != nil {
+		t.Fatalf("Error getting collection: %s", err)
+	}
+
+	calls = client.CapturedCalls()
+	if calls[0].URL != "/redfish/v1/Systems" {
+		t.Errorf("Expected a plain request, got: %s", calls[0].URL)
+	}
+}
+
+// TestGetCollectionExpandAlways tests expansion can be forced on.
+func TestGetCollectionExpandAlways(t *testing.T) {
+	client := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {testResponse(expandedCollectionBody)},
+		},
+	}
+	_, err := GetCollection(client, "/redfish/v1/Systems?$top=10", Expand(ExpandAlways))
+	if err != nil {
+		t.Fatalf("Error getting collection: %s", err)
+	}
+
+	calls := client.CapturedCalls()
+	if calls[0].URL != "/redfish/v1/Systems?$top=10&$expand=.($levels=1)" {
+		t.Errorf("Expected an expanded request, got: %s", calls[0].URL)
+	}
+}
+
+// TestGetCollectionExpandFallback tests a plain request is made when the
+// service rejects $expand.
+func TestGetCollectionExpandFallback(t *testing.T) {
+	client := &expandClient{}
+	client.CustomReturnForActions = map[string][]interface{}{
+		http.MethodGet: {
+			&http.Response{StatusCode: http.StatusNotImplemented, Body: io.NopCloser(strings.NewReader(""))},
+			testResponse(expandedCollectionBody),
+		},
+	}
+
+	collection, err := GetCollection(client, "/redfish/v1/Systems")
+	if err != nil {
+		t.Fatalf("Error getting collection: %s", err)
+	}
+
+	if len(client.CapturedCalls()) != 2 || len(collection.ItemLinks) != 2 {
+		t.Errorf("Expected a fallback request, got: %#v", client.CapturedCalls())
+	}
+}
@@ -0,0 +1,95 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"sync"
+)
+
+// DefaultCollectionConcurrency is the number of collection members fetched
+// at the same time when the client does not set its own limit.
+const DefaultCollectionConcurrency = 4
+
+// CollectionConcurrencyLimiter can be implemented by a Client to control how
+// many collection members are fetched at the same time.
+type CollectionConcurrencyLimiter interface {
+	// CollectionConcurrency returns the maximum number of concurrent
+	// requests used to fetch collection members. Zero or less uses
+	// DefaultCollectionConcurrency.
+	CollectionConcurrency() int
+}
+
+// collectionConcurrency returns the concurrency limit to use for the client.
+func collectionConcurrency(c Client) int {
+	if limiter, ok := c.(CollectionConcurrencyLimiter); ok {
+		if limit := limiter.CollectionConcurrency(); limit > 0 {
+			return limit
+		}
+	}
+	return DefaultCollectionConcurrency
+}
+
+// FetchCollectionMembers calls fetch for every link, with at most the
+// client's collection concurrency limit running at once. The fetched objects
+// are returned in the same order as links. Members that could not be fetched
+// are left out of the result and their errors are returned together in a
+// *CollectionError.
+func FetchCollectionMembers(c Client, links []string, fetch func(link string) (interface{}, error)) ([]interface{}, error) {
+	members := make([]interface{}, len(links))
+	errs := make([]error, len(links))
+
+	// Stop fetching members once the client's context is done
+	ctx := clientContext(c)
+	fetchMember := func(i int, link string) {
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			return
+		}
+		members[i], errs[i] = fetch(link)
+	}
+
+	limit := collectionConcurrency(c)
+	if limit == 1 || len(links) < 2 {
+		for i, link := range links {
+			fetchMember(i, link)
+		}
+	} else {
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, limit)
+		for i, link := range links {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(i int, link string) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				fetchMember(i, link)
+			}(i, link)
+		}
+		wg.Wait()
+	}
+
+	var result []interface{}
+	collectionError := NewCollectionError()
+	for i, link := range links {
+		if errs[i] != nil {
+			collectionError.Failures[link] = errs[i]
+		} else {
+			result = append(result, members[i])
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	// Report the cancellation rather than one failure per member
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+
+	return result, collectionError
+}
@@ -0,0 +1,92 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// limitedClient is a TestClient with a custom concurrency limit.
+type limitedClient struct {
+	TestClient
+	limit int
+}
+
+func (c *limitedClient) CollectionConcurrency() int {
+	return c.limit
+}
+
+// TestFetchCollectionMembers tests members are returned in order, with
+// failures gathered in a CollectionError.
+func TestFetchCollectionMembers(t *testing.T) {
+	var links []string
+	for i := 0; i < 20; i++ {
+		links = append(links, fmt.Sprintf("/redfish/v1/Systems/%d", i))
+	}
+
+	var running, maxRunning int32
+	client := &limitedClient{limit: 3}
+	members, err := FetchCollectionMembers(client, links, func(link string) (interface{}, error) {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			seen := atomic.LoadInt32(&maxRunning)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxRunning, seen, current) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+
+		if strings.HasSuffix(link, "/5") {
+			return nil, fmt.Errorf("not found")
+		}
+		return link, nil
+	})
+
+	if maxRunning > 3 {
+		t.Errorf("Expected at most 3 concurrent fetches, got %d", maxRunning)
+	}
+
+	if len(members) != 19 {
+		t.Fatalf("Expected 19 members, got %d", len(members))
+	}
+
+	for i, member := range members {
+		expected := i
+		if i >= 5 {
+			expected++
+		}
+		if member.(string) != links[expected] {
+			t.Errorf("Member %d out of order: %s", i, member)
+		}
+	}
+
+	collectionError, ok := err.(*CollectionError)
+	if !ok {
+		t.Fatalf("Expected a CollectionError, got: %v", err)
+	}
+	if len(collectionError.Failures) != 1 || collectionError.Failures[links[5]] == nil {
+		t.Errorf("Unexpected failures: %v", collectionError.Failures)
+	}
+}
+
+// TestFetchCollectionMembersNoError tests a nil error is returned on success.
+func TestFetchCollectionMembersNoError(t *testing.T) {
+	members, err := FetchCollectionMembers(&TestClient{}, []string{"/a", "/b"}, func(link string) (interface{}, error) {
+		return link, nil
+	})
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	if len(members) != 2 || members[0] != "/a" || members[1] != "/b" {
+		t.Errorf("Unexpected members: %v", members)
+	}
+}
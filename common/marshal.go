@@ -0,0 +1,84 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// MarshalObject marshals an object back into the Redfish JSON it was read
+// from. raw is the JSON the object was decoded from, original a pointer to a
+// new object of the same type to decode it into, and current the object
+// converted to a type without a MarshalJSON method:
+//
+//	func (chassis *Chassis) MarshalJSON() ([]byte, error) {
+//		type temp Chassis
+//		return common.MarshalObject(chassis.rawData, new(Chassis), temp(*chassis))
+//	}
+//
+// The properties changed since the object was decoded are merged into raw,
+// so the Links, Actions and other properties the object only keeps in
+// unexported fields or does not model are preserved. Objects without raw
+// JSON are marshaled from their fields.
+func MarshalObject(raw []byte, original, current interface{}) ([]byte, error) {
+	if len(raw) == 0 {
+		return json.Marshal(current)
+	}
+
+	err := json.Unmarshal(raw, original)
+	if err != nil {
+		return nil, err
+	}
+
+	var d updateDiff
+	payload := d.structPayload("", reflect.ValueOf(original).Elem(), reflect.ValueOf(current))
+	if len(payload) == 0 {
+		return raw, nil
+	}
+
+	var object interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	err = decoder.Decode(&object)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(mergePatch(object, payload))
+}
+
+// mergePatch applies a PATCH payload to a decoded JSON value the way the
+// service would: objects are merged, array elements sent as null removed,
+// and other values replaced.
+func mergePatch(target, patch interface{}) interface{} {
+	switch patch := patch.(type) {
+	case patchObject:
+		object, ok := target.(map[string]interface{})
+		if !ok {
+			object = make(map[string]interface{})
+		}
+		for name, value := range patch {
+			object[name] = mergePatch(object[name], value)
+		}
+		return object
+	case patchArray:
+		array, _ := target.([]interface{})
+		result := make([]interface{}, 0, len(patch))
+		for i, value := range patch {
+			if value == nil {
+				continue
+			}
+			var element interface{}
+			if i < len(array) {
+				element = array[i]
+			}
+			result = append(result, mergePatch(element, value))
+		}
+		return result
+	}
+	return patch
+}
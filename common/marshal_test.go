@@ -0,0 +1,53 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+var marshalObjectBody = `{
+	"@odata.id": "/redfish/v1/Objects/1",
+	"AssetTag": "tag",
+	"Boot": {"BootNext": "Pxe", "BootNext@Redfish.AllowableValues": ["Pxe", "Hdd"]},
+	"Addresses": [{"Address": "10.0.0.1", "Origin": "Static"}, {"Address": "10.0.0.2"}],
+	"Chassis": "/redfish/v1/Chassis/1",
+	"Links": {"ManagedBy": [{"@odata.id": "/redfish/v1/Managers/1"}]}
+}`
+
+// TestMarshalObject tests changes are merged into the raw JSON.
+func TestMarshalObject(t *testing.T) {
+	type temp diffObject
+	var object diffObject
+	err := json.Unmarshal([]byte(marshalObjectBody), &object)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %v", err)
+	}
+
+	b, err := MarshalObject([]byte(marshalObjectBody), new(diffObject), temp(object))
+	if err != nil || string(b) != marshalObjectBody {
+		t.Errorf("An unchanged object should marshal to its raw JSON, got: %s %v", b, err)
+	}
+
+	object.AssetTag = "new"
+	object.Boot.BootNext = "Hdd"
+	object.Addresses = object.Addresses[1:]
+	b, err = MarshalObject([]byte(marshalObjectBody), new(diffObject), temp(object))
+	if err != nil {
+		t.Fatalf("Error marshaling the object: %v", err)
+	}
+	expected := `{"@odata.id":"/redfish/v1/Objects/1","Addresses":[{"Address":"10.0.0.2","Origin":""}],` +
+		`"AssetTag":"new","Boot":{"BootNext":"Hdd","BootNext@Redfish.AllowableValues":["Pxe","Hdd"]},` +
+		`"Chassis":"/redfish/v1/Chassis/1","Links":{"ManagedBy":[{"@odata.id":"/redfish/v1/Managers/1"}]}}`
+	if string(b) != expected {
+		t.Errorf("Unexpected JSON: %s", b)
+	}
+
+	b, err = MarshalObject(nil, new(diffObject), temp{AssetTag: "tag"})
+	if err != nil || !json.Valid(b) {
+		t.Errorf("Objects without raw JSON should be marshaled from their fields: %s %v", b, err)
+	}
+}
@@ -4,10 +4,6 @@
 
 package common
 
-import (
-	"encoding/json"
-)
-
 // Message is This type shall define a Message as described in the
 // Redfish specification.
 type Message struct {
@@ -37,48 +33,11 @@ type Message struct {
 
 // GetMessage will get a Message instance from the service.
 func GetMessage(c Client, uri string) (*Message, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var message Message
-	err = json.NewDecoder(resp.Body).Decode(&message)
-	if err != nil {
-		return nil, err
-	}
-
-	message.SetClient(c)
-	return &message, nil
+	return GetObject[Message](c, uri)
 }
 
 // ListReferencedMessages gets the collection of Message from
 // a provided reference.
 func ListReferencedMessages(c Client, link string) ([]*Message, error) {
-	var result []*Message
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := NewCollectionError()
-	for _, messageLink := range links.ItemLinks {
-		message, err := GetMessage(c, messageLink)
-		if err != nil {
-			collectionError.Failures[messageLink] = err
-		} else {
-			result = append(result, message)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return ListReferenced[Message](c, link)
 }
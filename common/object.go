@@ -0,0 +1,190 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// SchemaObject is implemented by pointers to the Redfish and Swordfish
+// objects, which embed Entity.
+type SchemaObject[T any] interface {
+	*T
+	SetClient(Client)
+	SetETag(string)
+	entity() *Entity
+}
+
+// Completer can be implemented by objects with properties taken from other
+// resources. GetObject and Refresh call Complete once the object is decoded,
+// for it to read these properties with c. Properties that cannot be read are
+// left empty.
+type Completer interface {
+	Complete(c Client)
+}
+
+// GetObject gets the object at uri from the service. The ETag returned with
+// it is kept to guard later changes, and the client set for its methods.
+func GetObject[T any, PT SchemaObject[T]](c Client, uri string) (*T, error) {
+	if strings.TrimSpace(uri) == "" {
+		return nil, fmt.Errorf("uri should not be empty")
+	}
+
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result T
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	if completer, ok := interface{}(PT(&result)).(Completer); ok {
+		completer.Complete(c)
+	}
+	PT(&result).SetETag(objectETag(resp.Header, body))
+	PT(&result).SetClient(c)
+	PT(&result).entity().setSource(PT(&result), body)
+	return &result, nil
+}
+
+// entity gives access to the Entity embedded in an object.
+func (e *Entity) entity() *Entity {
+	return e
+}
+
+// setSource keeps the object embedding the entity and the JSON it was read
+// from.
+func (e *Entity) setSource(object interface{}, body []byte) {
+	e.object = object
+	e.rawJSON = body
+}
+
+// Refresh reads the entity again from the service and replaces the object
+// embedding it in place, along with its ETag. The properties of a Completer
+// are read again too. Only the objects returned by GetObject and
+// ListReferenced, as all getters are, can be refreshed, copies of them cannot.
+func (e *Entity) Refresh() error {
+	if object, ok := e.object.(interface{ entity() *Entity }); ok && object.entity() == e {
+		return e.refresh(object)
+	}
+	return fmt.Errorf("%s was not read from the service and cannot be refreshed", e.ODataID)
+}
+
+// refresh reads the entity again into object, the object embedding it.
+func (e *Entity) refresh(object interface{}) error {
+	resp, err := e.Client.Get(e.ODataID)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	// Decode into a new object so a failure leaves the current one untouched
+	refreshed := reflect.New(reflect.TypeOf(object).Elem())
+	err = json.Unmarshal(body, refreshed.Interface())
+	if err != nil {
+		return err
+	}
+	if completer, ok := refreshed.Interface().(Completer); ok {
+		completer.Complete(e.Client)
+	}
+
+	client := e.Client
+	reflect.ValueOf(object).Elem().Set(refreshed.Elem())
+	e.SetETag(objectETag(resp.Header, body))
+	e.SetClient(client)
+	e.setSource(object, body)
+	return nil
+}
+
+// RawJSON returns the JSON the entity was last read from, giving access to
+// the properties its type does not model. It is nil for objects that were
+// not returned by GetObject or ListReferenced.
+func (e *Entity) RawJSON() []byte {
+	return e.rawJSON
+}
+
+// DecodeOem decodes the Oem property of the entity into target, which
+// usually is a pointer to a struct modelling the vendor extensions:
+//
+//	var oem struct {
+//		Contoso struct {
+//			ProductionDate string
+//		}
+//	}
+//	err := system.DecodeOem(&oem)
+//
+// target is left unchanged if the entity has no Oem property.
+func (e *Entity) DecodeOem(target interface{}) error {
+	if e.rawJSON == nil {
+		return fmt.Errorf("%s was not read from the service and has no Oem property to decode", e.ODataID)
+	}
+
+	var t struct {
+		Oem json.RawMessage
+	}
+	err := json.Unmarshal(e.rawJSON, &t)
+	if err != nil || len(t.Oem) == 0 {
+		return err
+	}
+
+	return json.Unmarshal(t.Oem, target)
+}
+
+// objectETag gets the ETag of an object from the ETag header, or from the
+// @odata.etag property for services that only report it in the body.
+func objectETag(header http.Header, body []byte) string {
+	if etag := header.Get("ETag"); etag != "" {
+		return etag
+	}
+
+	var t struct {
+		ODataEtag string `json:"@odata.etag"`
+	}
+	_ = json.Unmarshal(body, &t)
+	return t.ODataEtag
+}
+
+// ListReferenced gets the members of the collection at link from the
+// service. No link means no members. Members that could not be fetched are
+// left out and their errors are returned together in a *CollectionError.
+func ListReferenced[T any, PT SchemaObject[T]](c Client, link string) ([]*T, error) {
+	var result []*T
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	members, err := FetchCollection(c, links, func(c Client, link string) (interface{}, error) {
+		return GetObject[T, PT](c, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*T))
+	}
+
+	return result, err
+}
@@ -0,0 +1,186 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func objectResponse(etag, body string) *http.Response {
+	header := http.Header{}
+	if etag != "" {
+		header.Set("ETag", etag)
+	}
+	return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+// TestGetObject tests objects are decoded with their client and ETag.
+func TestGetObject(t *testing.T) {
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {objectResponse(`W/"1"`, `{"@odata.id": "/redfish/v1/Messages/1", "Id": "1", "MessageId": "Base.1.8.Success"}`)},
+		},
+	}
+
+	message, err := GetObject[Message](testClient, "/redfish/v1/Messages/1")
+	if err != nil {
+		t.Fatalf("Error getting the object: %v", err)
+	}
+	if message.ID != "1" || message.MessageID != "Base.1.8.Success" {
+		t.Errorf("Unexpected object: %+v", message)
+	}
+	if message.ETag() != `W/"1"` || message.Client != testClient {
+		t.Errorf("Expected the ETag and client to be set, got %q and %v", message.ETag(), message.Client)
+	}
+
+	if _, err := GetObject[Message](testClient, " "); err == nil {
+		t.Error("An empty uri should be refused")
+	}
+}
+
+// TestListReferenced tests the members of a collection are fetched and
+// failures reported together.
+func TestListReferenced(t *testing.T) {
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				objectResponse("", `{"Members@odata.count": 2, "Members": [{"@odata.id": "/redfish/v1/Messages/1"}, {"@odata.id": "/redfish/v1/Messages/2"}]}`),
+				objectResponse("", `{"Id": "1"}`),
+				objectResponse("", `not json`),
+			},
+		},
+	}
+
+	messages, err := ListReferenced[Message](testClient, "/redfish/v1/Messages")
+	if len(messages) != 1 || messages[0].ID != "1" || messages[0].Client != testClient {
+		t.Errorf("Unexpected members: %+v", messages)
+	}
+	var collectionError *CollectionError
+	if !errors.As(err, &collectionError) || collectionError.Failures["/redfish/v1/Messages/2"] == nil {
+		t.Errorf("Expected the failing member to be reported, got: %v", err)
+	}
+
+	testClient.Reset()
+	messages, err = ListReferenced[Message](testClient, "")
+	if messages != nil || err != nil || len(testClient.CapturedCalls()) != 0 {
+		t.Error("No link should mean no members and no calls")
+	}
+}
+
+// TestRefresh tests objects are read again in place.
+func TestRefresh(t *testing.T) {
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				objectResponse(`W/"1"`, `{"@odata.id": "/redfish/v1/Messages/1", "MessageId": "Base.1.8.Success", "Severity": "OK"}`),
+				objectResponse(`W/"2"`, `{"@odata.id": "/redfish/v1/Messages/1", "MessageId": "Base.1.8.Created"}`),
+				objectResponse("", `not json`),
+			},
+		},
+	}
+
+	message, err := GetObject[Message](testClient, "/redfish/v1/Messages/1")
+	if err != nil {
+		t.Fatalf("Error getting the object: %v", err)
+	}
+	if err := message.Refresh(); err != nil {
+		t.Fatalf("Error refreshing the object: %v", err)
+	}
+	if message.MessageID != "Base.1.8.Created" || message.Severity != "" || message.ETag() != `W/"2"` || message.Client != testClient {
+		t.Errorf("Unexpected refreshed object: %+v", message)
+	}
+	if !strings.Contains(string(message.RawJSON()), "Base.1.8.Created") {
+		t.Errorf("Unexpected raw JSON: %s", message.RawJSON())
+	}
+
+	if err := message.Refresh(); err == nil || message.MessageID != "Base.1.8.Created" {
+		t.Errorf("A failed refresh should leave the object unchanged, got: %v", err)
+	}
+
+	copied := *message
+	if err := copied.Refresh(); err == nil {
+		t.Error("Copies should not be refreshed")
+	}
+}
+
+// completedMessage is a Message with a property read from another resource.
+type completedMessage struct {
+	Message
+	Related string
+}
+
+func (m *completedMessage) Complete(c Client) {
+	related, err := GetObject[Message](c, m.ODataID+"/Related")
+	if err == nil {
+		m.Related = related.MessageID
+	}
+}
+
+// TestRefreshCompleter tests the properties read from other resources are
+// read again on refresh.
+func TestRefreshCompleter(t *testing.T) {
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				objectResponse("", `{"@odata.id": "/redfish/v1/Messages/1"}`),
+				objectResponse("", `{"MessageId": "Base.1.8.Success"}`),
+				objectResponse("", `{"@odata.id": "/redfish/v1/Messages/1"}`),
+				objectResponse("", `{"MessageId": "Base.1.8.Created"}`),
+			},
+		},
+	}
+
+	message, err := GetObject[completedMessage](testClient, "/redfish/v1/Messages/1")
+	if err != nil || message.Related != "Base.1.8.Success" {
+		t.Fatalf("Expected the related message to be read, got: %+v %v", message, err)
+	}
+	if err := message.Refresh(); err != nil || message.Related != "Base.1.8.Created" {
+		t.Errorf("Expected the related message to be read again, got: %+v %v", message, err)
+	}
+	if calls := testClient.CapturedCalls(); len(calls) != 4 || calls[3].URL != "/redfish/v1/Messages/1/Related" {
+		t.Errorf("Unexpected calls: %+v", calls)
+	}
+}
+
+// TestDecodeOem tests the Oem property is decoded from the raw JSON.
+func TestDecodeOem(t *testing.T) {
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				objectResponse("", `{"@odata.id": "/redfish/v1/Messages/1", "Oem": {"Contoso": {"Code": 42}}}`),
+				objectResponse("", `{"@odata.id": "/redfish/v1/Messages/2"}`),
+			},
+		},
+	}
+
+	var oem struct {
+		Contoso struct {
+			Code int
+		}
+	}
+	message, err := GetObject[Message](testClient, "/redfish/v1/Messages/1")
+	if err != nil {
+		t.Fatalf("Error getting the object: %v", err)
+	}
+	if err := message.DecodeOem(&oem); err != nil || oem.Contoso.Code != 42 {
+		t.Errorf("Unexpected Oem: %+v, %v", oem, err)
+	}
+
+	message, err = GetObject[Message](testClient, "/redfish/v1/Messages/2")
+	if err != nil {
+		t.Fatalf("Error getting the object: %v", err)
+	}
+	if err := message.DecodeOem(&oem); err != nil || oem.Contoso.Code != 42 {
+		t.Errorf("A missing Oem should leave the target unchanged: %+v, %v", oem, err)
+	}
+
+	if err := (&Message{}).DecodeOem(&oem); err == nil {
+		t.Error("Objects not read from the service have no Oem to decode")
+	}
+}
@@ -0,0 +1,299 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// QueryFeatures lists the query parameters supported by a service, as
+// advertised in the ProtocolFeaturesSupported property of the service root.
+type QueryFeatures struct {
+	// Select indicates support for the $select query parameter.
+	Select bool
+	// Filter indicates support for the $filter query parameter.
+	Filter bool
+	// TopSkip indicates support for the $top and $skip query parameters.
+	TopSkip bool
+	// Only indicates support for the only query parameter.
+	Only bool
+}
+
+// QueryFeatureSupporter can be implemented by a Client to tell which query
+// parameters the service supports.
+type QueryFeatureSupporter interface {
+	// SupportedQueryFeatures returns the query parameters the service
+	// supports.
+	SupportedQueryFeatures() QueryFeatures
+}
+
+// supportedQueryFeatures returns the query parameters supported by the
+// client's service. They are all assumed supported when the client does not
+// tell.
+func supportedQueryFeatures(c Client) QueryFeatures {
+	if supporter, ok := c.(QueryFeatureSupporter); ok {
+		return supporter.SupportedQueryFeatures()
+	}
+	return QueryFeatures{Select: true, Filter: true, TopSkip: true, Only: true}
+}
+
+// Filter is a $filter expression. Use the Eq, Ne, Gt, Ge, Lt, Le, And, Or and
+// Not functions to build one with correctly quoted values.
+type Filter string
+
+// filterValue formats a value for use in a $filter expression. Strings are
+// enclosed in single quotes, with embedded quotes doubled.
+func filterValue(value interface{}) string {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String:
+		return "'" + strings.ReplaceAll(v.String(), "'", "''") + "'"
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	}
+	return filterValue(fmt.Sprintf("%v", value))
+}
+
+// comparison builds a comparison between a property and a value.
+func comparison(property, operator string, value interface{}) Filter {
+	return Filter(fmt.Sprintf("%s %s %s", property, operator, filterValue(value)))
+}
+
+// Eq matches resources where the property equals the value.
+func Eq(property string, value interface{}) Filter {
+	return comparison(property, "eq", value)
+}
+
+// Ne matches resources where the property does not equal the value.
+func Ne(property string, value interface{}) Filter {
+	return comparison(property, "ne", value)
+}
+
+// Gt matches resources where the property is greater than the value.
+func Gt(property string, value interface{}) Filter {
+	return comparison(property, "gt", value)
+}
+
+// Ge matches resources where the property is greater than or equal to the
+// value.
+func Ge(property string, value interface{}) Filter {
+	return comparison(property, "ge", value)
+}
+
+// Lt matches resources where the property is less than the value.
+func Lt(property string, value interface{}) Filter {
+	return comparison(property, "lt", value)
+}
+
+// Le matches resources where the property is less than or equal to the
+// value.
+func Le(property string, value interface{}) Filter {
+	return comparison(property, "le", value)
+}
+
+// join combines filters with a logical operator.
+func join(operator string, filters []Filter) Filter {
+	parts := make([]string, 0, len(filters))
+	for _, filter := range filters {
+		if filter != "" {
+			parts = append(parts, "("+string(filter)+")")
+		}
+	}
+	if len(parts) == 1 {
+		return Filter(strings.TrimSuffix(strings.TrimPrefix(parts[0], "("), ")"))
+	}
+	return Filter(strings.Join(parts, " "+operator+" "))
+}
+
+// And matches resources matching all of the filters.
+func And(filters ...Filter) Filter {
+	return join("and", filters)
+}
+
+// Or matches resources matching any of the filters.
+func Or(filters ...Filter) Filter {
+	return join("or", filters)
+}
+
+// Not matches resources not matching the filter.
+func Not(filter Filter) Filter {
+	return Filter("not (" + string(filter) + ")")
+}
+
+// Query builds the OData query parameters of a request.
+type Query struct {
+	selectProperties []string
+	filter           Filter
+	top              int
+	skip             int
+	hasTop           bool
+	hasSkip          bool
+	only             bool
+}
+
+// NewQuery creates an empty query.
+func NewQuery() *Query {
+	return &Query{}
+}
+
+// Select limits the properties returned to the ones listed.
+func (q *Query) Select(properties ...string) *Query {
+	q.selectProperties = append(q.selectProperties, properties...)
+	return q
+}
+
+// Filter only returns the collection members matching the filter.
+func (q *Query) Filter(filter Filter) *Query {
+	q.filter = filter
+	return q
+}
+
+// Top limits the number of collection members returned.
+func (q *Query) Top(count int) *Query {
+	q.top = count
+	q.hasTop = true
+	return q
+}
+
+// Skip skips the given number of collection members.
+func (q *Query) Skip(count int) *Query {
+	q.skip = count
+	q.hasSkip = true
+	return q
+}
+
+// Page selects the given page (starting at 0) of size members. It is a
+// shortcut for Skip(page*size).Top(size).
+func (q *Query) Page(page, size int) *Query {
+	return q.Skip(page * size).Top(size)
+}
+
+// Only returns the single member of a collection instead of the collection.
+// The result is the member itself, so it is read with GetObject and cannot be
+// used with GetCollection or the ListReferenced helpers.
+func (q *Query) Only() *Query {
+	q.only = true
+	return q
+}
+
+// Validate checks the query is well formed and only uses the query
+// parameters supported by the service.
+func (q *Query) Validate(features QueryFeatures) error {
+	if q.hasTop && q.top < 0 {
+		return fmt.Errorf("invalid $top value: %d", q.top)
+	}
+	if q.hasSkip && q.skip < 0 {
+		return fmt.Errorf("invalid $skip value: %d", q.skip)
+	}
+
+	unsupported := func(name string) error {
+		return fmt.Errorf("service does not support the %s query parameter", name)
+	}
+	if len(q.selectProperties) > 0 && !features.Select {
+		return unsupported("$select")
+	}
+	if q.filter != "" && !features.Filter {
+		return unsupported("$filter")
+	}
+	if (q.hasTop || q.hasSkip) && !features.TopSkip {
+		return unsupported("$top/$skip")
+	}
+	if q.only && !features.Only {
+		return unsupported("only")
+	}
+
+	return nil
+}
+
+// isQueryValueSafe reports whether the byte can be left unescaped in a query
+// value. Characters used by OData expressions are kept readable.
+func isQueryValueSafe(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return true
+	}
+	return strings.IndexByte("-._~!$'()*,;:@/", c) >= 0
+}
+
+// escapeQueryValue percent-encodes a query parameter value. Spaces are
+// encoded as %20 since some services do not decode '+'.
+func escapeQueryValue(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if isQueryValueSafe(c) {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// Encode returns the encoded query string, without the leading '?'.
+func (q *Query) Encode() string {
+	var params []string
+	if q.only {
+		params = append(params, "only")
+	}
+	if len(q.selectProperties) > 0 {
+		params = append(params, "$select="+escapeQueryValue(strings.Join(q.selectProperties, ",")))
+	}
+	if q.filter != "" {
+		params = append(params, "$filter="+escapeQueryValue(string(q.filter)))
+	}
+	if q.hasTop {
+		params = append(params, "$top="+strconv.Itoa(q.top))
+	}
+	if q.hasSkip {
+		params = append(params, "$skip="+strconv.Itoa(q.skip))
+	}
+	return strings.Join(params, "&")
+}
+
+// Apply appends the query to the uri.
+func (q *Query) Apply(uri string) string {
+	encoded := q.Encode()
+	if encoded == "" {
+		return uri
+	}
+
+	separator := "?"
+	if strings.Contains(uri, "?") {
+		separator = "&"
+	}
+	return uri + separator + encoded
+}
+
+// ApplyQuery checks the query against the features supported by the client's
+// service, if known, and appends it to the uri. The result can be passed as
+// the link of any of the ListReferenced helpers, unless the query uses Only.
+func ApplyQuery(c Client, uri string, q *Query) (string, error) {
+	if q == nil {
+		return uri, nil
+	}
+
+	if err := q.Validate(supportedQueryFeatures(c)); err != nil {
+		return "", err
+	}
+
+	return q.Apply(uri), nil
+}
+
+// WithQuery adds the query parameters to the collection request.
+func WithQuery(q *Query) CollectionOption {
+	return func(o *collectionOptions) {
+		o.query = q
+	}
+}
@@ -0,0 +1,142 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"net/http"
+	"testing"
+)
+
+// queryClient is a TestClient with a limited set of query features.
+type queryClient struct {
+	TestClient
+	features QueryFeatures
+}
+
+func (c *queryClient) SupportedQueryFeatures() QueryFeatures {
+	return c.features
+}
+
+// TestQueryEncode tests the encoding of the query parameters.
+func TestQueryEncode(t *testing.T) {
+	query := NewQuery().
+		Select("Id", "Severity").
+		Filter(And(Eq("Severity", CriticalHealth), Gt("Created", "2021-01-01T00:00:00Z"))).
+		Page(2, 50)
+
+	expected := "$select=Id,Severity" +
+		"&$filter=(Severity%20eq%20'Critical')%20and%20(Created%20gt%20'2021-01-01T00:00:00Z')" +
+		"&$top=50&$skip=100"
+	if encoded := query.Encode(); encoded != expected {
+		t.Errorf("Unexpected encoding:\n%s\nexpected:\n%s", encoded, expected)
+	}
+
+	if uri := query.Apply("/redfish/v1/Entries?only"); uri != "/redfish/v1/Entries?only&"+expected {
+		t.Errorf("Unexpected uri: %s", uri)
+	}
+
+	if uri := NewQuery().Apply("/redfish/v1/Entries"); uri != "/redfish/v1/Entries" {
+		t.Errorf("Empty query should not change the uri: %s", uri)
+	}
+}
+
+// TestFilterValues tests values are quoted and escaped.
+func TestFilterValues(t *testing.T) {
+	tests := []struct {
+		filter   Filter
+		expected string
+	}{
+		{Eq("Name", "O'Brien"), "Name eq 'O''Brien'"},
+		{Ne("Enabled", true), "Enabled ne true"},
+		{Le("Count", 3), "Count le 3"},
+		{Ge("Reading", 1.5), "Reading ge 1.5"},
+		{Or(Lt("Id", 10)), "Id lt 10"},
+		{Not(Eq("State", EnabledState)), "not (State eq 'Enabled')"},
+	}
+
+	for _, test := range tests {
+		if string(test.filter) != test.expected {
+			t.Errorf("Expected %s, got %s", test.expected, test.filter)
+		}
+	}
+
+	if escaped := escapeQueryValue("a&b=c #d+e"); escaped != "a%26b%3Dc%20%23d%2Be" {
+		t.Errorf("Unexpected escaping: %s", escaped)
+	}
+}
+
+// TestQueryValidate tests queries are checked against the service features.
+func TestQueryValidate(t *testing.T) {
+	features := QueryFeatures{Select: true}
+	if err := NewQuery().Select("Id").Validate(features); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	if err := NewQuery().Filter(Eq("Id", "1")).Validate(features); err == nil {
+		t.Error("$filter should not be supported")
+	}
+
+	if err := NewQuery().Top(10).Validate(features); err == nil {
+		t.Error("$top should not be supported")
+	}
+
+	if err := NewQuery().Only().Validate(features); err == nil {
+		t.Error("only should not be supported")
+	}
+
+	if err := NewQuery().Skip(-1).Validate(QueryFeatures{TopSkip: true}); err == nil {
+		t.Error("Negative $skip should be rejected")
+	}
+}
+
+// TestGetCollectionWithQuery tests the query is added to the collection
+// request.
+func TestGetCollectionWithQuery(t *testing.T) {
+	client := &queryClient{features: QueryFeatures{TopSkip: true}}
+	client.CustomReturnForActions = map[string][]interface{}{
+		http.MethodGet: {testResponse(expandedCollectionBody)},
+	}
+
+	_, err := GetCollection(client, "/redfish/v1/Entries", WithQuery(NewQuery().Top(2)))
+	if err != nil {
+		t.Fatalf("Error getting collection: %s", err)
+	}
+
+	calls := client.CapturedCalls()
+	if calls[0].URL != "/redfish/v1/Entries?$top=2" {
+		t.Errorf("Unexpected request: %s", calls[0].URL)
+	}
+
+	_, err = GetCollection(client, "/redfish/v1/Entries", WithQuery(NewQuery().Select("Id")))
+	if err == nil {
+		t.Error("Unsupported query should fail")
+	}
+}
+
+// TestGetCollectionOnly tests collections are not requested with only, which
+// returns a member instead.
+func TestGetCollectionOnly(t *testing.T) {
+	client := &TestClient{}
+
+	_, err := GetCollection(client, "/redfish/v1/Entries", WithQuery(NewQuery().Only()))
+	if err != errOnlyCollection {
+		t.Errorf("Expected only to be rejected, got: %v", err)
+	}
+	link, err := ApplyQuery(client, "/redfish/v1/Entries", NewQuery().Only().Select("Id"))
+	if err != nil {
+		t.Fatalf("Error applying the query: %v", err)
+	}
+	_, err = ListReferenced[Message](client, link)
+	if err != errOnlyCollection {
+		t.Errorf("Expected only to be rejected, got: %v", err)
+	}
+	if calls := client.CapturedCalls(); len(calls) != 0 {
+		t.Errorf("Expected no request, got: %+v", calls)
+	}
+
+	if hasOnlyQuery("/redfish/v1/Entries?$filter=only%20eq%201") {
+		t.Error("Expected only in other parameters to be allowed")
+	}
+}
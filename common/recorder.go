@@ -0,0 +1,526 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultRedactedProperties are the properties whose values are replaced in
+// the fixtures saved by a Recorder. A property is redacted when its name
+// contains one of them, ignoring case, so Password also covers NewPassword.
+var DefaultRedactedProperties = []string{"Password", "UserName", "Token", "SerialNumber"}
+
+// redactedValue replaces the values of redacted properties.
+const redactedValue = "REDACTED"
+
+// redactedHeaders are the headers left out of fixtures.
+var redactedHeaders = []string{"X-Auth-Token", "Authorization", "Cookie", "Set-Cookie"}
+
+// Exchange is a request sent to the service and its response, as saved in a
+// fixture file.
+type Exchange struct {
+	// Method is the method of the request.
+	Method string
+	// URL is the URL of the request.
+	URL string
+	// Request is the JSON payload of the request, if any. Multipart payloads
+	// are not saved.
+	Request json.RawMessage `json:",omitempty"`
+	// StatusCode is the status code of the response.
+	StatusCode int `json:",omitempty"`
+	// Header is the header of the response.
+	Header http.Header `json:",omitempty"`
+	// Body is the body of the response, if it is JSON.
+	Body json.RawMessage `json:",omitempty"`
+	// Text is the body of the response, if it is not JSON.
+	Text string `json:",omitempty"`
+	// Error is the error of a request that got no response.
+	Error string `json:",omitempty"`
+}
+
+// clientFixture is the name of the fixture file saving the answers of the
+// recorded client to the optional Client interfaces.
+const clientFixture = "client.json"
+
+// RecordedClient is what the recorded client answered to the optional Client
+// interfaces, such as QueryFeatureSupporter, as saved in the client.json file
+// of a fixture directory.
+type RecordedClient struct {
+	// CollectionConcurrency is the collection concurrency limit.
+	CollectionConcurrency int
+	// CollectionExpandQuery is the $expand value supported for collections.
+	CollectionExpandQuery string
+	// ExpandMode is the expansion mode used for collections.
+	ExpandMode ExpandMode
+	// QueryFeatures are the query parameters supported by the service.
+	QueryFeatures QueryFeatures
+}
+
+// recordClient returns the answers of c to the optional Client interfaces.
+func recordClient(c Client) RecordedClient {
+	recorded := RecordedClient{
+		CollectionConcurrency: collectionConcurrency(c),
+		ExpandMode:            ExpandAuto,
+		QueryFeatures:         supportedQueryFeatures(c),
+	}
+	if supporter, ok := c.(ExpandQuerySupporter); ok {
+		recorded.CollectionExpandQuery = supporter.CollectionExpandQuery()
+	}
+	if provider, ok := c.(ExpandModeProvider); ok {
+		recorded.ExpandMode = provider.ExpandMode()
+	}
+	return recorded
+}
+
+// fixtureFiles returns the exchange fixture files of a directory, sorted.
+func fixtureFiles(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	exchanges := files[:0]
+	for _, file := range files {
+		if filepath.Base(file) != clientFixture {
+			exchanges = append(exchanges, file)
+		}
+	}
+	sort.Strings(exchanges)
+	return exchanges, nil
+}
+
+// Recorder is a Client saving the requests sent through it and the responses
+// of the service to a fixture directory, one file per request, so they can be
+// served back by a ReplayClient in tests:
+//
+//	recorder, err := common.NewRecorder(c, "testdata/ilo5-2.72")
+//	if err != nil {
+//		return err
+//	}
+//	system, err := redfish.GetComputerSystem(recorder, "/redfish/v1/Systems/1")
+//
+// Credentials and serial numbers are redacted from the JSON payloads and
+// bodies saved, and the authentication headers are left out. Values in URLs
+// are saved as they are. The headers of the requests are not saved, see
+// ReplayClient for what this means for conditional requests.
+//
+// The Recorder answers the optional Client interfaces, such as
+// QueryFeatureSupporter or Uploader, as the recorded client does, and saves
+// these answers to client.json when it is created.
+type Recorder struct {
+	// Redacted are the properties redacted from the fixtures. It defaults to
+	// DefaultRedactedProperties.
+	Redacted []string
+
+	client Client
+	dir    string
+
+	mu   sync.Mutex
+	next int
+}
+
+// NewRecorder returns a Recorder sending the requests with c and saving them
+// to dir, which is created if needed. Files already in dir are kept, and the
+// new ones are numbered after them. The answers of c to the optional Client
+// interfaces are saved again.
+func NewRecorder(c Client, dir string) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil { // nolint:gosec
+		return nil, err
+	}
+	files, err := fixtureFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(recordClient(c), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, clientFixture), append(data, '\n'), 0o644); err != nil { // nolint:gosec
+		return nil, err
+	}
+
+	return &Recorder{
+		Redacted: DefaultRedactedProperties,
+		client:   c,
+		dir:      dir,
+		next:     len(files),
+	}, nil
+}
+
+// CollectionConcurrency returns the concurrency limit of the recorded client.
+func (r *Recorder) CollectionConcurrency() int {
+	return collectionConcurrency(r.client)
+}
+
+// CollectionExpandQuery returns the $expand value supported by the recorded
+// client.
+func (r *Recorder) CollectionExpandQuery() string {
+	if supporter, ok := r.client.(ExpandQuerySupporter); ok {
+		return supporter.CollectionExpandQuery()
+	}
+	return ""
+}
+
+// ExpandMode returns the expansion mode of the recorded client.
+func (r *Recorder) ExpandMode() ExpandMode {
+	if provider, ok := r.client.(ExpandModeProvider); ok {
+		return provider.ExpandMode()
+	}
+	return ExpandAuto
+}
+
+// SupportedQueryFeatures returns the query parameters supported by the
+// recorded client.
+func (r *Recorder) SupportedQueryFeatures() QueryFeatures {
+	return supportedQueryFeatures(r.client)
+}
+
+// Context returns the context of the recorded client.
+func (r *Recorder) Context() context.Context {
+	return clientContext(r.client)
+}
+
+// PostBinary performs a Post request with a raw body and records it. The body
+// is not saved.
+func (r *Recorder) PostBinary(ctx context.Context, url string, body io.Reader, contentType string, progress ProgressFunc, customHeaders map[string]string) (*http.Response, error) {
+	uploader, err := clientUploader(r.client)
+	if err != nil {
+		return nil, err
+	}
+	return r.record(http.MethodPost, url, nil, func() (*http.Response, error) {
+		return uploader.PostBinary(ctx, url, body, contentType, progress, customHeaders)
+	})
+}
+
+// PostMultipartParts performs a multipart/form-data Post request and records
+// it. The parts are not saved.
+func (r *Recorder) PostMultipartParts(ctx context.Context, url string, parts []MultipartPart, progress ProgressFunc, customHeaders map[string]string) (*http.Response, error) {
+	uploader, err := clientUploader(r.client)
+	if err != nil {
+		return nil, err
+	}
+	return r.record(http.MethodPost, url, nil, func() (*http.Response, error) {
+		return uploader.PostMultipartParts(ctx, url, parts, progress, customHeaders)
+	})
+}
+
+// record sends a request with send and saves it with its response.
+func (r *Recorder) record(method, url string, payload interface{}, send func() (*http.Response, error)) (*http.Response, error) {
+	exchange := Exchange{Method: method, URL: url}
+	if payload != nil {
+		request, err := redactPayload(payload, r.Redacted)
+		if err != nil {
+			return nil, err
+		}
+		exchange.Request = request
+	}
+
+	resp, err := send()
+	switch e, ok := asError(err); {
+	case ok && e.HTTPReturnedStatusCode != 0:
+		exchange.StatusCode = e.HTTPReturnedStatusCode
+		exchange.setBody(e.rawData, r.Redacted)
+	case err != nil:
+		exchange.Error = err.Error()
+	default:
+		exchange.StatusCode = resp.StatusCode
+		exchange.Header = resp.Header.Clone()
+		for _, name := range redactedHeaders {
+			exchange.Header.Del(name)
+		}
+		if resp.Body != nil {
+			body, readErr := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+			if readErr != nil {
+				return nil, readErr
+			}
+			exchange.setBody(body, r.Redacted)
+		}
+	}
+
+	if saveErr := r.save(&exchange); saveErr != nil {
+		if resp != nil && resp.Body != nil {
+			resp.Body.Close()
+		}
+		return nil, fmt.Errorf("error recording %s %s: %w", method, url, saveErr)
+	}
+	return resp, err
+}
+
+// fixtureName matches the characters replaced in fixture file names.
+var fixtureName = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// maxFixtureName is the maximum length of the URL part of a fixture name.
+const maxFixtureName = 100
+
+// save writes an exchange to the next fixture file.
+func (r *Recorder) save(exchange *Exchange) error {
+	data, err := json.MarshalIndent(exchange, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.next++
+	number := r.next
+	r.mu.Unlock()
+
+	name := strings.Trim(fixtureName.ReplaceAllString(exchange.URL, "_"), "_")
+	if len(name) > maxFixtureName {
+		name = name[:maxFixtureName]
+	}
+	file := filepath.Join(r.dir, fmt.Sprintf("%04d-%s-%s.json", number, exchange.Method, name))
+	return os.WriteFile(file, append(data, '\n'), 0o644) // nolint:gosec
+}
+
+// setBody sets the body of the response, redacted if it is JSON.
+func (exchange *Exchange) setBody(body []byte, redacted []string) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return
+	}
+	if !json.Valid(body) {
+		exchange.Text = string(body)
+		return
+	}
+	redactedBody, err := redactJSON(body, redacted)
+	if err != nil {
+		exchange.Text = string(body)
+		return
+	}
+	exchange.Body = redactedBody
+}
+
+// redactPayload returns the JSON of a request payload, redacted.
+func redactPayload(payload interface{}, redacted []string) (json.RawMessage, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return redactJSON(data, redacted)
+}
+
+// redactJSON replaces the values of the redacted properties in a JSON
+// document. The document is returned compact with its keys sorted, so equal
+// documents give equal bytes.
+func redactJSON(data []byte, redacted []string) (json.RawMessage, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+	return json.Marshal(redactValue(document, redacted))
+}
+
+// redactValue replaces the values of the redacted properties in a decoded
+// JSON document.
+func redactValue(value interface{}, redacted []string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if isRedacted(key, redacted) && item != nil {
+				v[key] = redactedValue
+			} else {
+				v[key] = redactValue(item, redacted)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item, redacted)
+		}
+	}
+	return value
+}
+
+// isRedacted reports whether a property is redacted.
+func isRedacted(key string, redacted []string) bool {
+	key = strings.ToLower(key)
+	for _, name := range redacted {
+		if strings.Contains(key, strings.ToLower(name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get performs a GET request against the Redfish service and records it.
+func (r *Recorder) Get(url string) (*http.Response, error) {
+	return r.record(http.MethodGet, url, nil, func() (*http.Response, error) {
+		return r.client.Get(url)
+	})
+}
+
+// GetWithHeaders performs a GET request against the Redfish service and records it.
+func (r *Recorder) GetWithHeaders(url string, customHeaders map[string]string) (*http.Response, error) {
+	return r.record(http.MethodGet, url, nil, func() (*http.Response, error) {
+		return r.client.GetWithHeaders(url, customHeaders)
+	})
+}
+
+// Post performs a Post request against the Redfish service and records it.
+func (r *Recorder) Post(url string, payload interface{}) (*http.Response, error) {
+	return r.record(http.MethodPost, url, payload, func() (*http.Response, error) {
+		return r.client.Post(url, payload)
+	})
+}
+
+// PostWithHeaders performs a Post request against the Redfish service and records it.
+func (r *Recorder) PostWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return r.record(http.MethodPost, url, payload, func() (*http.Response, error) {
+		return r.client.PostWithHeaders(url, payload, customHeaders)
+	})
+}
+
+// PostMultipart performs a Post request against the Redfish service and records it.
+func (r *Recorder) PostMultipart(url string, payload map[string]io.Reader) (*http.Response, error) {
+	return r.record(http.MethodPost, url, nil, func() (*http.Response, error) {
+		return r.client.PostMultipart(url, payload)
+	})
+}
+
+// PostMultipartWithHeaders performs a Post request against the Redfish service and records it.
+func (r *Recorder) PostMultipartWithHeaders(url string, payload map[string]io.Reader, customHeaders map[string]string) (*http.Response, error) {
+	return r.record(http.MethodPost, url, nil, func() (*http.Response, error) {
+		return r.client.PostMultipartWithHeaders(url, payload, customHeaders)
+	})
+}
+
+// Put performs a Put request against the Redfish service and records it.
+func (r *Recorder) Put(url string, payload interface{}) (*http.Response, error) {
+	return r.record(http.MethodPut, url, payload, func() (*http.Response, error) {
+		return r.client.Put(url, payload)
+	})
+}
+
+// PutWithHeaders performs a Put request against the Redfish service and records it.
+func (r *Recorder) PutWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return r.record(http.MethodPut, url, payload, func() (*http.Response, error) {
+		return r.client.PutWithHeaders(url, payload, customHeaders)
+	})
+}
+
+// Patch performs a Patch request against the Redfish service and records it.
+func (r *Recorder) Patch(url string, payload interface{}) (*http.Response, error) {
+	return r.record(http.MethodPatch, url, payload, func() (*http.Response, error) {
+		return r.client.Patch(url, payload)
+	})
+}
+
+// PatchWithHeaders performs a Patch request against the Redfish service and records it.
+func (r *Recorder) PatchWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return r.record(http.MethodPatch, url, payload, func() (*http.Response, error) {
+		return r.client.PatchWithHeaders(url, payload, customHeaders)
+	})
+}
+
+// Delete performs a Delete request against the Redfish service and records it.
+func (r *Recorder) Delete(url string) (*http.Response, error) {
+	return r.record(http.MethodDelete, url, nil, func() (*http.Response, error) {
+		return r.client.Delete(url)
+	})
+}
+
+// DeleteWithHeaders performs a Delete request against the Redfish service and records it.
+func (r *Recorder) DeleteWithHeaders(url string, customHeaders map[string]string) (*http.Response, error) {
+	return r.record(http.MethodDelete, url, nil, func() (*http.Response, error) {
+		return r.client.DeleteWithHeaders(url, customHeaders)
+	})
+}
+
+// GetContext performs a GET request against the Redfish service and records it.
+func (r *Recorder) GetContext(ctx context.Context, url string) (*http.Response, error) {
+	return r.record(http.MethodGet, url, nil, func() (*http.Response, error) {
+		return r.client.GetContext(ctx, url)
+	})
+}
+
+// GetWithHeadersContext performs a GET request against the Redfish service and records it.
+func (r *Recorder) GetWithHeadersContext(ctx context.Context, url string, customHeaders map[string]string) (*http.Response, error) {
+	return r.record(http.MethodGet, url, nil, func() (*http.Response, error) {
+		return r.client.GetWithHeadersContext(ctx, url, customHeaders)
+	})
+}
+
+// PostContext performs a Post request against the Redfish service and records it.
+func (r *Recorder) PostContext(ctx context.Context, url string, payload interface{}) (*http.Response, error) {
+	return r.record(http.MethodPost, url, payload, func() (*http.Response, error) {
+		return r.client.PostContext(ctx, url, payload)
+	})
+}
+
+// PostWithHeadersContext performs a Post request against the Redfish service and records it.
+func (r *Recorder) PostWithHeadersContext(ctx context.Context, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return r.record(http.MethodPost, url, payload, func() (*http.Response, error) {
+		return r.client.PostWithHeadersContext(ctx, url, payload, customHeaders)
+	})
+}
+
+// PostMultipartContext performs a Post request against the Redfish service and records it.
+func (r *Recorder) PostMultipartContext(ctx context.Context, url string, payload map[string]io.Reader) (*http.Response, error) {
+	return r.record(http.MethodPost, url, nil, func() (*http.Response, error) {
+		return r.client.PostMultipartContext(ctx, url, payload)
+	})
+}
+
+// PostMultipartWithHeadersContext performs a Post request against the Redfish service and records it.
+func (r *Recorder) PostMultipartWithHeadersContext(ctx context.Context, url string, payload map[string]io.Reader, customHeaders map[string]string) (*http.Response, error) {
+	return r.record(http.MethodPost, url, nil, func() (*http.Response, error) {
+		return r.client.PostMultipartWithHeadersContext(ctx, url, payload, customHeaders)
+	})
+}
+
+// PutContext performs a Put request against the Redfish service and records it.
+func (r *Recorder) PutContext(ctx context.Context, url string, payload interface{}) (*http.Response, error) {
+	return r.record(http.MethodPut, url, payload, func() (*http.Response, error) {
+		return r.client.PutContext(ctx, url, payload)
+	})
+}
+
+// PutWithHeadersContext performs a Put request against the Redfish service and records it.
+func (r *Recorder) PutWithHeadersContext(ctx context.Context, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return r.record(http.MethodPut, url, payload, func() (*http.Response, error) {
+		return r.client.PutWithHeadersContext(ctx, url, payload, customHeaders)
+	})
+}
+
+// PatchContext performs a Patch request against the Redfish service and records it.
+func (r *Recorder) PatchContext(ctx context.Context, url string, payload interface{}) (*http.Response, error) {
+	return r.record(http.MethodPatch, url, payload, func() (*http.Response, error) {
+		return r.client.PatchContext(ctx, url, payload)
+	})
+}
+
+// PatchWithHeadersContext performs a Patch request against the Redfish service and records it.
+func (r *Recorder) PatchWithHeadersContext(ctx context.Context, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return r.record(http.MethodPatch, url, payload, func() (*http.Response, error) {
+		return r.client.PatchWithHeadersContext(ctx, url, payload, customHeaders)
+	})
+}
+
+// DeleteContext performs a Delete request against the Redfish service and records it.
+func (r *Recorder) DeleteContext(ctx context.Context, url string) (*http.Response, error) {
+	return r.record(http.MethodDelete, url, nil, func() (*http.Response, error) {
+		return r.client.DeleteContext(ctx, url)
+	})
+}
+
+// DeleteWithHeadersContext performs a Delete request against the Redfish service and records it.
+func (r *Recorder) DeleteWithHeadersContext(ctx context.Context, url string, customHeaders map[string]string) (*http.Response, error) {
+	return r.record(http.MethodDelete, url, nil, func() (*http.Response, error) {
+		return r.client.DeleteWithHeadersContext(ctx, url, customHeaders)
+	})
+}
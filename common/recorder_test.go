@@ -0,0 +1,496 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var recordedSystemBody = `{
+	"@odata.id": "/redfish/v1/Systems/1",
+	"Id": "1",
+	"Name": "System",
+	"SerialNumber": "CZ2D1X0ABC",
+	"Oem": {"Hpe": {"ChassisSerialNumber": "CZ2D1X0ABC", "PostState": "FinishedPost"}}
+}`
+
+type recordedSystem struct {
+	Entity
+	SerialNumber string
+}
+
+// TestRecorderReplay tests recorded requests are redacted and served back.
+func TestRecorderReplay(t *testing.T) {
+	dir := t.TempDir()
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				objectResponse(`W/"1"`, recordedSystemBody),
+				objectResponse(`W/"2"`, strings.Replace(recordedSystemBody, `"System"`, `"Renamed"`, 1)),
+			},
+			http.MethodPost: {
+				&http.Response{StatusCode: http.StatusCreated, Header: http.Header{"X-Auth-Token": {"secret"}}, Body: io.NopCloser(strings.NewReader(""))},
+				&http.Response{StatusCode: http.StatusBadRequest, Body: io.NopCloser(strings.NewReader(`{"error": {"code": "Base.1.8.PropertyMissing"}}`))},
+			},
+		},
+	}
+	recorder, err := NewRecorder(testClient, dir)
+	if err != nil {
+		t.Fatalf("Error creating the recorder: %v", err)
+	}
+
+	system, err := GetObject[recordedSystem](recorder, "/redfish/v1/Systems/1")
+	if err != nil || system.SerialNumber != "CZ2D1X0ABC" {
+		t.Fatalf("Expected the system to be returned as it was read, got: %+v %v", system, err)
+	}
+	_, err = GetObject[recordedSystem](recorder, "/redfish/v1/Systems/1")
+	if err != nil {
+		t.Fatalf("Error getting the system again: %v", err)
+	}
+	login := map[string]string{"UserName": "admin", "Password": "secret"}
+	resp, err := recorder.Post("/redfish/v1/SessionService/Sessions", login)
+	if err != nil {
+		t.Fatalf("Error logging in: %v", err)
+	}
+	resp.Body.Close()
+	_, err = recorder.Post("/redfish/v1/Systems/1/Actions/ComputerSystem.Reset", map[string]string{})
+	if err == nil {
+		t.Fatalf("Expected the reset to fail")
+	}
+
+	files, _ := fixtureFiles(dir)
+	if len(files) != 4 || filepath.Base(files[0]) != "0001-GET-redfish_v1_Systems_1.json" {
+		t.Fatalf("Unexpected fixtures: %v", files)
+	}
+	for _, file := range files {
+		data, _ := os.ReadFile(file)
+		if strings.Contains(string(data), "CZ2D1X0ABC") || strings.Contains(string(data), "secret") ||
+			strings.Contains(string(data), "admin") {
+			t.Errorf("Expected %s to be redacted, got: %s", file, data)
+		}
+	}
+
+	c, err := NewReplayClient(dir)
+	if err != nil {
+		t.Fatalf("Error reading the fixtures: %v", err)
+	}
+	for _, name := range []string{"System", "Renamed", "Renamed"} {
+		system, err = GetObject[recordedSystem](c, "/redfish/v1/Systems/1")
+		if err != nil || system.Name != name || system.SerialNumber != redactedValue {
+			t.Errorf("Expected the recorded %s system, got: %+v %v", name, system, err)
+		}
+	}
+	if system.ETag() != `W/"2"` {
+		t.Errorf("Expected the recorded ETag, got: %s", system.ETag())
+	}
+
+	resp, err = c.Post("/redfish/v1/SessionService/Sessions", map[string]string{"UserName": "operator", "Password": "other"})
+	if err != nil || resp.StatusCode != http.StatusCreated || resp.Header.Get("X-Auth-Token") != "" {
+		t.Errorf("Expected the recorded login without its token, got: %+v %v", resp, err)
+	}
+	_, err = c.Post("/redfish/v1/Systems/1/Actions/ComputerSystem.Reset", map[string]string{})
+	if !HasMessage(err, "PropertyMissing") || !hasStatus(err, http.StatusBadRequest) {
+		t.Errorf("Expected the recorded error, got: %v", err)
+	}
+	_, err = c.Post("/redfish/v1/Systems/1/Actions/ComputerSystem.Reset", map[string]string{"ResetType": "On"})
+	if err == nil {
+		t.Errorf("Expected a request that was not recorded to fail")
+	}
+}
+
+// featureClient is a TestClient implementing the optional Client interfaces.
+type featureClient struct {
+	*TestClient
+	ctx context.Context
+}
+
+func (c *featureClient) CollectionExpandQuery() string {
+	return "*($levels=1)"
+}
+
+func (c *featureClient) ExpandMode() ExpandMode {
+	return ExpandNever
+}
+
+func (c *featureClient) SupportedQueryFeatures() QueryFeatures {
+	return QueryFeatures{Select: true}
+}
+
+func (c *featureClient) Context() context.Context {
+	return c.ctx
+}
+
+func (c *featureClient) PostBinary(ctx context.Context, url string, r io.Reader, contentType string, progress ProgressFunc, customHeaders map[string]string) (*http.Response, error) {
+	return c.PostContext(ctx, url, nil)
+}
+
+func (c *featureClient) PostMultipartParts(ctx context.Context, url string, parts []MultipartPart, progress ProgressFunc, customHeaders map[string]string) (*http.Response, error) {
+	return c.PostContext(ctx, url, nil)
+}
+
+// TestRecorderClientFeatures tests the optional Client interfaces are
+// forwarded to the recorded client, and their answers served back.
+func TestRecorderClientFeatures(t *testing.T) {
+	dir := t.TempDir()
+	type key struct{}
+	wrapped := &featureClient{
+		TestClient: &TestClient{
+			CustomReturnForActions: map[string][]interface{}{
+				http.MethodPost: {
+					&http.Response{StatusCode: http.StatusAccepted, Header: http.Header{"Location": {"/redfish/v1/TaskService/Tasks/1"}},
+						Body: io.NopCloser(strings.NewReader(""))},
+				},
+			},
+		},
+		ctx: context.WithValue(context.Background(), key{}, "recorded"),
+	}
+	recorder, err := NewRecorder(wrapped, dir)
+	if err != nil {
+		t.Fatalf("Error creating the recorder: %v", err)
+	}
+	expected := RecordedClient{
+		CollectionConcurrency: 1,
+		CollectionExpandQuery: "*($levels=1)",
+		ExpandMode:            ExpandNever,
+		QueryFeatures:         QueryFeatures{Select: true},
+	}
+
+	if recordClient(recorder) != expected || recorder.Context().Value(key{}) != "recorded" {
+		t.Errorf("Expected the answers of the recorded client, got: %+v", recordClient(recorder))
+	}
+	if _, err := ApplyQuery(recorder, "/redfish/v1/Systems", NewQuery().Top(1)); err == nil {
+		t.Error("Expected the query features of the recorded client to be checked")
+	}
+	resp, err := recorder.PostBinary(context.Background(), "/redfish/v1/UpdateService/push", strings.NewReader("image"), "", nil, nil)
+	if err != nil {
+		t.Fatalf("Error uploading: %v", err)
+	}
+	resp.Body.Close()
+	if calls := wrapped.CapturedCalls(); len(calls) != 1 || calls[0].URL != "/redfish/v1/UpdateService/push" {
+		t.Errorf("Expected the upload to be sent by the recorded client, got: %+v", calls)
+	}
+
+	c, err := NewReplayClient(dir)
+	if err != nil {
+		t.Fatalf("Error reading the fixtures: %v", err)
+	}
+	if recordClient(c) != expected {
+		t.Errorf("Expected the recorded answers, got: %+v", recordClient(c))
+	}
+	resp, err = c.PostMultipartParts(context.Background(), "/redfish/v1/UpdateService/push", nil, nil, nil)
+	if err != nil || resp.StatusCode != http.StatusAccepted || resp.Header.Get("Location") != "/redfish/v1/TaskService/Tasks/1" {
+		t.Errorf("Expected the recorded upload, got: %+v %v", resp, err)
+	}
+
+	recorder, err = NewRecorder(&TestClient{}, t.TempDir())
+	if err != nil {
+		t.Fatalf("Error creating the recorder: %v", err)
+	}
+	if _, err := recorder.PostBinary(context.Background(), "/redfish/v1/UpdateService/push", strings.NewReader("image"), "", nil, nil); err == nil {
+		t.Error("Expected uploads to fail when the recorded client does not support them")
+	}
+	c, err = NewReplayClient(t.TempDir())
+	if err != nil {
+		t.Fatalf("Error reading the fixtures: %v", err)
+	}
+	if recordClient(c) != recordClient(nil) {
+		t.Errorf("Expected the answers of a plain client without client.json, got: %+v", recordClient(c))
+	}
+}
+
+// TestRecorderRedaction tests the redacted properties are replaced wherever
+// they are in the payloads and bodies saved.
+func TestRecorderRedaction(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  interface{}
+		body     string
+		request  string
+		response string
+	}{
+		{
+			name:     "top level",
+			payload:  map[string]string{"UserName": "admin", "Password": "secret", "RoleId": "Administrator"},
+			body:     `{"UserName": "admin", "Id": "1"}`,
+			request:  `{"Password":"REDACTED","RoleId":"Administrator","UserName":"REDACTED"}`,
+			response: `{"Id":"1","UserName":"REDACTED"}`,
+		},
+		{
+			name: "nested",
+			payload: map[string]interface{}{
+				"Oem": map[string]interface{}{"Hpe": map[string]string{"NewPassword": "secret", "Mode": "On"}},
+			},
+			body:     `{"Oem": {"Hpe": {"ChassisSerialNumber": "CZ2D1X0ABC", "PostState": "InPost"}}}`,
+			request:  `{"Oem":{"Hpe":{"Mode":"On","NewPassword":"REDACTED"}}}`,
+			response: `{"Oem":{"Hpe":{"ChassisSerialNumber":"REDACTED","PostState":"InPost"}}}`,
+		},
+		{
+			name: "arrays",
+			payload: map[string]interface{}{
+				"Accounts": []map[string]string{{"UserName": "admin"}, {"UserName": "operator"}},
+			},
+			body:     `{"Members": [{"SerialNumber": "A1", "Id": "1"}, {"SerialNumber": "B2", "Id": "2"}], "Tags": ["Token"]}`,
+			request:  `{"Accounts":[{"UserName":"REDACTED"},{"UserName":"REDACTED"}]}`,
+			response: `{"Members":[{"Id":"1","SerialNumber":"REDACTED"},{"Id":"2","SerialNumber":"REDACTED"}],"Tags":["Token"]}`,
+		},
+		{
+			name:     "nulls and objects",
+			payload:  map[string]interface{}{"Password": nil},
+			body:     `{"Token": {"Value": "abc"}, "Count": 12345678901234567890}`,
+			request:  `{"Password":null}`,
+			response: `{"Count":12345678901234567890,"Token":"REDACTED"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			testClient := &TestClient{
+				CustomReturnForActions: map[string][]interface{}{
+					http.MethodPost: {objectResponse("", test.body)},
+				},
+			}
+			recorder, err := NewRecorder(testClient, dir)
+			if err != nil {
+				t.Fatalf("Error creating the recorder: %v", err)
+			}
+			resp, err := recorder.Post("/redfish/v1/Test", test.payload)
+			if err != nil {
+				t.Fatalf("Error posting: %v", err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if string(body) != test.body {
+				t.Errorf("Expected the response to be returned as it was read, got: %s", body)
+			}
+
+			exchange := readFixture(t, filepath.Join(dir, "0001-POST-redfish_v1_Test.json"))
+			if request := compactJSON(t, exchange.Request); request != test.request {
+				t.Errorf("Expected the request %s, got: %s", test.request, request)
+			}
+			if response := compactJSON(t, exchange.Body); response != test.response {
+				t.Errorf("Expected the response %s, got: %s", test.response, response)
+			}
+		})
+	}
+}
+
+// readFixture reads an exchange saved by a Recorder.
+func readFixture(t *testing.T, file string) *Exchange {
+	t.Helper()
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("Error reading the fixture: %v", err)
+	}
+	exchange := new(Exchange)
+	if err := json.Unmarshal(data, exchange); err != nil {
+		t.Fatalf("Error decoding the fixture: %v", err)
+	}
+	return exchange
+}
+
+// compactJSON returns a JSON document without its indentation.
+func compactJSON(t *testing.T, data []byte) string {
+	t.Helper()
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, data); err != nil {
+		t.Fatalf("Error compacting %s: %v", data, err)
+	}
+	return compact.String()
+}
+
+// writeFixtures saves exchanges to dir as a Recorder would.
+func writeFixtures(t *testing.T, dir string, exchanges []Exchange) {
+	t.Helper()
+	for i := range exchanges {
+		data, err := json.Marshal(&exchanges[i])
+		if err != nil {
+			t.Fatalf("Error encoding the fixture: %v", err)
+		}
+		file := filepath.Join(dir, fmt.Sprintf("%04d-%s.json", i+1, exchanges[i].Method))
+		if err := os.WriteFile(file, data, 0o600); err != nil {
+			t.Fatalf("Error writing the fixture: %v", err)
+		}
+	}
+}
+
+// replayed is the expected outcome of a replayed request.
+type replayed struct {
+	statusCode int
+	body       string
+	err        string
+}
+
+// TestReplayClient tests how recorded exchanges are matched and served back.
+func TestReplayClient(t *testing.T) {
+	systemURI := "/redfish/v1/Systems/1"
+	tests := []struct {
+		name      string
+		exchanges []Exchange
+		method    string
+		url       string
+		payload   interface{}
+		headers   map[string]string
+		expected  []replayed
+	}{
+		{
+			name: "served in order",
+			exchanges: []Exchange{
+				{Method: http.MethodGet, URL: systemURI, StatusCode: http.StatusOK, Body: json.RawMessage(`{"Name":"First"}`)},
+				{Method: http.MethodGet, URL: "/redfish/v1/Systems", StatusCode: http.StatusOK, Body: json.RawMessage(`{}`)},
+				{Method: http.MethodGet, URL: systemURI, StatusCode: http.StatusOK, Body: json.RawMessage(`{"Name":"Second"}`)},
+				{Method: http.MethodGet, URL: systemURI, StatusCode: http.StatusOK, Body: json.RawMessage(`{"Name":"Last"}`)},
+			},
+			method: http.MethodGet,
+			url:    systemURI,
+			expected: []replayed{
+				{statusCode: http.StatusOK, body: `{"Name":"First"}`},
+				{statusCode: http.StatusOK, body: `{"Name":"Second"}`},
+				{statusCode: http.StatusOK, body: `{"Name":"Last"}`},
+				{statusCode: http.StatusOK, body: `{"Name":"Last"}`},
+			},
+		},
+		{
+			name: "text body",
+			exchanges: []Exchange{
+				{Method: http.MethodGet, URL: "/redfish/v1/$metadata", StatusCode: http.StatusOK, Text: "<Edmx/>"},
+			},
+			method:   http.MethodGet,
+			url:      "/redfish/v1/$metadata",
+			expected: []replayed{{statusCode: http.StatusOK, body: "<Edmx/>"}},
+		},
+		{
+			name: "error status",
+			exchanges: []Exchange{
+				{Method: http.MethodGet, URL: systemURI, StatusCode: http.StatusServiceUnavailable,
+					Body: json.RawMessage(`{"error":{"code":"Base.1.8.ServiceTemporarilyUnavailable"}}`)},
+				{Method: http.MethodGet, URL: systemURI, StatusCode: http.StatusNotFound},
+			},
+			method: http.MethodGet,
+			url:    systemURI,
+			expected: []replayed{
+				{statusCode: http.StatusServiceUnavailable, err: "ServiceTemporarilyUnavailable"},
+				{statusCode: http.StatusNotFound, err: "404"},
+			},
+		},
+		{
+			name: "transport error",
+			exchanges: []Exchange{
+				{Method: http.MethodGet, URL: systemURI, Error: "connection reset by peer"},
+				{Method: http.MethodGet, URL: systemURI, StatusCode: http.StatusOK, Body: json.RawMessage(`{}`)},
+			},
+			method: http.MethodGet,
+			url:    systemURI,
+			expected: []replayed{
+				{err: "connection reset by peer"},
+				{statusCode: http.StatusOK, body: `{}`},
+			},
+		},
+		{
+			name: "redacted payload",
+			exchanges: []Exchange{
+				{Method: http.MethodPost, URL: "/redfish/v1/SessionService/Sessions",
+					Request: json.RawMessage(`{"Password":"REDACTED","UserName":"REDACTED"}`), StatusCode: http.StatusCreated},
+			},
+			method:   http.MethodPost,
+			url:      "/redfish/v1/SessionService/Sessions",
+			payload:  map[string]string{"UserName": "operator", "Password": "other"},
+			expected: []replayed{{statusCode: http.StatusCreated}},
+		},
+		{
+			name: "headers ignored",
+			exchanges: []Exchange{
+				{Method: http.MethodPatch, URL: systemURI, Request: json.RawMessage(`{"AssetTag":"Rack 5"}`),
+					StatusCode: http.StatusPreconditionFailed},
+			},
+			method:   http.MethodPatch,
+			url:      systemURI,
+			payload:  map[string]string{"AssetTag": "Rack 5"},
+			headers:  map[string]string{"If-Match": `W/"current"`},
+			expected: []replayed{{statusCode: http.StatusPreconditionFailed, err: "412"}},
+		},
+		{
+			name: "unrecorded URL",
+			exchanges: []Exchange{
+				{Method: http.MethodGet, URL: systemURI, StatusCode: http.StatusOK, Body: json.RawMessage(`{}`)},
+			},
+			method:   http.MethodGet,
+			url:      "/redfish/v1/Systems/2",
+			expected: []replayed{{err: "no recorded response"}},
+		},
+		{
+			name: "unrecorded method",
+			exchanges: []Exchange{
+				{Method: http.MethodGet, URL: systemURI, StatusCode: http.StatusOK, Body: json.RawMessage(`{}`)},
+			},
+			method:   http.MethodDelete,
+			url:      systemURI,
+			expected: []replayed{{err: "no recorded response"}},
+		},
+		{
+			name: "unrecorded payload",
+			exchanges: []Exchange{
+				{Method: http.MethodPatch, URL: systemURI, Request: json.RawMessage(`{"AssetTag":"Rack 5"}`),
+					StatusCode: http.StatusNoContent},
+			},
+			method:   http.MethodPatch,
+			url:      systemURI,
+			payload:  map[string]string{"AssetTag": "Rack 6"},
+			expected: []replayed{{err: "no recorded response"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFixtures(t, dir, test.exchanges)
+			c, err := NewReplayClient(dir)
+			if err != nil {
+				t.Fatalf("Error reading the fixtures: %v", err)
+			}
+
+			for i, expected := range test.expected {
+				var resp *http.Response
+				switch test.method {
+				case http.MethodGet:
+					resp, err = c.GetWithHeaders(test.url, test.headers)
+				case http.MethodPost:
+					resp, err = c.PostWithHeaders(test.url, test.payload, test.headers)
+				case http.MethodPatch:
+					resp, err = c.PatchWithHeaders(test.url, test.payload, test.headers)
+				case http.MethodDelete:
+					resp, err = c.DeleteWithHeaders(test.url, test.headers)
+				}
+
+				if expected.err != "" {
+					if err == nil || !strings.Contains(err.Error(), expected.err) {
+						t.Errorf("Request %d: expected an error with %q, got: %v", i, expected.err, err)
+					}
+					if expected.statusCode != 0 && !hasStatus(err, expected.statusCode) {
+						t.Errorf("Request %d: expected the status %d, got: %v", i, expected.statusCode, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("Request %d: unexpected error: %v", i, err)
+				}
+				body, _ := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				if resp.StatusCode != expected.statusCode || string(body) != expected.body {
+					t.Errorf("Request %d: expected %d %s, got: %d %s", i, expected.statusCode, expected.body, resp.StatusCode, body)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,362 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ReplayClient is a Client serving back the responses saved by a Recorder,
+// to test against the traffic of a real service:
+//
+//	c, err := common.NewReplayClient("testdata/ilo5-2.72")
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	system, err := redfish.GetComputerSystem(c, "/redfish/v1/Systems/1")
+//
+// Requests are matched to the fixtures by method, URL and JSON payload, with
+// the payload redacted as it was recorded. When a request was recorded several
+// times, the responses are served in the order they were recorded, the last
+// one being repeated. Requests that were not recorded fail.
+//
+// Request headers are ignored, including the If-Match and If-None-Match of
+// conditional requests, as they are not recorded. The responses of a client
+// whose requests depend on what it saw before, such as one with a
+// gofish.ResponseCache or updating objects with their ETag, may then diverge
+// from the recording: a 412 Precondition Failed recorded for a stale ETag is
+// served whatever ETag is sent, and a body the recorded client took from its
+// cache is served even if the replayed client never read it.
+//
+// The optional Client interfaces, such as QueryFeatureSupporter, are answered
+// as the recorded client did, or as a Client not implementing them when the
+// fixtures have no client.json. Requests use context.Background() unless the
+// client is wrapped with WithContext.
+type ReplayClient struct {
+	// Redacted are the properties redacted from the payloads before they
+	// are matched. It must be the same as the Redacted of the Recorder, and
+	// defaults to DefaultRedactedProperties.
+	Redacted []string
+
+	recorded RecordedClient
+
+	mu        sync.Mutex
+	exchanges map[string][]*Exchange
+	served    map[string]int
+}
+
+// NewReplayClient returns a ReplayClient serving the fixtures of dir.
+func NewReplayClient(dir string) (*ReplayClient, error) {
+	files, err := fixtureFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &ReplayClient{
+		Redacted:  DefaultRedactedProperties,
+		recorded:  recordClient(nil),
+		exchanges: make(map[string][]*Exchange),
+		served:    make(map[string]int),
+	}
+	switch data, err := os.ReadFile(filepath.Join(dir, clientFixture)); {
+	case err == nil:
+		if err := json.Unmarshal(data, &c.recorded); err != nil {
+			return nil, fmt.Errorf("error reading fixture %s: %w", clientFixture, err)
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, err
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		exchange := new(Exchange)
+		if err := json.Unmarshal(data, exchange); err != nil {
+			return nil, fmt.Errorf("error reading fixture %s: %w", file, err)
+		}
+
+		var request bytes.Buffer
+		if len(exchange.Request) > 0 {
+			if err := json.Compact(&request, exchange.Request); err != nil {
+				return nil, fmt.Errorf("error reading fixture %s: %w", file, err)
+			}
+		}
+		key := exchangeKey(exchange.Method, exchange.URL, request.Bytes())
+		c.exchanges[key] = append(c.exchanges[key], exchange)
+	}
+	return c, nil
+}
+
+// exchangeKey returns the key requests are matched with.
+func exchangeKey(method, url string, request []byte) string {
+	return method + " " + url + " " + string(request)
+}
+
+// replay returns the recorded response to a request.
+func (c *ReplayClient) replay(method, url string, payload interface{}) (*http.Response, error) {
+	var request []byte
+	if payload != nil {
+		var err error
+		request, err = redactPayload(payload, c.Redacted)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	key := exchangeKey(method, url, request)
+	c.mu.Lock()
+	exchanges := c.exchanges[key]
+	if len(exchanges) == 0 {
+		c.mu.Unlock()
+		return nil, fmt.Errorf("no recorded response for %s %s %s", method, url, request)
+	}
+	i := c.served[key]
+	if i < len(exchanges)-1 {
+		c.served[key]++
+	} else {
+		i = len(exchanges) - 1
+	}
+	c.mu.Unlock()
+
+	exchange := exchanges[i]
+	body := []byte(exchange.Body)
+	if exchange.Text != "" {
+		body = []byte(exchange.Text)
+	}
+	switch {
+	case exchange.Error != "":
+		return nil, errors.New(exchange.Error)
+	case exchange.StatusCode != 0 && (exchange.StatusCode < http.StatusOK || exchange.StatusCode >= http.StatusMultipleChoices):
+		return nil, ConstructError(exchange.StatusCode, body)
+	}
+
+	return &http.Response{
+		StatusCode: exchange.StatusCode,
+		Status:     fmt.Sprintf("%d %s", exchange.StatusCode, http.StatusText(exchange.StatusCode)),
+		Header:     exchange.Header.Clone(),
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Request:    &http.Request{Method: method},
+	}, nil
+}
+
+// CollectionConcurrency returns the recorded concurrency limit.
+func (c *ReplayClient) CollectionConcurrency() int {
+	return c.recorded.CollectionConcurrency
+}
+
+// CollectionExpandQuery returns the recorded $expand value.
+func (c *ReplayClient) CollectionExpandQuery() string {
+	return c.recorded.CollectionExpandQuery
+}
+
+// ExpandMode returns the recorded expansion mode.
+func (c *ReplayClient) ExpandMode() ExpandMode {
+	return c.recorded.ExpandMode
+}
+
+// SupportedQueryFeatures returns the recorded query parameters supported.
+func (c *ReplayClient) SupportedQueryFeatures() QueryFeatures {
+	return c.recorded.QueryFeatures
+}
+
+// PostBinary performs a Post request with a raw body against the recorded
+// Redfish service. The body is not read nor matched. An error is returned if
+// ctx is done.
+func (c *ReplayClient) PostBinary(ctx context.Context, url string, r io.Reader, contentType string, progress ProgressFunc, customHeaders map[string]string) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.replay(http.MethodPost, url, nil)
+}
+
+// PostMultipartParts performs a multipart/form-data Post request against the
+// recorded Redfish service. The parts are not read nor matched. An error is
+// returned if ctx is done.
+func (c *ReplayClient) PostMultipartParts(ctx context.Context, url string, parts []MultipartPart, progress ProgressFunc, customHeaders map[string]string) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.replay(http.MethodPost, url, nil)
+}
+
+// Get performs a GET request against the recorded Redfish service.
+func (c *ReplayClient) Get(url string) (*http.Response, error) {
+	return c.replay(http.MethodGet, url, nil)
+}
+
+// GetWithHeaders performs a GET request against the recorded Redfish service.
+func (c *ReplayClient) GetWithHeaders(url string, customHeaders map[string]string) (*http.Response, error) {
+	return c.replay(http.MethodGet, url, nil)
+}
+
+// Post performs a Post request against the recorded Redfish service.
+func (c *ReplayClient) Post(url string, payload interface{}) (*http.Response, error) {
+	return c.replay(http.MethodPost, url, payload)
+}
+
+// PostWithHeaders performs a Post request against the recorded Redfish service.
+func (c *ReplayClient) PostWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return c.replay(http.MethodPost, url, payload)
+}
+
+// PostMultipart performs a Post request against the recorded Redfish
+// service. The payload is not matched.
+func (c *ReplayClient) PostMultipart(url string, payload map[string]io.Reader) (*http.Response, error) {
+	return c.replay(http.MethodPost, url, nil)
+}
+
+// PostMultipartWithHeaders performs a Post request against the recorded
+// Redfish service. The payload is not matched.
+func (c *ReplayClient) PostMultipartWithHeaders(url string, payload map[string]io.Reader, customHeaders map[string]string) (*http.Response, error) {
+	return c.replay(http.MethodPost, url, nil)
+}
+
+// Put performs a Put request against the recorded Redfish service.
+func (c *ReplayClient) Put(url string, payload interface{}) (*http.Response, error) {
+	return c.replay(http.MethodPut, url, payload)
+}
+
+// PutWithHeaders performs a Put request against the recorded Redfish service.
+func (c *ReplayClient) PutWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return c.replay(http.MethodPut, url, payload)
+}
+
+// Patch performs a Patch request against the recorded Redfish service.
+func (c *ReplayClient) Patch(url string, payload interface{}) (*http.Response, error) {
+	return c.replay(http.MethodPatch, url, payload)
+}
+
+// PatchWithHeaders performs a Patch request against the recorded Redfish service.
+func (c *ReplayClient) PatchWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return c.replay(http.MethodPatch, url, payload)
+}
+
+// Delete performs a Delete request against the recorded Redfish service.
+func (c *ReplayClient) Delete(url string) (*http.Response, error) {
+	return c.replay(http.MethodDelete, url, nil)
+}
+
+// DeleteWithHeaders performs a Delete request against the recorded Redfish service.
+func (c *ReplayClient) DeleteWithHeaders(url string, customHeaders map[string]string) (*http.Response, error) {
+	return c.replay(http.MethodDelete, url, nil)
+}
+
+// GetContext performs a GET request against the recorded Redfish service. An
+// error is returned if ctx is done.
+func (c *ReplayClient) GetContext(ctx context.Context, url string) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Get(url)
+}
+
+// GetWithHeadersContext performs a GET request against the recorded Redfish
+// service. An error is returned if ctx is done.
+func (c *ReplayClient) GetWithHeadersContext(ctx context.Context, url string, customHeaders map[string]string) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.GetWithHeaders(url, customHeaders)
+}
+
+// PostContext performs a Post request against the recorded Redfish service.
+// An error is returned if ctx is done.
+func (c *ReplayClient) PostContext(ctx context.Context, url string, payload interface{}) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Post(url, payload)
+}
+
+// PostWithHeadersContext performs a Post request against the recorded Redfish
+// service. An error is returned if ctx is done.
+func (c *ReplayClient) PostWithHeadersContext(ctx context.Context, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.PostWithHeaders(url, payload, customHeaders)
+}
+
+// PostMultipartContext performs a Post request against the recorded Redfish
+// service. An error is returned if ctx is done.
+func (c *ReplayClient) PostMultipartContext(ctx context.Context, url string, payload map[string]io.Reader) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.PostMultipart(url, payload)
+}
+
+// PostMultipartWithHeadersContext performs a Post request against the recorded
+// Redfish service. An error is returned if ctx is done.
+func (c *ReplayClient) PostMultipartWithHeadersContext(ctx context.Context, url string, payload map[string]io.Reader, customHeaders map[string]string) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.PostMultipartWithHeaders(url, payload, customHeaders)
+}
+
+// PutContext performs a Put request against the recorded Redfish service. An
+// error is returned if ctx is done.
+func (c *ReplayClient) PutContext(ctx context.Context, url string, payload interface{}) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Put(url, payload)
+}
+
+// PutWithHeadersContext performs a Put request against the recorded Redfish
+// service. An error is returned if ctx is done.
+func (c *ReplayClient) PutWithHeadersContext(ctx context.Context, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.PutWithHeaders(url, payload, customHeaders)
+}
+
+// PatchContext performs a Patch request against the recorded Redfish service.
+// An error is returned if ctx is done.
+func (c *ReplayClient) PatchContext(ctx context.Context, url string, payload interface{}) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Patch(url, payload)
+}
+
+// PatchWithHeadersContext performs a Patch request against the recorded
+// Redfish service. An error is returned if ctx is done.
+func (c *ReplayClient) PatchWithHeadersContext(ctx context.Context, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.PatchWithHeaders(url, payload, customHeaders)
+}
+
+// DeleteContext performs a Delete request against the recorded Redfish
+// service. An error is returned if ctx is done.
+func (c *ReplayClient) DeleteContext(ctx context.Context, url string) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Delete(url)
+}
+
+// DeleteWithHeadersContext performs a Delete request against the recorded
+// Redfish service. An error is returned if ctx is done.
+func (c *ReplayClient) DeleteWithHeadersContext(ctx context.Context, url string, customHeaders map[string]string) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.DeleteWithHeaders(url, customHeaders)
+}
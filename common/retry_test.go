@@ -0,0 +1,27 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestParseRetryAfter tests parsing the Retry-After header formats.
+func TestParseRetryAfter(t *testing.T) {
+	if wait, ok := ParseRetryAfter("5"); !ok || wait != 5*time.Second {
+		t.Errorf("Unexpected seconds parsing: %s %t", wait, ok)
+	}
+
+	date := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+	if wait, ok := ParseRetryAfter(date); !ok || wait <= 0 || wait > 10*time.Second {
+		t.Errorf("Unexpected date parsing: %s %t", wait, ok)
+	}
+
+	if _, ok := ParseRetryAfter("soon"); ok {
+		t.Error("Invalid value should not parse")
+	}
+}
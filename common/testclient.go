@@ -5,6 +5,7 @@
 package common
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -39,6 +40,12 @@ type TestClient struct {
 	CustomReturnForActions map[string][]interface{}
 }
 
+// CollectionConcurrency makes collection members be fetched one at a time so
+// the calls are captured in a predictable order.
+func (c *TestClient) CollectionConcurrency() int {
+	return 1
+}
+
 // CapturedCalls gets all calls that were made through this instance
 func (c *TestClient) CapturedCalls() []TestAPICall {
 	return c.calls
@@ -198,3 +205,111 @@ func (c *TestClient) Delete(url string) (*http.Response, error) {
 func (c *TestClient) DeleteWithHeaders(url string, customHeaders map[string]string) (*http.Response, error) {
 	return c.performAction(http.MethodDelete, url, nil, customHeaders)
 }
+
+// GetContext performs a GET request against the Redfish service. An
+// error is returned without recording the call if ctx is done.
+func (c *TestClient) GetContext(ctx context.Context, url string) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Get(url)
+}
+
+// GetWithHeadersContext performs a GET request against the Redfish service. An
+// error is returned without recording the call if ctx is done.
+func (c *TestClient) GetWithHeadersContext(ctx context.Context, url string, customHeaders map[string]string) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.GetWithHeaders(url, customHeaders)
+}
+
+// PostContext performs a Post request against the Redfish service. An
+// error is returned without recording the call if ctx is done.
+func (c *TestClient) PostContext(ctx context.Context, url string, payload interface{}) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Post(url, payload)
+}
+
+// PostWithHeadersContext performs a Post request against the Redfish service. An
+// error is returned without recording the call if ctx is done.
+func (c *TestClient) PostWithHeadersContext(ctx context.Context, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.PostWithHeaders(url, payload, customHeaders)
+}
+
+// PostMultipartContext performs a Post request against the Redfish service. An
+// error is returned without recording the call if ctx is done.
+func (c *TestClient) PostMultipartContext(ctx context.Context, url string, payload map[string]io.Reader) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.PostMultipart(url, payload)
+}
+
+// PostMultipartWithHeadersContext performs a Post request against the Redfish service. An
+// error is returned without recording the call if ctx is done.
+func (c *TestClient) PostMultipartWithHeadersContext(ctx context.Context, url string, payload map[string]io.Reader, customHeaders map[string]string) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.PostMultipartWithHeaders(url, payload, customHeaders)
+}
+
+// PutContext performs a Put request against the Redfish service. An
+// error is returned without recording the call if ctx is done.
+func (c *TestClient) PutContext(ctx context.Context, url string, payload interface{}) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Put(url, payload)
+}
+
+// PutWithHeadersContext performs a Put request against the Redfish service. An
+// error is returned without recording the call if ctx is done.
+func (c *TestClient) PutWithHeadersContext(ctx context.Context, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.PutWithHeaders(url, payload, customHeaders)
+}
+
+// PatchContext performs a Patch request against the Redfish service. An
+// error is returned without recording the call if ctx is done.
+func (c *TestClient) PatchContext(ctx context.Context, url string, payload interface{}) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Patch(url, payload)
+}
+
+// PatchWithHeadersContext performs a Patch request against the Redfish service. An
+// error is returned without recording the call if ctx is done.
+func (c *TestClient) PatchWithHeadersContext(ctx context.Context, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.PatchWithHeaders(url, payload, customHeaders)
+}
+
+// DeleteContext performs a Delete request against the Redfish service. An
+// error is returned without recording the call if ctx is done.
+func (c *TestClient) DeleteContext(ctx context.Context, url string) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Delete(url)
+}
+
+// DeleteWithHeadersContext performs a Delete request against the Redfish service. An
+// error is returned without recording the call if ctx is done.
+func (c *TestClient) DeleteWithHeadersContext(ctx context.Context, url string, customHeaders map[string]string) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.DeleteWithHeaders(url, customHeaders)
+}
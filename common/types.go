@@ -5,6 +5,7 @@
 package common
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -29,6 +30,19 @@ type Client interface {
 	PutWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error)
 	Delete(url string) (*http.Response, error)
 	DeleteWithHeaders(url string, customHeaders map[string]string) (*http.Response, error)
+
+	GetContext(ctx context.Context, url string) (*http.Response, error)
+	GetWithHeadersContext(ctx context.Context, url string, customHeaders map[string]string) (*http.Response, error)
+	PostContext(ctx context.Context, url string, payload interface{}) (*http.Response, error)
+	PostWithHeadersContext(ctx context.Context, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error)
+	PostMultipartContext(ctx context.Context, url string, payload map[string]io.Reader) (*http.Response, error)
+	PostMultipartWithHeadersContext(ctx context.Context, url string, payload map[string]io.Reader, customHeaders map[string]string) (*http.Response, error)
+	PatchContext(ctx context.Context, url string, payload interface{}) (*http.Response, error)
+	PatchWithHeadersContext(ctx context.Context, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error)
+	PutContext(ctx context.Context, url string, payload interface{}) (*http.Response, error)
+	PutWithHeadersContext(ctx context.Context, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error)
+	DeleteContext(ctx context.Context, url string) (*http.Response, error)
+	DeleteWithHeadersContext(ctx context.Context, url string, customHeaders map[string]string) (*http.Response, error)
 }
 
 // Entity provides the common basis for all Redfish and Swordfish objects.
@@ -40,71 +54,50 @@ type Entity struct {
 	// Name is the name of the resource or array element.
 	Name string `json:"Name"`
 	// Client is the REST client interface to the system.
-	Client Client
+	Client Client `json:"-"`
+	// etag is the ETag the service returned with the entity.
+	etag string
+	// rawJSON is the body the entity was read from.
+	rawJSON []byte
+	// object is the object embedding the entity, to refresh it in place.
+	object interface{}
 }
 
 // SetClient sets the API client connection to use for accessing this
-// entity.
+// entity. A context set with WithContext is kept, so the requests made through
+// the entity, such as getting its linked resources, use it too.
 func (e *Entity) SetClient(c Client) {
 	e.Client = c
 }
 
-// Update commits changes to an entity.
-func (e *Entity) Update(originalEntity, currentEntity reflect.Value, allowedUpdates []string) error {
-	payload := make(map[string]interface{})
-
-	for i := 0; i < originalEntity.NumField(); i++ {
-		if !originalEntity.Field(i).CanInterface() {
-			// Private field or something that we can't access
-			continue
-		}
-		fieldType := originalEntity.Type().Field(i).Type.Kind()
-		if fieldType == reflect.Struct || fieldType == reflect.Ptr || fieldType == reflect.Slice {
-			// TODO: Handle more complicated data types
-			continue
-		}
-		fieldName := originalEntity.Type().Field(i).Name
-		jsonName := originalEntity.Type().Field(i).Tag.Get("json")
-		if jsonName != "" {
-			fieldName = jsonName
-		}
-		originalValue := originalEntity.Field(i).Interface()
-		currentValue := currentEntity.Field(i).Interface()
-		if originalValue == nil && currentValue == nil {
-			continue
-		} else if originalValue == nil {
-			payload[fieldName] = currentValue
-		} else if reflect.TypeOf(originalValue).Kind() != reflect.Map {
-			if originalValue != currentValue {
-				payload[fieldName] = currentValue
-			}
-		} else if !reflect.DeepEqual(originalValue, currentValue) {
-			payload[fieldName] = currentValue
-		}
-	}
+// ETag returns the ETag the service returned with the entity, if any.
+func (e *Entity) ETag() string {
+	return e.etag
+}
+
+// SetETag sets the ETag of the entity.
+func (e *Entity) SetETag(etag string) {
+	e.etag = etag
+}
+
+// Update commits changes to an entity. The changes are sent with the ETag
+// of the entity, see Patch.
+func (e *Entity) Update(originalEntity, currentEntity reflect.Value, allowedUpdates []string, opts ...UpdateOption) error {
+	// Nested objects and arrays are compared property by property so only
+	// what changed is sent, with read only checks on the dotted path of
+	// each property.
+	var d updateDiff
+	payload := d.structPayload("", originalEntity, currentEntity)
 
 	// See if we are attempting to update anything that is not allowed
-	for field := range payload {
-		found := false
-		for _, name := range allowedUpdates {
-			if name == field {
-				found = true
-				break
-			}
-		}
-
-		if !found {
-			return fmt.Errorf("%s field is read only", field)
-		}
+	if field, found := d.readOnly(allowedUpdates); found {
+		return fmt.Errorf("%s field is read only", field)
 	}
 
 	// If there are any allowed updates, try to send updates to the system and
 	// return the result.
 	if len(payload) > 0 {
-		_, err := e.Client.Patch(e.ODataID, payload) // nolint:bodyclose
-		if err != nil {
-			return err
-		}
+		return e.Patch(e.ODataID, payload, opts...)
 	}
 
 	return nil
@@ -744,8 +737,13 @@ func ConstructError(statusCode int, b []byte) error {
 // Error is redfish error response object for HTTP status codes different from 200, 201 and 204
 type Error struct {
 	rawData []byte
+	// cause is the underlying error when the request failed before the
+	// service returned a response.
+	cause error
 	// An integer that represents the status code returned by the API
 	HTTPReturnedStatusCode int `json:"-"`
+	// Attempts is the number of times the request was sent before giving up.
+	Attempts int `json:"-"`
 	// A string indicating a specific MessageId from the message registry.
 	Code string `json:"code"`
 	// A human readable error message corresponding to the message in the message registry.
@@ -755,14 +753,33 @@ type Error struct {
 }
 
 func (e *Error) Error() string {
+	msg := string(e.rawData)
 	if e.HTTPReturnedStatusCode != 0 {
-		return fmt.Sprintf("%d: %s", e.HTTPReturnedStatusCode, e.rawData)
+		msg = fmt.Sprintf("%d: %s", e.HTTPReturnedStatusCode, e.rawData)
+	}
+	if e.Attempts > 1 {
+		msg = fmt.Sprintf("%s (after %d attempts)", msg, e.Attempts)
+	}
+	return msg
+}
+
+// Unwrap returns the underlying transport error, if any.
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// ConstructTransportError wraps an error returned before the service sent a
+// response, recording how many attempts were made.
+func ConstructTransportError(err error, attempts int) error {
+	return &Error{
+		rawData:  []byte(err.Error()),
+		cause:    err,
+		Message:  err.Error(),
+		Attempts: attempts,
 	}
-	return string(e.rawData)
 }
 
 // ErrExtendedInfo is for redfish ExtendedInfo error response
-// TODO: support RelatedProperties
 type ErrExtendedInfo struct {
 	// Indicating a specific error or message (not to be confused with the HTTP status code).
 	// This code can be used to access a detailed message from a message registry.
@@ -773,6 +790,9 @@ type ErrExtendedInfo struct {
 	// An optional array of strings representing the substitution parameter values for the message.
 	// This shall be included in the response if a MessageId is specified for a parameterized message.
 	MessageArgs []string
+	// An optional array of JSON Pointers defining the specific properties
+	// within a JSON payload described by the message.
+	RelatedProperties []string `json:",omitempty"`
 	// An optional string representing the severity of the error.
 	Severity string
 	// An optional string describing recommended action(s) to take to resolve the error.
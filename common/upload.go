@@ -0,0 +1,56 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// ProgressFunc is called while a request body is sent with the number of
+// bytes sent so far and the total size, or -1 if the size is not known. The
+// count starts again from zero if the request is retried.
+type ProgressFunc func(sent, total int64)
+
+// MultipartPart is a part of a multipart/form-data upload.
+type MultipartPart struct {
+	// Name is the name of the form field, such as UpdateParameters or
+	// UpdateFile for the MultipartHttpPushUri of the UpdateService.
+	Name string
+	// FileName is the optional file name sent for file parts.
+	FileName string
+	// ContentType is the optional content type of the part, such as
+	// application/json. File parts default to application/octet-stream.
+	ContentType string
+	// Reader provides the content of the part. It is read while the request
+	// is sent. If it implements io.Seeker, the request can be retried.
+	Reader io.Reader
+	// Size is the size of the content. If zero, it is taken from the reader
+	// when possible, such as for files and bytes.Reader.
+	Size int64
+}
+
+// Uploader is implemented by clients that can stream large request bodies,
+// such as firmware images, without holding them in memory.
+type Uploader interface {
+	// PostBinary performs a Post request with a raw body.
+	PostBinary(ctx context.Context, url string, r io.Reader, contentType string, progress ProgressFunc, customHeaders map[string]string) (*http.Response, error)
+	// PostMultipartParts performs a multipart/form-data Post request.
+	PostMultipartParts(ctx context.Context, url string, parts []MultipartPart, progress ProgressFunc, customHeaders map[string]string) (*http.Response, error)
+}
+
+// errUploadNotSupported is returned by the clients wrapping another one for
+// uploads when the wrapped client is not an Uploader.
+var errUploadNotSupported = errors.New("client does not support streaming uploads")
+
+// clientUploader returns the Uploader of a wrapped client.
+func clientUploader(c Client) (Uploader, error) {
+	if uploader, ok := c.(Uploader); ok {
+		return uploader, nil
+	}
+	return nil, errUploadNotSupported
+}
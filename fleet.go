@@ -0,0 +1,223 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultFleetConcurrency is the number of endpoints a Fleet works on at the
+// same time when Concurrency is not set.
+const DefaultFleetConcurrency = 16
+
+// DefaultFleetLogoutTimeout is how long a Fleet waits for a logout to
+// complete when LogoutTimeout is not set.
+const DefaultFleetLogoutTimeout = 10 * time.Second
+
+// FleetResult is the outcome of running an operation against one endpoint of
+// a Fleet.
+type FleetResult struct {
+	// Endpoint is the endpoint of the ClientConfig.
+	Endpoint string
+	// Err is the error returned by the operation, or the error that
+	// prevented it from running such as a failed connection, a timeout or a
+	// panic.
+	Err error
+	// Duration is the time taken, including connecting to the endpoint.
+	Duration time.Duration
+}
+
+// Success reports whether the operation succeeded.
+func (r *FleetResult) Success() bool {
+	return r.Err == nil
+}
+
+// FleetFunc is an operation run by a Fleet against one endpoint. The
+// requests made with c use ctx, which is done once the Fleet's timeout
+// expires or the context passed to Run is cancelled.
+type FleetFunc func(ctx context.Context, c *APIClient) error
+
+// Fleet runs operations against many Redfish services at once. It takes care
+// of connecting to each endpoint, bounding the number of endpoints worked on
+// concurrently, and logging out whatever happens to the operation:
+//
+//	fleet := gofish.NewFleet(configs...)
+//	fleet.Timeout = time.Minute
+//	for _, result := range fleet.Run(ctx, func(ctx context.Context, c *gofish.APIClient) error {
+//		_, err := c.Service.Systems()
+//		return err
+//	}) {
+//		...
+//	}
+type Fleet struct {
+	// Concurrency is the number of endpoints worked on at the same time.
+	// Zero uses DefaultFleetConcurrency.
+	Concurrency int
+
+	// Timeout is the time allowed for each endpoint in a call to Run,
+	// including connecting to it. Zero means no timeout.
+	Timeout time.Duration
+
+	// LogoutTimeout is the time allowed for logging out of an endpoint,
+	// which is done even after Timeout expired or the operation was
+	// cancelled. Zero uses DefaultFleetLogoutTimeout.
+	LogoutTimeout time.Duration
+
+	// KeepConnected keeps the sessions and connections open between calls
+	// to Run instead of logging out after each of them. Close must then be
+	// called to log out.
+	KeepConnected bool
+
+	configs []ClientConfig
+
+	// mu serializes the calls to Run and Close.
+	mu      sync.Mutex
+	clients []*APIClient
+}
+
+// NewFleet creates a Fleet for the services with the given configs.
+func NewFleet(configs ...ClientConfig) *Fleet {
+	return &Fleet{
+		configs: configs,
+		clients: make([]*APIClient, len(configs)),
+	}
+}
+
+// concurrency returns the number of endpoints to work on at the same time.
+func (f *Fleet) concurrency() int {
+	if f.Concurrency <= 0 {
+		return DefaultFleetConcurrency
+	}
+	return f.Concurrency
+}
+
+// Run calls fn for every endpoint of the fleet and returns the results in the
+// order of the configs. Endpoints that were not reached yet when ctx is
+// cancelled fail with the context error.
+func (f *Fleet) Run(ctx context.Context, fn FleetFunc) []FleetResult {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	results := make([]FleetResult, len(f.configs))
+	sem := make(chan struct{}, f.concurrency())
+	var wg sync.WaitGroup
+	for i := range f.configs {
+		results[i].Endpoint = f.configs[i].Endpoint
+
+		if err := ctx.Err(); err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			start := time.Now()
+			results[i].Err = f.runEndpoint(ctx, i, fn)
+			results[i].Duration = time.Since(start)
+		}(i)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// runEndpoint connects to an endpoint if needed and runs the operation.
+func (f *Fleet) runEndpoint(ctx context.Context, i int, fn FleetFunc) (err error) {
+	var client *APIClient
+	panicked := true
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic while running operation: %v", r)
+		}
+		if client == nil {
+			return
+		}
+
+		// The client state is unknown after a panic, start over next time
+		if f.KeepConnected && !panicked {
+			client.ctx = context.Background()
+			f.clients[i] = client
+			return
+		}
+		f.logout(client)
+		f.clients[i] = nil
+	}()
+
+	if f.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, f.Timeout)
+		defer cancel()
+	}
+
+	client = f.clients[i]
+	if client == nil {
+		client, err = ConnectContext(ctx, f.configs[i])
+		if err != nil {
+			return err
+		}
+	} else {
+		client.ctx = ctx
+	}
+
+	err = fn(ctx, client)
+	panicked = false
+	return err
+}
+
+// logout closes the session of the client, even if its context is done.
+func (f *Fleet) logout(client *APIClient) {
+	timeout := f.LogoutTimeout
+	if timeout <= 0 {
+		timeout = DefaultFleetLogoutTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	client.ctx = ctx
+	client.Logout()
+	client.HTTPClient.CloseIdleConnections()
+}
+
+// Close logs out of the endpoints kept connected by KeepConnected.
+func (f *Fleet) Close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sem := make(chan struct{}, f.concurrency())
+	var wg sync.WaitGroup
+	for i, client := range f.clients {
+		if client == nil {
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(client *APIClient) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			f.logout(client)
+		}(client)
+		f.clients[i] = nil
+	}
+	wg.Wait()
+}
@@ -0,0 +1,173 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fleetServer is a service counting logins and logouts.
+type fleetServer struct {
+	*httptest.Server
+	logins  int32
+	logouts int32
+}
+
+func newFleetServer() *fleetServer {
+	s := &fleetServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/redfish/v1/SessionService/Sessions":
+			atomic.AddInt32(&s.logins, 1)
+			w.Header().Set("X-Auth-Token", "token")
+			w.Header().Set("Location", "/redfish/v1/SessionService/Sessions/1")
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodDelete:
+			atomic.AddInt32(&s.logouts, 1)
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/redfish/v1/Slow":
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		default:
+			w.Write([]byte(authServiceRoot)) // nolint
+		}
+	}))
+	return s
+}
+
+func (s *fleetServer) config() ClientConfig {
+	return ClientConfig{Endpoint: s.URL, HTTPClient: s.Client(), Username: "admin", Password: "secret"}
+}
+
+// TestFleetRun tests the operation runs on every endpoint with a result per
+// endpoint, and sessions are closed whatever happened.
+func TestFleetRun(t *testing.T) {
+	servers := []*fleetServer{newFleetServer(), newFleetServer(), newFleetServer()}
+	for _, s := range servers {
+		defer s.Close()
+	}
+
+	fleet := NewFleet(servers[0].config(), servers[1].config(), servers[2].config())
+	fleet.Timeout = 50 * time.Millisecond
+
+	results := fleet.Run(context.Background(), func(ctx context.Context, c *APIClient) error {
+		switch c.endpoint {
+		case servers[1].URL:
+			panic("boom")
+		case servers[2].URL:
+			_, err := c.Get("/redfish/v1/Slow") // nolint:bodyclose
+			return err
+		}
+		return nil
+	})
+
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+	if !results[0].Success() || results[0].Endpoint != servers[0].URL || results[0].Duration <= 0 {
+		t.Errorf("Unexpected result for the first endpoint: %+v", results[0])
+	}
+	if results[1].Success() {
+		t.Error("The panic should have been reported")
+	}
+	if !errors.Is(results[2].Err, context.DeadlineExceeded) {
+		t.Errorf("Expected the slow endpoint to time out, got: %v", results[2].Err)
+	}
+
+	for i, s := range servers {
+		if s.logins != 1 || s.logouts != 1 {
+			t.Errorf("Endpoint %d: expected one login and one logout, got %d and %d", i, s.logins, s.logouts)
+		}
+	}
+}
+
+// panickingTransport is a transport panicking on every request.
+type panickingTransport struct{}
+
+func (panickingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	panic("transport failure")
+}
+
+// TestFleetConnectPanic tests a panic while connecting is reported as the
+// result of the endpoint instead of crashing the program.
+func TestFleetConnectPanic(t *testing.T) {
+	server := newFleetServer()
+	defer server.Close()
+
+	config := server.config()
+	config.HTTPClient = &http.Client{Transport: panickingTransport{}}
+	called := false
+	results := NewFleet(server.config(), config).Run(context.Background(), func(ctx context.Context, c *APIClient) error {
+		if c.HTTPClient == config.HTTPClient {
+			called = true
+		}
+		return nil
+	})
+
+	if !results[0].Success() {
+		t.Errorf("The other endpoint should have succeeded, got: %v", results[0].Err)
+	}
+	if results[1].Success() || called {
+		t.Errorf("Expected the panic to be reported, got: %+v", results[1])
+	}
+}
+
+// TestFleetKeepConnected tests sessions are reused between runs and closed by
+// Close.
+func TestFleetKeepConnected(t *testing.T) {
+	server := newFleetServer()
+	defer server.Close()
+
+	fleet := NewFleet(server.config())
+	fleet.KeepConnected = true
+
+	for i := 0; i < 3; i++ {
+		results := fleet.Run(context.Background(), func(ctx context.Context, c *APIClient) error {
+			_, err := c.Get("/redfish/v1/")
+			return err
+		})
+		if !results[0].Success() {
+			t.Fatalf("Run failed: %v", results[0].Err)
+		}
+	}
+
+	if server.logins != 1 || server.logouts != 0 {
+		t.Errorf("Expected a single session kept open, got %d logins and %d logouts", server.logins, server.logouts)
+	}
+
+	fleet.Close()
+	if server.logouts != 1 {
+		t.Errorf("Close should have logged out, got %d logouts", server.logouts)
+	}
+}
+
+// TestFleetCancelled tests endpoints are not contacted once the context is
+// cancelled.
+func TestFleetCancelled(t *testing.T) {
+	server := newFleetServer()
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := NewFleet(server.config()).Run(ctx, func(ctx context.Context, c *APIClient) error {
+		return nil
+	})
+
+	if !errors.Is(results[0].Err, context.Canceled) {
+		t.Errorf("Expected a cancellation error, got: %v", results[0].Err)
+	}
+	if server.logins != 0 {
+		t.Errorf("No login should have happened, got %d", server.logins)
+	}
+}
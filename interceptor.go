@@ -0,0 +1,271 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"time"
+
+	"github.com/trungng1992/gofish/common"
+)
+
+// RequestHandler sends a request and returns the response of the service.
+type RequestHandler func(req *http.Request) (*http.Response, error)
+
+// Interceptor wraps the sending of each HTTP request made by the APIClient.
+// It can inspect or change the request, call next to send it, and inspect or
+// change the response. Retried requests go through the interceptors again.
+type Interceptor interface {
+	Intercept(req *http.Request, next RequestHandler) (*http.Response, error)
+}
+
+// InterceptorFunc adapts a function to the Interceptor interface.
+type InterceptorFunc func(req *http.Request, next RequestHandler) (*http.Response, error)
+
+// Intercept calls f.
+func (f InterceptorFunc) Intercept(req *http.Request, next RequestHandler) (*http.Response, error) {
+	return f(req, next)
+}
+
+// chainInterceptors builds a handler running the interceptors in order around
+// the final handler. The first interceptor sees the request first and the
+// response last.
+func chainInterceptors(interceptors []Interceptor, final RequestHandler) RequestHandler {
+	handler := final
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor := interceptors[i]
+		next := handler
+		handler = func(req *http.Request) (*http.Response, error) {
+			return interceptor.Intercept(req, next)
+		}
+	}
+	return handler
+}
+
+// Logger is the logging interface used by LoggingInterceptor. It is
+// satisfied by *log.Logger.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// LoggingInterceptor logs the method, URL, status and duration of every
+// request.
+func LoggingInterceptor(logger Logger) Interceptor {
+	return TimingInterceptor(func(req *http.Request, resp *http.Response, err error, duration time.Duration) {
+		if err != nil {
+			logger.Printf("%s %s failed after %s: %v", req.Method, req.URL.RequestURI(), duration, err)
+			return
+		}
+		logger.Printf("%s %s %d (%s)", req.Method, req.URL.RequestURI(), resp.StatusCode, duration)
+	})
+}
+
+// TimingInterceptor calls report with the time taken by every request, until
+// the response headers were received.
+func TimingInterceptor(report func(req *http.Request, resp *http.Response, err error, duration time.Duration)) Interceptor {
+	return InterceptorFunc(func(req *http.Request, next RequestHandler) (*http.Response, error) {
+		start := time.Now()
+		resp, err := next(req)
+		report(req, resp, err, time.Since(start))
+		return resp, err
+	})
+}
+
+// HeaderInterceptor sets the given headers on every request, replacing any
+// existing value.
+func HeaderInterceptor(headers map[string]string) Interceptor {
+	return InterceptorFunc(func(req *http.Request, next RequestHandler) (*http.Response, error) {
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		return next(req)
+	})
+}
+
+// redactedValue replaces sensitive values in dumps.
+const redactedValue = "REDACTED"
+
+// sensitiveHeaders are the headers redacted in dumps.
+var sensitiveHeaders = []string{"X-Auth-Token", "Authorization", "Cookie", "Set-Cookie"}
+
+// redactHeader returns a copy of the header with credentials redacted.
+func redactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, name := range sensitiveHeaders {
+		if _, ok := redacted[name]; ok {
+			redacted.Set(name, redactedValue)
+		}
+	}
+	return redacted
+}
+
+// redactJSONValue replaces the values of password properties in a decoded
+// JSON document.
+func redactJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if strings.Contains(strings.ToLower(key), "password") {
+				v[key] = redactedValue
+			} else {
+				v[key] = redactJSONValue(item)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactJSONValue(item)
+		}
+	}
+	return value
+}
+
+// redactJSON redacts password properties in a JSON body. Bodies that are not
+// valid JSON are returned unchanged.
+func redactJSON(body []byte) []byte {
+	var document interface{}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return body
+	}
+
+	redacted, err := json.Marshal(redactJSONValue(document))
+	if err != nil {
+		return body
+	}
+	return redacted
+}
+
+// isJSONContent reports whether the content type is JSON.
+func isJSONContent(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == applicationJSON || strings.HasSuffix(mediaType, "+json"))
+}
+
+// bufferBody reads a body and returns a fresh reader with the same content.
+func bufferBody(body io.ReadCloser) ([]byte, io.ReadCloser, error) {
+	if body == nil || body == http.NoBody {
+		return nil, body, nil
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// dumpBody returns the body as it should appear in a dump. Only JSON bodies
+// are included, redacted. Other bodies such as firmware images are replaced
+// by a short note.
+func dumpBody(contentType string, body []byte) []byte {
+	if len(body) == 0 {
+		return nil
+	}
+	if !isJSONContent(contentType) {
+		return []byte(fmt.Sprintf("[%d bytes of %s omitted]", len(body), contentType))
+	}
+	return redactJSON(body)
+}
+
+// DumpInterceptor writes a dump of every request and response to w, with
+// session tokens, credentials, cookies and password properties redacted.
+// Only JSON bodies are dumped in full.
+func DumpInterceptor(w io.Writer) Interceptor {
+	return InterceptorFunc(func(req *http.Request, next RequestHandler) (*http.Response, error) {
+		if err := dumpRequest(w, req); err != nil {
+			return nil, err
+		}
+
+		resp, err := next(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := dumpResponse(w, resp); err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+
+		return resp, nil
+	})
+}
+
+// dumpRequest writes a redacted dump of an outgoing request.
+func dumpRequest(w io.Writer, req *http.Request) error {
+	var body []byte
+	if isJSONContent(req.Header.Get("Content-Type")) {
+		var err error
+		body, req.Body, err = bufferBody(req.Body)
+		if err != nil {
+			return common.ConstructError(0, []byte(err.Error()))
+		}
+	}
+
+	dump := req.Clone(req.Context())
+	dump.Header = redactHeader(req.Header)
+	dump.Body = nil
+	dump.ContentLength = 0
+
+	d, err := httputil.DumpRequestOut(dump, false)
+	if err != nil {
+		return common.ConstructError(0, []byte(err.Error()))
+	}
+
+	if body != nil {
+		d = append(d, dumpBody(req.Header.Get("Content-Type"), body)...)
+	} else if req.ContentLength > 0 {
+		d = append(d, fmt.Sprintf("[%d bytes of %s omitted]", req.ContentLength, req.Header.Get("Content-Type"))...)
+	}
+
+	return writeDump(w, d)
+}
+
+// dumpResponse writes a redacted dump of an incoming response.
+func dumpResponse(w io.Writer, resp *http.Response) error {
+	dump := *resp
+	dump.Header = redactHeader(resp.Header)
+	dump.Body = nil
+
+	d, err := httputil.DumpResponse(&dump, false)
+	if err != nil {
+		return common.ConstructError(0, []byte(err.Error()))
+	}
+
+	if isJSONContent(resp.Header.Get("Content-Type")) {
+		var body []byte
+		body, resp.Body, err = bufferBody(resp.Body)
+		if err != nil {
+			return common.ConstructError(0, []byte(err.Error()))
+		}
+		d = append(d, dumpBody(resp.Header.Get("Content-Type"), body)...)
+	}
+
+	return writeDump(w, d)
+}
+
+// writeDump writes a dump followed by a new line.
+func writeDump(w io.Writer, d []byte) error {
+	d = append(d, '\n')
+	if _, err := w.Write(d); err != nil {
+		return common.ConstructError(0, []byte(fmt.Sprintf("unable to write dump: %v", err)))
+	}
+	return nil
+}
+
+// AddInterceptors appends interceptors to the chain run around every
+// request. They run after the ones already registered.
+func (c *APIClient) AddInterceptors(interceptors ...Interceptor) {
+	// Copy so clients cloned from the same config do not share the chain
+	chain := make([]Interceptor, 0, len(c.interceptors)+len(interceptors))
+	chain = append(chain, c.interceptors...)
+	c.interceptors = append(chain, interceptors...)
+}
@@ -0,0 +1,179 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trungng1992/gofish/redfish"
+)
+
+// newInterceptorTestServer returns a server echoing the JSON request body,
+// setting a session cookie.
+func newInterceptorTestServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Test") != "" {
+			w.Header().Set("X-Test", r.Header.Get("X-Test"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "sessionKey=secret-cookie")
+		body, _ := io.ReadAll(r.Body)
+		if len(body) == 0 {
+			body = []byte(`{"Name": "Root Service"}`)
+		}
+		w.Write(body) // nolint
+	}))
+}
+
+// TestInterceptorOrder tests interceptors run in the order they were given.
+func TestInterceptorOrder(t *testing.T) {
+	ts := newInterceptorTestServer()
+	defer ts.Close()
+
+	var calls []string
+	record := func(name string) Interceptor {
+		return InterceptorFunc(func(req *http.Request, next RequestHandler) (*http.Response, error) {
+			calls = append(calls, name+" request")
+			resp, err := next(req)
+			calls = append(calls, name+" response")
+			return resp, err
+		})
+	}
+
+	client := newRetryTestClient(ts, nil)
+	client.AddInterceptors(record("first"), record("second"))
+
+	resp, err := client.Get("/redfish/v1/")
+	if err != nil {
+		t.Fatalf("Error making request: %v", err)
+	}
+	resp.Body.Close()
+
+	expected := "first request,second request,second response,first response"
+	if strings.Join(calls, ",") != expected {
+		t.Errorf("Wrong interceptor order: %v", calls)
+	}
+}
+
+// TestInterceptorRetries tests every attempt goes through the interceptors.
+func TestInterceptorRetries(t *testing.T) {
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(retryServiceRoot)) // nolint
+	}))
+	defer ts.Close()
+
+	var statuses []int
+	client := newRetryTestClient(ts, fastRetryPolicy())
+	client.AddInterceptors(TimingInterceptor(func(req *http.Request, resp *http.Response, err error, duration time.Duration) {
+		statuses = append(statuses, resp.StatusCode)
+	}))
+
+	resp, err := client.Get("/redfish/v1/")
+	if err != nil {
+		t.Fatalf("Error making request: %v", err)
+	}
+	resp.Body.Close()
+
+	if len(statuses) != 3 || statuses[2] != http.StatusOK {
+		t.Errorf("Expected 3 timed attempts, got: %v", statuses)
+	}
+}
+
+// TestHeaderInterceptor tests headers are injected in requests.
+func TestHeaderInterceptor(t *testing.T) {
+	ts := newInterceptorTestServer()
+	defer ts.Close()
+
+	client := newRetryTestClient(ts, nil)
+	client.AddInterceptors(HeaderInterceptor(map[string]string{"X-Test": "injected"}))
+
+	resp, err := client.Get("/redfish/v1/")
+	if err != nil {
+		t.Fatalf("Error making request: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.Header.Get("X-Test") != "injected" {
+		t.Errorf("Header was not injected: %v", resp.Header)
+	}
+}
+
+// TestDumpInterceptorRedacts tests credentials do not appear in dumps while
+// the request and response bodies are left intact.
+func TestDumpInterceptorRedacts(t *testing.T) {
+	ts := newInterceptorTestServer()
+	defer ts.Close()
+
+	var dump bytes.Buffer
+	client := newRetryTestClient(ts, nil)
+	client.auth = &redfish.AuthToken{Token: "secret-token"}
+	client.SetDumpWriter(&dump)
+
+	payload := map[string]interface{}{
+		"UserName": "admin",
+		"Password": "secret-password",
+		"Oem":      map[string]interface{}{"Vendor": map[string]string{"NewPassword": "secret-oem"}},
+	}
+	resp, err := client.Post("/redfish/v1/AccountService/Accounts", payload)
+	if err != nil {
+		t.Fatalf("Error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), "secret-password") {
+		t.Errorf("Response body was altered: %s", body)
+	}
+
+	for _, secret := range []string{"secret-token", "secret-password", "secret-oem", "secret-cookie"} {
+		if strings.Contains(dump.String(), secret) {
+			t.Errorf("Dump contains %s:\n%s", secret, dump.String())
+		}
+	}
+	if !strings.Contains(dump.String(), `"UserName":"admin"`) {
+		t.Errorf("Dump is missing the request body:\n%s", dump.String())
+	}
+}
+
+// TestDumpInterceptorBinary tests non-JSON bodies are left out of dumps.
+func TestDumpInterceptorBinary(t *testing.T) {
+	var received int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = len(body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	var dump bytes.Buffer
+	client := newRetryTestClient(ts, nil)
+	client.SetDumpWriter(&dump)
+
+	image := bytes.Repeat([]byte{0xff}, 1024)
+	resp, err := client.PostMultipart("/redfish/v1/UpdateService/update", map[string]io.Reader{"UpdateFile": bytes.NewReader(image)})
+	if err != nil {
+		t.Fatalf("Error making request: %v", err)
+	}
+	resp.Body.Close()
+
+	if received <= len(image) {
+		t.Errorf("Expected more than %d bytes to be sent, got %d", len(image), received)
+	}
+	if !strings.Contains(dump.String(), "omitted]") || strings.Contains(dump.String(), "\xff") {
+		t.Errorf("Binary body was not omitted:\n%s", dump.String())
+	}
+}
@@ -0,0 +1,200 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package mockup
+
+import (
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Fault is a failure injected in the replies of the server, to test how
+// clients cope with unreliable services. A fault can combine a latency with
+// one of the failures.
+type Fault struct {
+	// Method is the method of the requests affected. Empty matches all
+	// methods.
+	Method string
+	// Path is a pattern as used by path.Match, such as
+	// /redfish/v1/Systems/*, matching the URIs of the requests affected.
+	// Empty matches all URIs.
+	Path string
+
+	// Probability is the chance, from 0 to 1, of each matching request
+	// failing. Zero fails all of them. The random source has a fixed seed, so
+	// a test sees the same failures every run.
+	Probability float64
+	// Count is the number of matching requests failing before the fault is
+	// removed. Zero fails requests until the faults are cleared.
+	Count int
+
+	// Latency delays the reply.
+	Latency time.Duration
+	// StatusCode replies with this error, such as 503 Service Unavailable or
+	// 429 Too Many Requests, instead of serving the request.
+	StatusCode int
+	// RetryAfter is sent in the Retry-After header of the StatusCode error,
+	// rounded to seconds.
+	RetryAfter time.Duration
+	// Truncate cuts the JSON body of the reply in half.
+	Truncate bool
+	// Malformed replaces the body of the reply with invalid JSON.
+	Malformed bool
+	// Drop closes the connection without replying.
+	Drop bool
+	// ExpireSessions ends all the sessions before serving the request, as if
+	// they timed out, so it is refused with 401 Unauthorized.
+	ExpireSessions bool
+	// WrongETag replies with an ETag that does not match the resource, and
+	// refuses requests with an If-Match header with 412 Precondition Failed.
+	WrongETag bool
+}
+
+// faultRule is a fault injected in the server.
+type faultRule struct {
+	Fault
+	// remaining is the number of requests still failing, if Count is set.
+	remaining int
+}
+
+// faultInjector holds the faults injected in a server.
+type faultInjector struct {
+	mu     sync.Mutex
+	rules  []*faultRule
+	random *rand.Rand
+}
+
+// InjectFault adds a fault to the replies of the server. When several faults
+// match a request, the first one added applies:
+//
+//	// The next two reads of the system fail with 503
+//	server.InjectFault(mockup.Fault{
+//		Method:     http.MethodGet,
+//		Path:       "/redfish/v1/Systems/*",
+//		StatusCode: http.StatusServiceUnavailable,
+//		RetryAfter: time.Second,
+//		Count:      2,
+//	})
+func (s *Server) InjectFault(fault Fault) {
+	s.faults.mu.Lock()
+	defer s.faults.mu.Unlock()
+
+	s.faults.rules = append(s.faults.rules, &faultRule{Fault: fault, remaining: fault.Count})
+}
+
+// ClearFaults removes the faults injected in the server.
+func (s *Server) ClearFaults() {
+	s.faults.mu.Lock()
+	defer s.faults.mu.Unlock()
+
+	s.faults.rules = nil
+}
+
+// matchFault finds the fault to inject in the reply to a request.
+func (s *Server) matchFault(r *http.Request) (Fault, bool) {
+	s.faults.mu.Lock()
+	defer s.faults.mu.Unlock()
+
+	uri := cleanURI(r.URL.Path)
+	for i, rule := range s.faults.rules {
+		if rule.Method != "" && rule.Method != r.Method {
+			continue
+		}
+		if rule.Path != "" {
+			if matched, _ := path.Match(cleanURI(rule.Path), uri); !matched {
+				continue
+			}
+		}
+		if rule.Probability > 0 {
+			if s.faults.random == nil {
+				s.faults.random = rand.New(rand.NewSource(1)) // nolint:gosec
+			}
+			if s.faults.random.Float64() >= rule.Probability {
+				continue
+			}
+		}
+
+		if rule.Count > 0 {
+			rule.remaining--
+			if rule.remaining == 0 {
+				s.faults.rules = append(s.faults.rules[:i:i], s.faults.rules[i+1:]...)
+			}
+		}
+		return rule.Fault, true
+	}
+	return Fault{}, false
+}
+
+// inject replies to a request with a fault.
+func (s *Server) inject(w http.ResponseWriter, r *http.Request, fault Fault) {
+	if fault.Latency > 0 {
+		select {
+		case <-time.After(fault.Latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	switch {
+	case fault.Drop:
+		if hijacker, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hijacker.Hijack(); err == nil {
+				conn.Close()
+				return
+			}
+		}
+		panic(http.ErrAbortHandler)
+	case fault.StatusCode != 0:
+		if fault.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(fault.RetryAfter.Round(time.Second)/time.Second)))
+		}
+		writeError(w, fault.StatusCode, "Base.1.8.GeneralError", "A fault was injected in the reply.")
+		return
+	case fault.WrongETag && r.Header.Get("If-Match") != "":
+		writeError(w, http.StatusPreconditionFailed, "Base.1.8.PreconditionFailed",
+			"The ETag supplied did not match the ETag required to change this resource.")
+		return
+	case fault.ExpireSessions:
+		s.expireSessions()
+	}
+
+	if !fault.Truncate && !fault.Malformed && !fault.WrongETag {
+		s.serve(w, r)
+		return
+	}
+
+	reply := httptest.NewRecorder()
+	s.serve(reply, r)
+	body := reply.Body.Bytes()
+	switch {
+	case fault.Malformed:
+		body = []byte(`{"@odata.id": "/redfish/v1/", "Name": Malformed}`)
+	case fault.Truncate:
+		body = body[:len(body)/2]
+	}
+	for name, values := range reply.Header() {
+		w.Header()[name] = values
+	}
+	if fault.WrongETag && w.Header().Get("ETag") != "" {
+		w.Header().Set("ETag", `W/"mismatch"`)
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(reply.Code)
+	_, _ = w.Write(body)
+}
+
+// expireSessions ends all the sessions, as if they timed out.
+func (s *Server) expireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, session := range s.sessions {
+		s.removeResource(session)
+	}
+}
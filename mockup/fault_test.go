@@ -0,0 +1,190 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package mockup_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/trungng1992/gofish"
+	"github.com/trungng1992/gofish/common"
+	"github.com/trungng1992/gofish/mockup"
+	"github.com/trungng1992/gofish/redfish"
+)
+
+// TestFaultLatency tests replies are delayed.
+func TestFaultLatency(t *testing.T) {
+	server, c := connect(t)
+	server.InjectFault(mockup.Fault{Path: systemURI, Latency: 50 * time.Millisecond})
+
+	start := time.Now()
+	_, err := redfish.GetComputerSystem(c, systemURI)
+	if err != nil {
+		t.Fatalf("Error getting the system: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("Expected the reply to be delayed, took: %s", elapsed)
+	}
+}
+
+// TestFaultRetry tests errors are returned for the next calls only, with the
+// Retry-After header.
+func TestFaultRetry(t *testing.T) {
+	server, c := connect(t)
+	server.InjectFault(mockup.Fault{
+		Method:     http.MethodGet,
+		Path:       "/redfish/v1/Systems/*",
+		StatusCode: http.StatusServiceUnavailable,
+		RetryAfter: 2 * time.Second,
+		Count:      1,
+	})
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+systemURI, http.NoBody)
+	if err != nil {
+		t.Fatalf("Error creating the request: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Error getting the system: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable || resp.Header.Get("Retry-After") != "2" {
+		t.Errorf("Unexpected reply: %d %v", resp.StatusCode, resp.Header)
+	}
+
+	server.InjectFault(mockup.Fault{StatusCode: http.StatusTooManyRequests, Count: 2})
+	retrying, err := gofish.Connect(gofish.ClientConfig{
+		Endpoint: server.URL,
+		Username: "admin",
+		Password: "password",
+		RetryPolicy: &gofish.RetryPolicy{
+			MaxAttempts:      3,
+			InitialBackoff:   time.Millisecond,
+			IgnoreRetryAfter: true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected the requests to be retried, got: %v", err)
+	}
+	defer retrying.Logout()
+
+	_, err = redfish.GetComputerSystem(c, systemURI)
+	if err != nil {
+		t.Errorf("Expected the faults to be used up, got: %v", err)
+	}
+}
+
+// TestFaultExpireSessions tests clients log in again when their session
+// expires.
+func TestFaultExpireSessions(t *testing.T) {
+	server, c := connect(t)
+	server.InjectFault(mockup.Fault{Path: systemURI, ExpireSessions: true, Count: 1})
+
+	_, err := redfish.GetComputerSystem(c, systemURI)
+	if err != nil {
+		t.Fatalf("Expected the client to log in again, got: %v", err)
+	}
+
+	sessions, err := c.Service.Sessions()
+	if err != nil || len(sessions) != 1 {
+		t.Errorf("Expected the new session of the client, got: %v %v", sessions, err)
+	}
+}
+
+// TestFaultBadReplies tests truncated, malformed and dropped replies fail.
+func TestFaultBadReplies(t *testing.T) {
+	faults := map[string]mockup.Fault{
+		"truncated": {Truncate: true},
+		"malformed": {Malformed: true},
+		"dropped":   {Drop: true},
+	}
+	for name, fault := range faults {
+		t.Run(name, func(t *testing.T) {
+			server, c := connect(t)
+			fault.Path = systemURI
+			server.InjectFault(fault)
+
+			_, err := redfish.GetComputerSystem(c, systemURI)
+			if err == nil {
+				t.Errorf("Expected the reply to fail")
+			}
+
+			server.ClearFaults()
+			_, err = redfish.GetComputerSystem(c, systemURI)
+			if err != nil {
+				t.Errorf("Error getting the system after clearing the faults: %v", err)
+			}
+		})
+	}
+}
+
+// TestFaultWrongETag tests updates fail with a conflict.
+func TestFaultWrongETag(t *testing.T) {
+	server, c := connect(t)
+	server.InjectFault(mockup.Fault{Path: systemURI, WrongETag: true})
+
+	system, err := redfish.GetComputerSystem(c, systemURI)
+	if err != nil {
+		t.Fatalf("Error getting the system: %v", err)
+	}
+	if system.ETag() != `W/"mismatch"` {
+		t.Errorf("Expected a wrong ETag, got: %s", system.ETag())
+	}
+
+	system.AssetTag = "Chicago-45Z-2382"
+	err = system.Update()
+	if !common.IsConflict(err) {
+		t.Errorf("Expected a conflict, got: %v", err)
+	}
+}
+
+// TestFaultProbability tests faults with a probability fail the same requests
+// every run.
+func TestFaultProbability(t *testing.T) {
+	run := func() []bool {
+		server, c := connect(t)
+		server.InjectFault(mockup.Fault{Path: systemURI, StatusCode: http.StatusInternalServerError, Probability: 0.5})
+
+		var failures []bool
+		for i := 0; i < 20; i++ {
+			_, err := redfish.GetComputerSystem(c, systemURI)
+			failures = append(failures, err != nil)
+		}
+		return failures
+	}
+
+	first, second := run(), run()
+	failed := 0
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("Expected the same failures, got: %v %v", first, second)
+		}
+		if first[i] {
+			failed++
+		}
+	}
+	if failed == 0 || failed == len(first) {
+		t.Errorf("Expected some requests to fail, got: %v", first)
+	}
+}
+
+// TestFaultCollection tests members failing are reported in a collection
+// error.
+func TestFaultCollection(t *testing.T) {
+	server, c := connect(t)
+	server.InjectFault(mockup.Fault{Method: http.MethodGet, Path: systemURI, StatusCode: http.StatusInternalServerError})
+
+	systems, err := c.Service.Systems()
+	var collectionError *common.CollectionError
+	if !errors.As(err, &collectionError) || collectionError.Failures[systemURI] == nil {
+		t.Fatalf("Expected a collection error, got: %v", err)
+	}
+	if len(systems) != 0 {
+		t.Errorf("Expected no systems, got: %v", systems)
+	}
+}
@@ -0,0 +1,535 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+// Package mockup provides a Redfish service for tests. It serves the
+// resources of a mockup in the DMTF format, a directory tree with an
+// index.json file for each resource such as redfish/v1/Systems/1/index.json,
+// from an httptest.Server:
+//
+//	server, err := mockup.NewServer("testdata/public-rackmount1")
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer server.Close()
+//
+//	c, err := gofish.Connect(gofish.ClientConfig{
+//		Endpoint: server.URL,
+//		Username: "admin",
+//		Password: "password",
+//	})
+//
+// The server supports logging in with sessions or basic authentication,
+// PATCH and PUT requests, adding members to collections with POST and
+// removing them with DELETE, ETags and If-Match, and task monitors for
+// actions. Changes are kept for the lifetime of the server.
+//
+// Faults such as latency, errors, malformed replies or expired sessions can be
+// injected in the replies with InjectFault, to test how clients cope with
+// unreliable services.
+package mockup
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultSessionsURI is the sessions collection used when the service root
+// does not link to one.
+const DefaultSessionsURI = "/redfish/v1/SessionService/Sessions"
+
+// tasksURI is the collection the tasks of actions are added to.
+const tasksURI = "/redfish/v1/TaskService/Tasks"
+
+// Server is a Redfish service serving the resources of a mockup.
+type Server struct {
+	*httptest.Server
+
+	// Username and Password are the credentials accepted by the service.
+	// If Username is empty, the service does not require authentication and
+	// accepts any credentials to log in.
+	Username string
+	Password string
+	// TaskPolls is the number of times the task monitor of an action reports
+	// the task as running before it completes. If zero, actions complete
+	// before the service replies.
+	TaskPolls int
+
+	mu        sync.Mutex
+	resources map[string]*resource
+	sessions  map[string]string
+	monitors  map[string]*taskMonitor
+	nextTask  int
+
+	faults faultInjector
+}
+
+// resource is a resource of the service.
+type resource struct {
+	body    map[string]interface{}
+	version int
+}
+
+// etag is the ETag of the current version of the resource.
+func (r *resource) etag() string {
+	return fmt.Sprintf(`W/"%d"`, r.version)
+}
+
+// NewServer starts a server serving the mockup in dir. The caller should
+// call Close when finished, to shut it down.
+func NewServer(dir string) (*Server, error) {
+	s := &Server{
+		resources: make(map[string]*resource),
+		sessions:  make(map[string]string),
+		monitors:  make(map[string]*taskMonitor),
+	}
+
+	err := s.load(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Server = httptest.NewServer(s)
+	return s, nil
+}
+
+// load reads the resources of the mockup in dir.
+func (s *Server) load(dir string) error {
+	err := filepath.WalkDir(dir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || entry.Name() != "index.json" {
+			return err
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		var body map[string]interface{}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		err = decoder.Decode(&body)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+
+		rel, err := filepath.Rel(dir, filepath.Dir(file))
+		if err != nil {
+			return err
+		}
+		s.resources[cleanURI("/"+filepath.ToSlash(rel))] = &resource{body: body, version: 1}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, ok := s.resources["/redfish/v1"]; !ok {
+		return fmt.Errorf("%s has no service root at redfish/v1/index.json", dir)
+	}
+	return nil
+}
+
+// cleanURI normalizes the path of a resource, without a trailing slash.
+func cleanURI(uri string) string {
+	uri = path.Clean("/" + uri)
+	if uri == "/" {
+		return ""
+	}
+	return uri
+}
+
+// Resource returns the current JSON of the resource at uri, or nil if there
+// is none.
+func (s *Server) Resource(uri string) []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res, ok := s.resources[cleanURI(uri)]
+	if !ok {
+		return nil
+	}
+	data, _ := json.Marshal(res.body)
+	return data
+}
+
+// ServeHTTP handles a request to the service, injecting the first fault
+// matching it if any.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if fault, ok := s.matchFault(r); ok {
+		s.inject(w, r, fault)
+		return
+	}
+	s.serve(w, r)
+}
+
+// serve handles a request to the service.
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	uri := cleanURI(r.URL.Path)
+	if !s.authorized(r, uri) {
+		writeError(w, http.StatusUnauthorized, "Base.1.8.NoValidSession",
+			"There is no valid session established with the implementation.")
+		return
+	}
+
+	if monitor, ok := s.monitors[uri]; ok {
+		s.serveMonitor(w, r, uri, monitor)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		s.get(w, uri)
+	case http.MethodPatch, http.MethodPut:
+		s.update(w, r, uri)
+	case http.MethodPost:
+		s.post(w, r, uri)
+	case http.MethodDelete:
+		s.delete(w, r, uri)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Base.1.8.OperationNotAllowed",
+			fmt.Sprintf("The %s method is not allowed.", r.Method))
+	}
+}
+
+// sessionsURI is the URI of the sessions collection.
+func (s *Server) sessionsURI() string {
+	if root, ok := s.resources["/redfish/v1"]; ok {
+		if links, ok := root.body["Links"].(map[string]interface{}); ok {
+			if sessions := odataID(links["Sessions"]); sessions != "" {
+				return cleanURI(sessions)
+			}
+		}
+	}
+	return DefaultSessionsURI
+}
+
+// authorized reports whether the request may access uri. The service root
+// and logging in are always allowed.
+func (s *Server) authorized(r *http.Request, uri string) bool {
+	if s.Username == "" {
+		return true
+	}
+
+	switch {
+	case uri == "/redfish" || uri == "/redfish/v1" || uri == "/redfish/v1/odata" || uri == "/redfish/v1/$metadata":
+		return true
+	case uri == s.sessionsURI() && r.Method == http.MethodPost:
+		return true
+	}
+
+	if _, ok := s.sessions[r.Header.Get("X-Auth-Token")]; ok {
+		return true
+	}
+	username, password, ok := r.BasicAuth()
+	return ok && username == s.Username && password == s.Password
+}
+
+func (s *Server) get(w http.ResponseWriter, uri string) {
+	res, ok := s.resources[uri]
+	if !ok {
+		writeNotFound(w, uri)
+		return
+	}
+	writeResource(w, http.StatusOK, res)
+}
+
+// update applies a PATCH, or replaces the resource for a PUT.
+func (s *Server) update(w http.ResponseWriter, r *http.Request, uri string) {
+	res, ok := s.resources[uri]
+	if !ok {
+		writeNotFound(w, uri)
+		return
+	}
+	if !checkETag(w, r, res) {
+		return
+	}
+
+	patch, ok := readObject(w, r)
+	if !ok {
+		return
+	}
+
+	if r.Method == http.MethodPut {
+		patch["@odata.id"] = res.body["@odata.id"]
+		res.body = patch
+	} else {
+		res.body = merge(res.body, patch).(map[string]interface{})
+	}
+	res.version++
+	writeResource(w, http.StatusOK, res)
+}
+
+func (s *Server) post(w http.ResponseWriter, r *http.Request, uri string) {
+	if i := strings.Index(uri, "/Actions/"); i > 0 {
+		if _, ok := s.resources[uri[:i]]; !ok {
+			writeNotFound(w, uri)
+			return
+		}
+		s.startAction(w, uri)
+		return
+	}
+
+	collection, ok := s.resources[uri]
+	if !ok {
+		writeNotFound(w, uri)
+		return
+	}
+	if _, ok := collection.body["Members"]; !ok {
+		writeError(w, http.StatusMethodNotAllowed, "Base.1.8.OperationNotAllowed",
+			"Only members of collections can be created.")
+		return
+	}
+
+	body, ok := readObject(w, r)
+	if !ok {
+		return
+	}
+
+	if uri != s.sessionsURI() {
+		res := s.addMember(uri, collection, body)
+		w.Header().Set("Location", odataID(res.body))
+		writeResource(w, http.StatusCreated, res)
+		return
+	}
+
+	// Log in
+	username, _ := body["UserName"].(string)
+	password, _ := body["Password"].(string)
+	if s.Username != "" && (username != s.Username || password != s.Password) {
+		writeError(w, http.StatusUnauthorized, "Base.1.8.NoValidSession", "The credentials are invalid.")
+		return
+	}
+
+	token, err := newToken()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Base.1.8.InternalError", err.Error())
+		return
+	}
+	res := s.addMember(uri, collection, map[string]interface{}{
+		"@odata.type": "#Session.v1_0_0.Session",
+		"Name":        "User Session",
+		"UserName":    username,
+	})
+	s.sessions[token] = odataID(res.body)
+
+	w.Header().Set("X-Auth-Token", token)
+	w.Header().Set("Location", odataID(res.body))
+	writeResource(w, http.StatusCreated, res)
+}
+
+// addMember adds a resource with body to a collection.
+func (s *Server) addMember(uri string, collection *resource, body map[string]interface{}) *resource {
+	id := 1
+	for s.resources[uri+"/"+strconv.Itoa(id)] != nil {
+		id++
+	}
+	memberURI := uri + "/" + strconv.Itoa(id)
+
+	body["@odata.id"] = memberURI
+	body["Id"] = strconv.Itoa(id)
+	if _, ok := body["@odata.type"]; !ok {
+		if memberType := memberType(collection.body); memberType != "" {
+			body["@odata.type"] = memberType
+		}
+	}
+	res := &resource{body: body, version: 1}
+	s.resources[memberURI] = res
+
+	members, _ := collection.body["Members"].([]interface{})
+	collection.body["Members"] = append(members, map[string]interface{}{"@odata.id": memberURI})
+	collection.body["Members@odata.count"] = len(members) + 1
+	collection.version++
+	return res
+}
+
+// memberType derives the type of the members of a collection from the
+// collection type, such as #Session.Session for
+// #SessionCollection.SessionCollection.
+func memberType(collection map[string]interface{}) string {
+	collectionType, _ := collection["@odata.type"].(string)
+	name := strings.TrimPrefix(collectionType, "#")
+	if i := strings.Index(name, "Collection."); i > 0 {
+		name = name[:i]
+		return "#" + name + "." + name
+	}
+	return ""
+}
+
+func (s *Server) delete(w http.ResponseWriter, r *http.Request, uri string) {
+	res, ok := s.resources[uri]
+	if !ok {
+		writeNotFound(w, uri)
+		return
+	}
+	if !checkETag(w, r, res) {
+		return
+	}
+
+	s.removeResource(uri)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// removeResource removes a resource, the resources below it and its sessions,
+// and removes it from its collection.
+func (s *Server) removeResource(uri string) {
+	for resourceURI := range s.resources {
+		if resourceURI == uri || strings.HasPrefix(resourceURI, uri+"/") {
+			delete(s.resources, resourceURI)
+		}
+	}
+	for token, session := range s.sessions {
+		if session == uri {
+			delete(s.sessions, token)
+		}
+	}
+
+	// Remove the member from its collection
+	if collection, ok := s.resources[path.Dir(uri)]; ok {
+		if members, ok := collection.body["Members"].([]interface{}); ok {
+			var remaining []interface{}
+			for _, member := range members {
+				if odataID(member) != uri {
+					remaining = append(remaining, member)
+				}
+			}
+			if remaining == nil {
+				remaining = []interface{}{}
+			}
+			collection.body["Members"] = remaining
+			collection.body["Members@odata.count"] = len(remaining)
+			collection.version++
+		}
+	}
+}
+
+// checkETag checks the If-Match header of a request matches the resource,
+// replying 412 Precondition Failed if it does not.
+func checkETag(w http.ResponseWriter, r *http.Request, res *resource) bool {
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" || ifMatch == "*" || ifMatch == res.etag() {
+		return true
+	}
+	writeError(w, http.StatusPreconditionFailed, "Base.1.8.PreconditionFailed",
+		"The ETag supplied did not match the ETag required to change this resource.")
+	return false
+}
+
+// merge applies a PATCH to a resource: objects are merged, and arrays
+// changed element by element with {} leaving an element unchanged and null
+// removing it.
+func merge(target, patch interface{}) interface{} {
+	switch patch := patch.(type) {
+	case map[string]interface{}:
+		object, ok := target.(map[string]interface{})
+		if !ok {
+			object = make(map[string]interface{})
+		}
+		for name, value := range patch {
+			object[name] = merge(object[name], value)
+		}
+		return object
+	case []interface{}:
+		array, _ := target.([]interface{})
+		result := make([]interface{}, 0, len(patch))
+		for i, value := range patch {
+			if value == nil {
+				continue
+			}
+			var element interface{}
+			if i < len(array) {
+				element = array[i]
+			}
+			result = append(result, merge(element, value))
+		}
+		return result
+	}
+	return patch
+}
+
+// readObject decodes the JSON object in the body of a request, replying 400
+// Bad Request if it is not one.
+func readObject(w http.ResponseWriter, r *http.Request) (map[string]interface{}, bool) {
+	var body map[string]interface{}
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	err := decoder.Decode(&body)
+	if err == io.EOF {
+		return make(map[string]interface{}), true
+	}
+	if err != nil || body == nil {
+		writeError(w, http.StatusBadRequest, "Base.1.8.MalformedJSON",
+			"The request body submitted was malformed JSON and could not be parsed by the receiving service.")
+		return nil, false
+	}
+	return body, true
+}
+
+// odataID gets the @odata.id of a reference.
+func odataID(reference interface{}) string {
+	if object, ok := reference.(map[string]interface{}); ok {
+		id, _ := object["@odata.id"].(string)
+		return id
+	}
+	return ""
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	return hex.EncodeToString(b), err
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		statusCode = http.StatusInternalServerError
+		data = []byte(`{}`)
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("OData-Version", "4.0")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(data)
+}
+
+func writeResource(w http.ResponseWriter, statusCode int, res *resource) {
+	w.Header().Set("ETag", res.etag())
+	writeJSON(w, statusCode, res.body)
+}
+
+// writeError replies with a Redfish error.
+func writeError(w http.ResponseWriter, statusCode int, messageID, message string) {
+	writeJSON(w, statusCode, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    messageID,
+			"message": message,
+			"@Message.ExtendedInfo": []interface{}{
+				map[string]interface{}{
+					"MessageId": messageID,
+					"Message":   message,
+				},
+			},
+		},
+	})
+}
+
+func writeNotFound(w http.ResponseWriter, uri string) {
+	writeError(w, http.StatusNotFound, "Base.1.8.ResourceMissingAtURI",
+		fmt.Sprintf("The resource at the URI %s was not found.", uri))
+}
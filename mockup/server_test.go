@@ -0,0 +1,245 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package mockup_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/trungng1992/gofish"
+	"github.com/trungng1992/gofish/common"
+	"github.com/trungng1992/gofish/mockup"
+	"github.com/trungng1992/gofish/redfish"
+)
+
+const systemURI = "/redfish/v1/Systems/437XR1138R2"
+
+func connect(t *testing.T) (*mockup.Server, *gofish.APIClient) {
+	server, err := mockup.NewServer("testdata")
+	if err != nil {
+		t.Fatalf("Error starting the server: %v", err)
+	}
+	t.Cleanup(server.Close)
+	server.Username = "admin"
+	server.Password = "password"
+
+	c, err := gofish.Connect(gofish.ClientConfig{
+		Endpoint: server.URL,
+		Username: "admin",
+		Password: "password",
+	})
+	if err != nil {
+		t.Fatalf("Error connecting: %v", err)
+	}
+	t.Cleanup(c.Logout)
+	return server, c
+}
+
+// TestLogin tests sessions are created and required.
+func TestLogin(t *testing.T) {
+	server, c := connect(t)
+
+	sessions, err := c.Service.Sessions()
+	if err != nil || len(sessions) != 1 {
+		t.Fatalf("Expected the session of the client, got: %v %v", sessions, err)
+	}
+
+	_, err = gofish.Connect(gofish.ClientConfig{Endpoint: server.URL, Username: "admin", Password: "wrong"})
+	if !common.IsUnauthorized(err) {
+		t.Errorf("Expected the login to be refused, got: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+systemURI, http.NoBody)
+	if err != nil {
+		t.Fatalf("Error creating the request: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Error getting the system: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected requests without a session to be refused, got: %d", resp.StatusCode)
+	}
+}
+
+// TestUpdate tests changes are kept and guarded by ETags.
+func TestUpdate(t *testing.T) {
+	server, c := connect(t)
+
+	systems, err := c.Service.Systems()
+	if err != nil || len(systems) != 1 {
+		t.Fatalf("Expected one system, got: %v %v", systems, err)
+	}
+	stale, err := redfish.GetComputerSystem(c, systemURI)
+	if err != nil {
+		t.Fatalf("Error getting the system: %v", err)
+	}
+
+	system := systems[0]
+	system.AssetTag = "Chicago-45Z-2382"
+	system.Boot.BootSourceOverrideTarget = redfish.HddBootSourceOverrideTarget
+	err = system.Update()
+	if err != nil {
+		t.Fatalf("Error updating the system: %v", err)
+	}
+
+	err = system.Refresh()
+	if err != nil {
+		t.Fatalf("Error refreshing the system: %v", err)
+	}
+	if system.AssetTag != "Chicago-45Z-2382" || system.Boot.BootSourceOverrideTarget != redfish.HddBootSourceOverrideTarget ||
+		system.Boot.BootSourceOverrideEnabled != redfish.OnceBootSourceOverrideEnabled {
+		t.Errorf("Unexpected system after the update: %s", server.Resource(systemURI))
+	}
+
+	stale.AssetTag = "Chicago-45Z-2383"
+	err = stale.Update()
+	if !common.IsConflict(err) {
+		t.Errorf("Expected a conflict updating a stale system, got: %v", err)
+	}
+}
+
+// TestUpdateLocation tests the writable properties of a chassis location are
+// sent as a nested PATCH, leaving the rest of the location as it was.
+func TestUpdateLocation(t *testing.T) {
+	server, c := connect(t)
+
+	chassis, err := redfish.GetChassis(c, "/redfish/v1/Chassis/1U")
+	if err != nil {
+		t.Fatalf("Error getting the chassis: %v", err)
+	}
+	chassis.Location.Placement.Rack = "WEB44"
+	chassis.Location.PostalAddress.City = "Beaverton"
+	err = chassis.Update()
+	if err != nil {
+		t.Fatalf("Error updating the chassis: %v", err)
+	}
+
+	var stored struct {
+		Location struct {
+			PostalAddress common.PostalAddress
+			Placement     common.Placement
+			PartLocation  common.PartLocation
+		}
+	}
+	err = json.Unmarshal(server.Resource("/redfish/v1/Chassis/1U"), &stored)
+	if err != nil {
+		t.Fatalf("Error decoding the chassis: %v", err)
+	}
+	location := stored.Location
+	if location.Placement.Rack != "WEB44" || location.PostalAddress.City != "Beaverton" ||
+		location.Placement.RackOffset != 12 || location.Placement.Row != "North 1" ||
+		location.PostalAddress.Street != "1001 SW 5th Avenue" || location.PartLocation.ServiceLabel != "Rack WEB43" {
+		t.Errorf("Unexpected chassis after the update: %s", server.Resource("/redfish/v1/Chassis/1U"))
+	}
+
+	err = chassis.Refresh()
+	if err != nil {
+		t.Fatalf("Error refreshing the chassis: %v", err)
+	}
+	chassis.Location.PartLocation.ServiceLabel = "Rack WEB44"
+	err = chassis.Update()
+	if err == nil || err.Error() != "Location.PartLocation.ServiceLabel field is read only" {
+		t.Errorf("Expected the part location to be read only, got: %v", err)
+	}
+}
+
+// TestUpdateLinks tests a writable link is sent as a reference.
+func TestUpdateLinks(t *testing.T) {
+	server, c := connect(t)
+
+	manager, err := redfish.GetManager(c, "/redfish/v1/Managers/BMC")
+	if err != nil {
+		t.Fatalf("Error getting the manager: %v", err)
+	}
+	if manager.Links.ActiveSoftwareImage != "/redfish/v1/UpdateService/FirmwareInventory/BMC-1" {
+		t.Fatalf("Unexpected active software image: %s", manager.Links.ActiveSoftwareImage)
+	}
+	manager.Links.ActiveSoftwareImage = "/redfish/v1/UpdateService/FirmwareInventory/BMC-2"
+	err = manager.Update()
+	if err != nil {
+		t.Fatalf("Error updating the manager: %v", err)
+	}
+
+	var stored struct {
+		Links struct {
+			ActiveSoftwareImage common.Link
+			SoftwareImages      common.Links
+			ManagerForChassis   common.Links
+		}
+	}
+	err = json.Unmarshal(server.Resource("/redfish/v1/Managers/BMC"), &stored)
+	if err != nil {
+		t.Fatalf("Error decoding the manager: %v", err)
+	}
+	if stored.Links.ActiveSoftwareImage != "/redfish/v1/UpdateService/FirmwareInventory/BMC-2" ||
+		len(stored.Links.SoftwareImages) != 2 || len(stored.Links.ManagerForChassis) != 1 {
+		t.Errorf("Unexpected manager after the update: %s", server.Resource("/redfish/v1/Managers/BMC"))
+	}
+}
+
+// TestCollection tests members are added to and removed from collections.
+func TestCollection(t *testing.T) {
+	server, c := connect(t)
+
+	resp, err := c.Post("/redfish/v1/AccountService/Accounts", map[string]string{"UserName": "operator", "RoleId": "Operator"})
+	if err != nil {
+		t.Fatalf("Error creating the account: %v", err)
+	}
+	resp.Body.Close()
+	location := resp.Header.Get("Location")
+	if resp.StatusCode != http.StatusCreated || location != "/redfish/v1/AccountService/Accounts/2" {
+		t.Fatalf("Unexpected reply creating the account: %d %s", resp.StatusCode, location)
+	}
+
+	account, err := redfish.GetManagerAccount(c, location)
+	if err != nil || account.UserName != "operator" || account.ODataType != "#ManagerAccount.ManagerAccount" {
+		t.Errorf("Unexpected account: %+v %v", account, err)
+	}
+
+	resp, err = c.Delete(location)
+	if err != nil {
+		t.Fatalf("Error deleting the account: %v", err)
+	}
+	resp.Body.Close()
+
+	var accounts common.LinksCollection
+	err = json.Unmarshal(server.Resource("/redfish/v1/AccountService/Accounts"), &accounts)
+	if err != nil || accounts.Count != 1 || len(accounts.Members) != 1 {
+		t.Errorf("Expected the account to be removed, got: %+v %v", accounts, err)
+	}
+	_, err = redfish.GetManagerAccount(c, location)
+	if !common.IsNotFound(err) {
+		t.Errorf("Expected the account to be gone, got: %v", err)
+	}
+}
+
+// TestTaskMonitor tests actions complete through a task monitor.
+func TestTaskMonitor(t *testing.T) {
+	server, c := connect(t)
+	server.TaskPolls = 2
+
+	resp, err := c.Post(systemURI+"/Actions/ComputerSystem.Reset", map[string]string{"ResetType": "ForceOff"})
+	if err != nil {
+		t.Fatalf("Error resetting the system: %v", err)
+	}
+	op, err := redfish.NewAsyncOperation(c, resp)
+	if err != nil {
+		t.Fatalf("Error following the operation: %v", err)
+	}
+	if op.Monitor == "" || op.Done() {
+		t.Fatalf("Expected a task monitor, got: %+v", op)
+	}
+
+	op.PollInterval = time.Millisecond
+	err = op.Wait(context.Background())
+	if err != nil || op.TaskState() != redfish.CompletedTaskState {
+		t.Errorf("Expected the task to complete, got: %s %v", op.TaskState(), err)
+	}
+}
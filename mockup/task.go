@@ -0,0 +1,93 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package mockup
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// taskMonitor is the task monitor of an action.
+type taskMonitor struct {
+	// task is the resource of the task.
+	task *resource
+	// polls is the number of times the task is still reported running.
+	polls int
+}
+
+// startAction replies to an action. It completes at once, or after its task
+// monitor has been polled TaskPolls times.
+func (s *Server) startAction(w http.ResponseWriter, uri string) {
+	if s.TaskPolls <= 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	s.nextTask++
+	id := strconv.Itoa(s.nextTask)
+	body := map[string]interface{}{
+		"@odata.type":     "#Task.v1_4_3.Task",
+		"Name":            "Task " + id,
+		"TaskState":       "Running",
+		"TaskStatus":      "OK",
+		"PercentComplete": 0,
+		"Payload": map[string]interface{}{
+			"HttpOperation": http.MethodPost,
+			"TargetUri":     uri,
+		},
+	}
+
+	var task *resource
+	if tasks, ok := s.resources[tasksURI]; ok {
+		task = s.addMember(tasksURI, tasks, body)
+	} else {
+		body["@odata.id"] = tasksURI + "/" + id
+		body["Id"] = id
+		task = &resource{body: body, version: 1}
+		s.resources[tasksURI+"/"+id] = task
+	}
+
+	id, _ = task.body["Id"].(string)
+	monitorURI := "/redfish/v1/TaskService/TaskMonitors/" + id
+	s.monitors[monitorURI] = &taskMonitor{task: task, polls: s.TaskPolls}
+	task.body["TaskMonitor"] = monitorURI
+
+	w.Header().Set("Location", monitorURI)
+	writeJSON(w, http.StatusAccepted, task.body)
+}
+
+// serveMonitor replies to a request to a task monitor, 202 Accepted while
+// the task is running and the completed task after. DELETE cancels the task.
+func (s *Server) serveMonitor(w http.ResponseWriter, r *http.Request, uri string, monitor *taskMonitor) {
+	task := monitor.task
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodDelete:
+		task.body["TaskState"] = "Cancelled"
+		task.version++
+		delete(s.monitors, uri)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Base.1.8.OperationNotAllowed",
+			"Task monitors can only be read or deleted.")
+		return
+	}
+
+	if monitor.polls > 0 {
+		monitor.polls--
+		task.body["PercentComplete"] = 100 * (s.TaskPolls - monitor.polls) / (s.TaskPolls + 1)
+		task.version++
+		w.Header().Set("Location", uri)
+		writeJSON(w, http.StatusAccepted, task.body)
+		return
+	}
+
+	task.body["TaskState"] = "Completed"
+	task.body["PercentComplete"] = 100
+	task.version++
+	delete(s.monitors, uri)
+	writeJSON(w, http.StatusOK, task.body)
+}
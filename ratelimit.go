@@ -0,0 +1,120 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimit limits the load put on a service by a client. It is meant for
+// BMCs that stop responding when they receive too many requests.
+type RateLimit struct {
+	// RequestsPerSecond is the average number of requests sent per second.
+	// Zero means no limit.
+	RequestsPerSecond float64
+	// Burst is the number of requests that can be sent at once after a
+	// quiet period, above RequestsPerSecond. It defaults to 1.
+	Burst int
+	// MaxInFlight is the number of requests that can be outstanding at the
+	// same time, until the headers of their response are received. Zero
+	// means no limit.
+	MaxInFlight int
+}
+
+// rateLimiter enforces a RateLimit. Retries and collection members fetched
+// in parallel all go through it.
+type rateLimiter struct {
+	mu       sync.Mutex
+	rate     float64
+	burst    float64
+	tokens   float64
+	last     time.Time
+	inFlight chan struct{}
+}
+
+// newRateLimiter creates the limiter of a RateLimit, or nil if it does not
+// limit anything.
+func newRateLimiter(limit *RateLimit) *rateLimiter {
+	if limit == nil || (limit.RequestsPerSecond <= 0 && limit.MaxInFlight <= 0) {
+		return nil
+	}
+
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+
+	l := &rateLimiter{
+		rate:   limit.RequestsPerSecond,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+	if limit.MaxInFlight > 0 {
+		l.inFlight = make(chan struct{}, limit.MaxInFlight)
+	}
+	return l
+}
+
+// reserve takes a token and returns how long to wait before using it.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// unreserve gives back a token that was not used.
+func (l *rateLimiter) unreserve() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens++
+}
+
+// acquire waits until a request can be sent. The returned function must be
+// called once the response headers are received, whether or not the caller
+// reads and closes the body.
+func (l *rateLimiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	if l.inFlight != nil {
+		select {
+		case l.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	release := func() {
+		if l.inFlight != nil {
+			<-l.inFlight
+		}
+	}
+
+	if l.rate > 0 {
+		if err := sleepContext(ctx, l.reserve()); err != nil {
+			l.unreserve()
+			release()
+			return nil, err
+		}
+	}
+
+	return release, nil
+}
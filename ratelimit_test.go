@@ -0,0 +1,112 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRateLimitInFlight tests the number of outstanding requests is limited.
+func TestRateLimitInFlight(t *testing.T) {
+	var running, maxRunning int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			seen := atomic.LoadInt32(&maxRunning)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxRunning, seen, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		w.Write([]byte(retryServiceRoot)) // nolint
+	}))
+	defer ts.Close()
+
+	client := newRetryTestClient(ts, nil)
+	client.limiter = newRateLimiter(&RateLimit{MaxInFlight: 2})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get("/redfish/v1/")
+			if err != nil {
+				t.Errorf("Request failed: %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if maxRunning > 2 {
+		t.Errorf("Expected at most 2 requests in flight, got %d", maxRunning)
+	}
+}
+
+// TestRateLimitRequestsPerSecond tests requests are spaced out.
+func TestRateLimitRequestsPerSecond(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(retryServiceRoot)) // nolint
+	}))
+	defer ts.Close()
+
+	client := newRetryTestClient(ts, nil)
+	client.limiter = newRateLimiter(&RateLimit{RequestsPerSecond: 100, Burst: 2})
+
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		resp, err := client.Get("/redfish/v1/")
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	// Two requests go through at once, the other four wait 10ms each
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Errorf("Requests were not rate limited, took %s", elapsed)
+	}
+}
+
+// TestRateLimitUnclosedBodies tests responses whose body is never closed do
+// not hold on to their in-flight slot.
+func TestRateLimitUnclosedBodies(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(retryServiceRoot)) // nolint
+	}))
+	defer ts.Close()
+
+	client := newRetryTestClient(ts, nil)
+	client.limiter = newRateLimiter(&RateLimit{MaxInFlight: 2})
+
+	done := make(chan error)
+	go func() {
+		for i := 0; i < 5; i++ {
+			// The body is dropped like some callers do
+			if _, err := client.Get("/redfish/v1/"); err != nil { // nolint:bodyclose
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Request failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Requests blocked on the in-flight limit")
+	}
+}
@@ -285,8 +285,15 @@ func (accountservice *AccountService) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a AccountService object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (accountservice *AccountService) MarshalJSON() ([]byte, error) {
+	type temp AccountService
+	return common.MarshalObject(accountservice.rawData, new(AccountService), temp(*accountservice))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (accountservice *AccountService) Update() error {
+func (accountservice *AccountService) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(AccountService)
@@ -308,26 +315,13 @@ func (accountservice *AccountService) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(accountservice).Elem()
 
-	return accountservice.Entity.Update(originalElement, currentElement, readWriteFields)
+	return accountservice.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetAccountService will get the AccountService instance from the Redfish
 // service.
 func GetAccountService(c common.Client, uri string) (*AccountService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var t AccountService
-	err = json.NewDecoder(resp.Body).Decode(&t)
-	if err != nil {
-		return nil, err
-	}
-
-	t.SetClient(c)
-	return &t, nil
+	return common.GetObject[AccountService](c, uri)
 }
 
 // Accounts get the accounts from the account service
@@ -43,7 +43,7 @@ type ArrayController struct {
 	logicaldrives      string
 	unconfigureddrives string
 
-	// rawData holds the original serialized JSON so we can compare updates.
+	// rawData holds the original serialized JSON so the object can be marshaled back.
 	rawData []byte
 }
 
@@ -80,50 +80,19 @@ func (arraycontroller *ArrayController) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
-func GetArrayController(c common.Client, uri string) (*ArrayController, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-
-	defer resp.Body.Close()
-
-	var arrayController ArrayController
-	err = json.NewDecoder(resp.Body).Decode(&arrayController)
-	if err != nil {
-		return nil, err
-	}
+// MarshalJSON marshals a ArrayController object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (arraycontroller *ArrayController) MarshalJSON() ([]byte, error) {
+	type temp ArrayController
+	return common.MarshalObject(arraycontroller.rawData, new(ArrayController), temp(*arraycontroller))
+}
 
-	arrayController.SetClient(c)
-	return &arrayController, nil
+func GetArrayController(c common.Client, uri string) (*ArrayController, error) {
+	return common.GetObject[ArrayController](c, uri)
 }
 
 func ListReferencedArrayControllers(c common.Client, link string) ([]*ArrayController, error) {
-	var result []*ArrayController
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, arrayControllerLink := range links.ItemLinks {
-		arrayController, err := GetArrayController(c, arrayControllerLink)
-		if err != nil {
-			collectionError.Failures[arrayControllerLink] = err
-		} else {
-			result = append(result, arrayController)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return common.ListReferenced[ArrayController](c, link)
 }
 
 func (arrayController *ArrayController) PhysicalDrive() (*PhysicalDrive, error) {
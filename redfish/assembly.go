@@ -51,8 +51,15 @@ func (assembly *Assembly) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a Assembly object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (assembly *Assembly) MarshalJSON() ([]byte, error) {
+	type temp Assembly
+	return common.MarshalObject(assembly.rawData, new(Assembly), temp(*assembly))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (assembly *Assembly) Update() error {
+func (assembly *Assembly) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(Assembly)
@@ -68,55 +75,18 @@ func (assembly *Assembly) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(assembly).Elem()
 
-	return assembly.Entity.Update(originalElement, currentElement, readWriteFields)
+	return assembly.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetAssembly will get a Assembly instance from the service.
 func GetAssembly(c common.Client, uri string) (*Assembly, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var assembly Assembly
-	err = json.NewDecoder(resp.Body).Decode(&assembly)
-	if err != nil {
-		return nil, err
-	}
-
-	assembly.SetClient(c)
-	return &assembly, nil
+	return common.GetObject[Assembly](c, uri)
 }
 
 // ListReferencedAssemblys gets the collection of Assembly from
 // a provided reference.
-func ListReferencedAssemblys(c common.Client, link string) ([]*Assembly, error) { //nolint:dupl
-	var result []*Assembly
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, assemblyLink := range links.ItemLinks {
-		assembly, err := GetAssembly(c, assemblyLink)
-		if err != nil {
-			collectionError.Failures[assemblyLink] = err
-		} else {
-			result = append(result, assembly)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedAssemblys(c common.Client, link string) ([]*Assembly, error) {
+	return common.ListReferenced[Assembly](c, link)
 }
 
 // AssemblyData is information about an assembly.
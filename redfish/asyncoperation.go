@@ -0,0 +1,341 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/trungng1992/gofish/common"
+)
+
+// DefaultTaskPollInterval is how often the task monitor of an operation is
+// polled when the service does not say with Retry-After.
+const DefaultTaskPollInterval = 5 * time.Second
+
+// ErrOperationCancelled is the outcome of an operation cancelled with Cancel.
+var ErrOperationCancelled = errors.New("operation cancelled")
+
+// TaskFailedError is returned when the task of an operation ended without
+// completing successfully.
+type TaskFailedError struct {
+	// Task is the last state of the task.
+	Task *Task
+}
+
+func (e *TaskFailedError) Error() string {
+	msg := fmt.Sprintf("task %s ended in state %s", e.Task.ID, e.Task.TaskState)
+	for i := range e.Task.Messages {
+		message := e.Task.Messages[i].Message
+		if message == "" {
+			message = e.Task.Messages[i].MessageID
+		}
+		msg = fmt.Sprintf("%s: %s", msg, message)
+	}
+	return msg
+}
+
+// isTaskFinished reports whether a task ended, successfully or not.
+func isTaskFinished(state TaskState) bool {
+	switch state {
+	case CompletedTaskState, KilledTaskState, ExceptionTaskState, CancelledTaskState:
+		return true
+	}
+	return false
+}
+
+// AsyncOperation follows an operation the service may complete after
+// replying, such as a firmware update or a secure erase. The service then
+// replies 202 Accepted with the URI of a task monitor in the Location header,
+// which is polled for the outcome of the operation.
+//
+// Operations the service completed before replying are returned done, so
+// Wait returns at once.
+type AsyncOperation struct {
+	// Monitor is the URI of the task monitor of the operation. It is empty
+	// if the operation completed before the service replied.
+	Monitor string
+	// PollInterval is how often the task monitor is polled when the service
+	// does not say with Retry-After. Zero uses DefaultTaskPollInterval.
+	PollInterval time.Duration
+
+	client common.Client
+
+	mu         sync.Mutex
+	done       bool
+	err        error
+	task       *Task
+	statusCode int
+	result     []byte
+	retryAfter time.Duration
+}
+
+// monitorPath returns the path of a task monitor, which services may give as
+// an absolute URL.
+func monitorPath(location string) string {
+	u, err := url.Parse(location)
+	if err != nil || !u.IsAbs() {
+		return location
+	}
+	return u.RequestURI()
+}
+
+// decodeTask decodes a response body if it is a Task.
+func decodeTask(body []byte) *Task {
+	if len(body) == 0 {
+		return nil
+	}
+
+	var task Task
+	if err := json.Unmarshal(body, &task); err != nil || task.TaskState == "" {
+		return nil
+	}
+	return &task
+}
+
+// NewAsyncOperation creates the handle of an operation from the response the
+// service gave when it was requested. The response body is read and closed.
+func NewAsyncOperation(c common.Client, resp *http.Response) (*AsyncOperation, error) {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	op := &AsyncOperation{client: c}
+	task := decodeTask(body)
+	if task != nil {
+		task.SetClient(c)
+	}
+
+	if resp.StatusCode == http.StatusAccepted {
+		op.Monitor = resp.Header.Get("Location")
+		if op.Monitor == "" && task != nil {
+			op.Monitor = task.TaskMonitor
+		}
+		op.Monitor = monitorPath(op.Monitor)
+		op.retryAfter, _ = common.ParseRetryAfter(resp.Header.Get("Retry-After"))
+	}
+
+	if op.Monitor == "" {
+		op.finish(resp.StatusCode, body, task)
+	} else {
+		op.task = task
+	}
+	return op, nil
+}
+
+// newActionOperation performs a POST on an action target of entity and
+// returns the handle of the resulting operation. The request carries the
+// entity ETag in If-Match unless common.SkipETagMatch is given.
+func newActionOperation(entity *common.Entity, target string, payload interface{}, opts []common.UpdateOption) (*AsyncOperation, error) {
+	resp, err := entity.PostWithResponse(target, payload, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewAsyncOperation(entity.Client, resp)
+}
+
+// finish records the outcome of the operation. The lock must be held.
+func (op *AsyncOperation) finish(statusCode int, body []byte, task *Task) {
+	op.done = true
+	op.statusCode = statusCode
+	if task != nil {
+		op.task = task
+		if task.TaskState != CompletedTaskState {
+			op.err = &TaskFailedError{Task: task}
+		}
+		return
+	}
+	op.result = body
+}
+
+// Poll checks the task monitor once and reports whether the operation is
+// done. Errors reaching the service are returned without ending the
+// operation, so it can be polled again.
+func (op *AsyncOperation) Poll(ctx context.Context) (bool, error) {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+
+	if op.done {
+		return true, nil
+	}
+
+	resp, err := common.WithContext(ctx, op.client).Get(op.Monitor)
+	if err != nil {
+		var e *common.Error
+		switch {
+		case !errors.As(err, &e) || e.HTTPReturnedStatusCode == 0 || common.IsRetryable(err):
+			return false, err
+		case common.IsNotFound(err) && op.task != nil && op.task.TaskState == CompletedTaskState:
+			// The monitor went away after the task completed
+			op.finish(http.StatusOK, nil, op.task)
+		default:
+			// The monitor replies with the outcome of the operation
+			op.done = true
+			op.statusCode = e.HTTPReturnedStatusCode
+			op.err = err
+		}
+		return true, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return false, err
+	}
+
+	task := decodeTask(body)
+	if task != nil {
+		task.SetClient(op.client)
+	}
+
+	op.retryAfter, _ = common.ParseRetryAfter(resp.Header.Get("Retry-After"))
+	switch {
+	case resp.StatusCode == http.StatusAccepted:
+		if task != nil {
+			op.task = task
+		}
+	case task != nil && !isTaskFinished(task.TaskState):
+		// Some services give the task itself rather than a monitor
+		op.task = task
+	default:
+		op.finish(resp.StatusCode, body, task)
+	}
+
+	return op.done, nil
+}
+
+// Wait polls the task monitor until the operation is done or the context is
+// done, and returns the outcome of the operation. Transient errors polling the
+// monitor, as reported by common.IsRetryable, are retried at the next poll.
+func (op *AsyncOperation) Wait(ctx context.Context) error {
+	for !op.Done() {
+		op.mu.Lock()
+		wait := op.retryAfter
+		op.mu.Unlock()
+		if wait <= 0 {
+			wait = op.PollInterval
+		}
+		if wait <= 0 {
+			wait = DefaultTaskPollInterval
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		// Transient failures reaching the monitor are polled through
+		if _, err := op.Poll(ctx); err != nil && !common.IsRetryable(err) {
+			return err
+		}
+	}
+
+	return op.Err()
+}
+
+// Cancel asks the service to cancel the operation by deleting its task
+// monitor. Operations already done are left alone.
+func (op *AsyncOperation) Cancel(ctx context.Context) error {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+
+	if op.done {
+		return nil
+	}
+
+	resp, err := common.WithContext(ctx, op.client).Delete(op.Monitor)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	op.done = true
+	op.err = ErrOperationCancelled
+	return nil
+}
+
+// Done reports whether the operation is done.
+func (op *AsyncOperation) Done() bool {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	return op.done
+}
+
+// Err returns the outcome of the operation once it is done: nil if it
+// succeeded, the error returned by the service, a *TaskFailedError, or
+// ErrOperationCancelled.
+func (op *AsyncOperation) Err() error {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	return op.err
+}
+
+// Task returns the last state of the task of the operation, or nil if the
+// service did not give one.
+func (op *AsyncOperation) Task() *Task {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	return op.task
+}
+
+// PercentComplete returns the progress of the task, or zero if it is not
+// known.
+func (op *AsyncOperation) PercentComplete() int {
+	if task := op.Task(); task != nil {
+		return task.PercentComplete
+	}
+	return 0
+}
+
+// TaskState returns the state of the task, or an empty state if it is not
+// known.
+func (op *AsyncOperation) TaskState() TaskState {
+	if task := op.Task(); task != nil {
+		return task.TaskState
+	}
+	return ""
+}
+
+// Messages returns the messages of the task.
+func (op *AsyncOperation) Messages() []common.Message {
+	if task := op.Task(); task != nil {
+		return task.Messages
+	}
+	return nil
+}
+
+// StatusCode returns the HTTP status code of the outcome of the operation,
+// once it is done.
+func (op *AsyncOperation) StatusCode() int {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	return op.statusCode
+}
+
+// Result returns the body of the response that completed the operation, if
+// it was not a task. It is decoded into v if v is not nil.
+func (op *AsyncOperation) Result(v interface{}) ([]byte, error) {
+	op.mu.Lock()
+	result := op.result
+	op.mu.Unlock()
+
+	if v == nil || len(bytes.TrimSpace(result)) == 0 {
+		return result, nil
+	}
+	return result, json.Unmarshal(result, v)
+}
@@ -0,0 +1,198 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trungng1992/gofish/common"
+)
+
+func taskResponse(statusCode int, header http.Header, body string) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{StatusCode: statusCode, Header: header, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func acceptedResponse(location string) *http.Response {
+	return taskResponse(http.StatusAccepted, http.Header{"Location": {location}},
+		`{"@odata.id": "/redfish/v1/TaskService/Tasks/1", "Id": "1", "TaskState": "New", "PercentComplete": 0}`)
+}
+
+// TestAsyncOperationWait tests the task monitor is polled until the task
+// completes, with its progress available meanwhile.
+func TestAsyncOperationWait(t *testing.T) {
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {acceptedResponse("https://bmc.example.com/redfish/v1/TaskMonitors/1")},
+			http.MethodGet: {
+				taskResponse(http.StatusAccepted, http.Header{"Retry-After": {"0"}},
+					`{"Id": "1", "TaskState": "Running", "PercentComplete": 50}`),
+				taskResponse(http.StatusOK, nil,
+					`{"Id": "1", "TaskState": "Completed", "PercentComplete": 100, "Messages": [{"MessageId": "Base.1.8.Success"}]}`),
+			},
+		},
+	}
+
+	drive := &Drive{secureEraseTarget: "/redfish/v1/Drives/1/Actions/Drive.SecureErase"}
+	drive.SetClient(testClient)
+
+	op, err := drive.SecureErase()
+	if err != nil {
+		t.Fatalf("Error starting the operation: %v", err)
+	}
+	if op.Done() || op.Monitor != "/redfish/v1/TaskMonitors/1" || op.TaskState() != NewTaskState {
+		t.Fatalf("Unexpected operation: %+v", op)
+	}
+	op.PollInterval = time.Millisecond
+
+	if done, err := op.Poll(context.Background()); done || err != nil || op.PercentComplete() != 50 {
+		t.Errorf("Expected the task to be running, got %t %v %d%%", done, err, op.PercentComplete())
+	}
+
+	if err := op.Wait(context.Background()); err != nil {
+		t.Errorf("Error waiting for the operation: %v", err)
+	}
+	if op.TaskState() != CompletedTaskState || op.PercentComplete() != 100 || len(op.Messages()) != 1 {
+		t.Errorf("Unexpected final task: %+v", op.Task())
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 3 || calls[1].URL != "/redfish/v1/TaskMonitors/1" {
+		t.Errorf("Unexpected calls: %+v", calls)
+	}
+}
+
+// TestAsyncOperationWaitTransient tests transient errors polling the task
+// monitor do not end the wait.
+func TestAsyncOperationWaitTransient(t *testing.T) {
+	busy := func(statusCode int) *http.Response {
+		return taskResponse(statusCode, nil, `{"error": {"code": "Base.1.8.ServiceTemporarilyUnavailable"}}`)
+	}
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {acceptedResponse("/redfish/v1/TaskMonitors/1")},
+			http.MethodGet: {
+				busy(http.StatusServiceUnavailable),
+				busy(http.StatusTooManyRequests),
+				taskResponse(http.StatusOK, nil, `{"Id": "1", "TaskState": "Completed", "PercentComplete": 100}`),
+			},
+		},
+	}
+
+	drive := &Drive{secureEraseTarget: "/redfish/v1/Drives/1/Actions/Drive.SecureErase"}
+	drive.SetClient(testClient)
+
+	op, err := drive.SecureErase()
+	if err != nil {
+		t.Fatalf("Error starting the operation: %v", err)
+	}
+	op.PollInterval = time.Millisecond
+
+	if err := op.Wait(context.Background()); err != nil {
+		t.Errorf("Expected the wait to go through transient errors, got: %v", err)
+	}
+	if op.TaskState() != CompletedTaskState || len(testClient.CapturedCalls()) != 4 {
+		t.Errorf("Unexpected operation after %d calls: %+v", len(testClient.CapturedCalls()), op.Task())
+	}
+}
+
+// TestAsyncOperationFailed tests tasks ending in an exception are reported.
+func TestAsyncOperationFailed(t *testing.T) {
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {acceptedResponse("/redfish/v1/TaskMonitors/1")},
+			http.MethodGet: {
+				taskResponse(http.StatusOK, nil,
+					`{"Id": "1", "TaskState": "Exception", "Messages": [{"Message": "Drive is locked."}]}`),
+			},
+		},
+	}
+
+	bios := &Bios{resetBiosTarget: "/redfish/v1/Systems/1/Bios/Actions/Bios.ResetBios"}
+	bios.SetClient(testClient)
+
+	op, err := bios.ResetBios()
+	if err != nil {
+		t.Fatalf("Error starting the operation: %v", err)
+	}
+	op.PollInterval = time.Millisecond
+
+	var taskErr *TaskFailedError
+	err = op.Wait(context.Background())
+	if !errors.As(err, &taskErr) || taskErr.Task.TaskState != ExceptionTaskState {
+		t.Errorf("Expected a task failure, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "Drive is locked.") {
+		t.Errorf("The task messages should be in the error: %v", err)
+	}
+}
+
+// TestAsyncOperationSynchronous tests operations completed when requested
+// are done at once.
+func TestAsyncOperationSynchronous(t *testing.T) {
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {taskResponse(http.StatusNoContent, nil, "")},
+		},
+	}
+
+	drive := &Drive{secureEraseTarget: "/redfish/v1/Drives/1/Actions/Drive.SecureErase"}
+	drive.SetClient(testClient)
+
+	op, err := drive.SecureErase()
+	if err != nil {
+		t.Fatalf("Error starting the operation: %v", err)
+	}
+	if !op.Done() || op.Wait(context.Background()) != nil || op.StatusCode() != http.StatusNoContent {
+		t.Errorf("The operation should be done: %+v", op)
+	}
+	if len(testClient.CapturedCalls()) != 1 {
+		t.Error("No monitor should have been polled")
+	}
+}
+
+// TestAsyncOperationCancel tests cancelling deletes the task monitor, and
+// waiting stops with the context.
+func TestAsyncOperationCancel(t *testing.T) {
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {acceptedResponse("/redfish/v1/TaskMonitors/1")},
+		},
+	}
+
+	drive := &Drive{secureEraseTarget: "/redfish/v1/Drives/1/Actions/Drive.SecureErase"}
+	drive.SetClient(testClient)
+
+	op, err := drive.SecureErase()
+	if err != nil {
+		t.Fatalf("Error starting the operation: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := op.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the wait to time out, got: %v", err)
+	}
+
+	if err := op.Cancel(context.Background()); err != nil {
+		t.Fatalf("Error cancelling: %v", err)
+	}
+	if !op.Done() || !errors.Is(op.Err(), ErrOperationCancelled) {
+		t.Errorf("The operation should be cancelled, got: %v", op.Err())
+	}
+
+	calls := testClient.CapturedCalls()
+	if last := calls[len(calls)-1]; last.Action != http.MethodDelete || last.URL != "/redfish/v1/TaskMonitors/1" {
+		t.Errorf("Expected a DELETE on the monitor, got: %+v", last)
+	}
+}
@@ -89,10 +89,6 @@ type Bios struct {
 	activeSoftwareImage string
 	// rawData holds the original serialized JSON so we can compare updates.
 	rawData []byte
-	// etag contains the etag header when fetching the object. This is used to
-	// control updates to make sure the object has not been modified my a different
-	// process between fetching and updating that could cause conflicts.
-	etag string
 }
 
 // UnmarshalJSON unmarshals an Bios object from the raw JSON.
@@ -144,59 +140,27 @@ func (bios *Bios) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a Bios object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (bios *Bios) MarshalJSON() ([]byte, error) {
+	type temp Bios
+	return common.MarshalObject(bios.rawData, new(Bios), temp(*bios))
+}
+
 // GetBios will get a Bios instance from the service.
 func GetBios(c common.Client, uri string) (*Bios, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var bios Bios
-	err = json.NewDecoder(resp.Body).Decode(&bios)
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.Header["Etag"] != nil {
-		bios.etag = resp.Header["Etag"][0]
-	}
-
-	bios.SetClient(c)
-	return &bios, nil
+	return common.GetObject[Bios](c, uri)
 }
 
 // ListReferencedBioss gets the collection of Bios from a provided reference.
-func ListReferencedBioss(c common.Client, link string) ([]*Bios, error) { //nolint:dupl
-	var result []*Bios
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, biosLink := range links.ItemLinks {
-		bios, err := GetBios(c, biosLink)
-		if err != nil {
-			collectionError.Failures[biosLink] = err
-		} else {
-			result = append(result, bios)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedBioss(c common.Client, link string) ([]*Bios, error) {
+	return common.ListReferenced[Bios](c, link)
 }
 
 // ChangePassword shall change the selected BIOS password.
-func (bios *Bios) ChangePassword(passwordName, oldPassword, newPassword string) error {
+// The request carries the BIOS ETag in If-Match unless common.SkipETagMatch is
+// given.
+func (bios *Bios) ChangePassword(passwordName, oldPassword, newPassword string, opts ...common.UpdateOption) error {
 	if passwordName == "" {
 		return fmt.Errorf("password name must be supplied")
 	}
@@ -218,23 +182,17 @@ func (bios *Bios) ChangePassword(passwordName, oldPassword, newPassword string)
 		NewPassword:  newPassword,
 	}
 
-	resp, err := bios.Client.Post(bios.changePasswordTarget, t)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-
-	return err
+	return bios.Post(bios.changePasswordTarget, t, opts...)
 }
 
 // ResetBios shall perform a reset of the BIOS attributes to their default values.
 // A system reset may be required for the default values to be applied. This
-// action may impact other resources.
-func (bios *Bios) ResetBios() error {
-	resp, err := bios.Client.Post(bios.resetBiosTarget, nil)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+// action may impact other resources. The returned operation can be waited on
+// for services that reset the attributes after replying.
+// The request carries the BIOS ETag in If-Match unless common.SkipETagMatch is
+// given.
+func (bios *Bios) ResetBios(opts ...common.UpdateOption) (*AsyncOperation, error) {
+	return newActionOperation(&bios.Entity, bios.resetBiosTarget, nil, opts)
 }
 
 // AllowedAttributeUpdateApplyTimes returns the set of allowed apply times to request when
@@ -253,7 +211,7 @@ func (bios *Bios) AllowedAttributeUpdateApplyTimes() []common.ApplyTime {
 }
 
 // UpdateBiosAttributesApplyAt is used to update attribute values and set apply time together
-func (bios *Bios) UpdateBiosAttributesApplyAt(attrs BiosAttributes, applyTime common.ApplyTime) error {
+func (bios *Bios) UpdateBiosAttributesApplyAt(attrs BiosAttributes, applyTime common.ApplyTime, opts ...common.UpdateOption) error {
 	payload := make(map[string]interface{})
 
 	// Get a representation of the object's original state so we can find what
@@ -279,24 +237,15 @@ func (bios *Bios) UpdateBiosAttributesApplyAt(attrs BiosAttributes, applyTime co
 			data["@Redfish.SettingsApplyTime"] = map[string]string{"ApplyTime": string(applyTime)}
 		}
 
-		var header = make(map[string]string)
-		if bios.etag != "" {
-			header["If-Match"] = bios.etag
-		}
-
-		resp, err := bios.Client.PatchWithHeaders(bios.settingsTarget, data, header)
-		if err != nil {
-			return err
-		}
-		defer resp.Body.Close()
+		return bios.Patch(bios.settingsTarget, data, opts...)
 	}
 
 	return nil
 }
 
 // UpdateBiosAttributes is used to update attribute values.
-func (bios *Bios) UpdateBiosAttributes(attrs BiosAttributes) error {
-	return bios.UpdateBiosAttributesApplyAt(attrs, "")
+func (bios *Bios) UpdateBiosAttributes(attrs BiosAttributes, opts ...common.UpdateOption) error {
+	return bios.UpdateBiosAttributesApplyAt(attrs, "", opts...)
 }
 
 // GetActiveSoftwareImage gets the SoftwareInventory which represents the
@@ -313,8 +313,15 @@ func (chassis *Chassis) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a Chassis object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (chassis *Chassis) MarshalJSON() ([]byte, error) {
+	type temp Chassis
+	return common.MarshalObject(chassis.rawData, new(Chassis), temp(*chassis))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (chassis *Chassis) Update() error {
+func (chassis *Chassis) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(Chassis)
@@ -326,55 +333,30 @@ func (chassis *Chassis) Update() error {
 	readWriteFields := []string{
 		"AssetTag",
 		"IndicatorLED",
+		"Location.AltitudeMeters",
+		"Location.Contacts",
+		"Location.Info",
+		"Location.InfoFormat",
+		"Location.Latitude",
+		"Location.Longitude",
+		"Location.Placement",
+		"Location.PostalAddress",
 	}
 
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(chassis).Elem()
 
-	return chassis.Entity.Update(originalElement, currentElement, readWriteFields)
+	return chassis.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetChassis will get a Chassis instance from the Redfish service.
 func GetChassis(c common.Client, uri string) (*Chassis, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var chassis Chassis
-	err = json.NewDecoder(resp.Body).Decode(&chassis)
-	if err != nil {
-		return nil, err
-	}
-
-	chassis.SetClient(c)
-	return &chassis, nil
+	return common.GetObject[Chassis](c, uri)
 }
 
 // ListReferencedChassis gets the collection of Chassis from a provided reference.
 func ListReferencedChassis(c common.Client, link string) ([]*Chassis, error) {
-	var result []*Chassis
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, chassisLink := range links.ItemLinks {
-		chassis, err := GetChassis(c, chassisLink)
-		if err != nil {
-			collectionError.Failures[chassisLink] = err
-		} else {
-			result = append(result, chassis)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return common.ListReferenced[Chassis](c, link)
 }
 
 // Drives gets the drives attached to the storage controllers that this
@@ -397,21 +379,14 @@ func (chassis *Chassis) Drives() ([]*Drive, error) {
 		driveLinks = drives.ItemLinks
 	}
 
-	collectionError := common.NewCollectionError()
-	for _, driveLink := range driveLinks {
-		drive, err := GetDrive(chassis.Client, driveLink)
-		if err != nil {
-			collectionError.Failures[driveLink] = err
-		} else {
-			result = append(result, drive)
-		}
+	members, err := common.FetchCollectionMembers(chassis.Client, driveLinks, func(link string) (interface{}, error) {
+		return GetDrive(chassis.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*Drive))
 	}
 
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return result, err
 }
 
 // Thermal gets the thermal temperature and cooling information for the chassis
@@ -446,42 +421,28 @@ func (chassis *Chassis) Power() (*Power, error) {
 func (chassis *Chassis) ComputerSystems() ([]*ComputerSystem, error) {
 	var result []*ComputerSystem
 
-	collectionError := common.NewCollectionError()
-	for _, uri := range chassis.computerSystems {
-		cs, err := GetComputerSystem(chassis.Client, uri)
-		if err != nil {
-			collectionError.Failures[uri] = err
-		} else {
-			result = append(result, cs)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(chassis.Client, chassis.computerSystems, func(link string) (interface{}, error) {
+		return GetComputerSystem(chassis.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*ComputerSystem))
 	}
 
-	return result, collectionError
+	return result, err
 }
 
 // ManagedBy gets the collection of managers of this chassis
 func (chassis *Chassis) ManagedBy() ([]*Manager, error) {
 	var result []*Manager
 
-	collectionError := common.NewCollectionError()
-	for _, uri := range chassis.managedBy {
-		manager, err := GetManager(chassis.Client, uri)
-		if err != nil {
-			collectionError.Failures[uri] = err
-		} else {
-			result = append(result, manager)
-		}
+	members, err := common.FetchCollectionMembers(chassis.Client, chassis.managedBy, func(link string) (interface{}, error) {
+		return GetManager(chassis.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*Manager))
 	}
 
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return result, err
 }
 
 // NetworkAdapters gets the collection of network adapters of this chassis
@@ -491,7 +452,9 @@ func (chassis *Chassis) NetworkAdapters() ([]*NetworkAdapter, error) {
 
 // Reset shall reset the chassis. This action shall not reset Systems or other
 // contained resource, although side effects may occur which affect those resources.
-func (chassis *Chassis) Reset(resetType ResetType) error {
+// The request carries the chassis ETag in If-Match unless common.SkipETagMatch
+// is given.
+func (chassis *Chassis) Reset(resetType ResetType, opts ...common.UpdateOption) error {
 	// Make sure the requested reset type is supported by the chassis
 	valid := false
 	if len(chassis.SupportedResetTypes) > 0 {
@@ -518,11 +481,7 @@ func (chassis *Chassis) Reset(resetType ResetType) error {
 		ResetType: resetType,
 	}
 
-	resp, err := chassis.Client.Post(chassis.resetTarget, t)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+	return chassis.Post(chassis.resetTarget, t, opts...)
 }
 
 // Sensors gets the collection of network adapters of this chassis
@@ -66,8 +66,15 @@ func (compositionservice *CompositionService) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a CompositionService object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (compositionservice *CompositionService) MarshalJSON() ([]byte, error) {
+	type temp CompositionService
+	return common.MarshalObject(compositionservice.rawData, new(CompositionService), temp(*compositionservice))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (compositionservice *CompositionService) Update() error {
+func (compositionservice *CompositionService) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(CompositionService)
@@ -84,53 +91,16 @@ func (compositionservice *CompositionService) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(compositionservice).Elem()
 
-	return compositionservice.Entity.Update(originalElement, currentElement, readWriteFields)
+	return compositionservice.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetCompositionService will get a CompositionService instance from the service.
 func GetCompositionService(c common.Client, uri string) (*CompositionService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var compositionservice CompositionService
-	err = json.NewDecoder(resp.Body).Decode(&compositionservice)
-	if err != nil {
-		return nil, err
-	}
-
-	compositionservice.SetClient(c)
-	return &compositionservice, nil
+	return common.GetObject[CompositionService](c, uri)
 }
 
 // ListReferencedCompositionServices gets the collection of CompositionService from
 // a provided reference.
-func ListReferencedCompositionServices(c common.Client, link string) ([]*CompositionService, error) { //nolint:dupl
-	var result []*CompositionService
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, compositionserviceLink := range links.ItemLinks {
-		compositionservice, err := GetCompositionService(c, compositionserviceLink)
-		if err != nil {
-			collectionError.Failures[compositionserviceLink] = err
-		} else {
-			result = append(result, compositionservice)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedCompositionServices(c common.Client, link string) ([]*CompositionService, error) {
+	return common.ListReferenced[CompositionService](c, link)
 }
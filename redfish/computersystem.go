@@ -322,6 +322,8 @@ type Boot struct {
 	// one time boot only. Changes to this property do not alter the BIOS
 	// persistent boot order configuration.
 	UefiTargetBootSourceOverride string `json:",omitempty"`
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a Boot object from the raw JSON.
@@ -342,9 +344,19 @@ func (boot *Boot) UnmarshalJSON(b []byte) error {
 	// Extract the links to other entities for later
 	boot.bootOptions = string(t.BootOptions)
 
+	// Save the raw object data so the object can be marshaled back
+	boot.rawData = b
+
 	return nil
 }
 
+// MarshalJSON marshals a Boot object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (boot *Boot) MarshalJSON() ([]byte, error) {
+	type temp Boot
+	return common.MarshalObject(boot.rawData, new(Boot), temp(*boot))
+}
+
 // BootOption represents the properties of a bootable device available in the
 // system.
 type BootOption struct {
@@ -374,20 +386,7 @@ type BootOption struct {
 
 // GetBootOption will get a BootOption instance from the service.
 func GetBootOption(c common.Client, uri string) (*BootOption, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var bootoption BootOption
-	err = json.NewDecoder(resp.Body).Decode(&bootoption)
-	if err != nil {
-		return nil, err
-	}
-
-	bootoption.SetClient(c)
-	return &bootoption, nil
+	return common.GetObject[BootOption](c, uri)
 }
 
 // ResetType describe the type off reset to be issue by the resource
@@ -559,10 +558,6 @@ type ComputerSystem struct {
 	ManagedBy []string
 	// rawData holds the original serialized JSON so we can compare updates.
 	rawData []byte
-	// etag contains the etag header when fetching the object. This is used to
-	// control updates to make sure the object has not been modified my a different
-	// process between fetching and updating that could cause conflicts.
-	etag string
 }
 
 // UnmarshalJSON unmarshals a ComputerSystem object from the raw JSON.
@@ -656,8 +651,15 @@ func (computersystem *ComputerSystem) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a ComputerSystem object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (computersystem *ComputerSystem) MarshalJSON() ([]byte, error) {
+	type temp ComputerSystem
+	return common.MarshalObject(computersystem.rawData, new(ComputerSystem), temp(*computersystem))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (computersystem *ComputerSystem) Update() error {
+func (computersystem *ComputerSystem) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	cs := new(ComputerSystem)
@@ -668,7 +670,18 @@ func (computersystem *ComputerSystem) Update() error {
 
 	readWriteFields := []string{
 		"AssetTag",
+		"Boot.AliasBootOrder",
+		"Boot.BootNext",
+		"Boot.BootOrder",
+		"Boot.BootOrderPropertySelection",
+		"Boot.BootSourceOverrideEnabled",
+		"Boot.BootSourceOverrideMode",
+		"Boot.BootSourceOverrideTarget",
+		"Boot.UefiTargetBootSourceOverride",
 		"HostName",
+		"HostWatchdogTimer.FunctionEnabled",
+		"HostWatchdogTimer.TimeoutAction",
+		"HostWatchdogTimer.WarningAction",
 		"IndicatorLED",
 		"PowerRestorePolicy",
 	}
@@ -676,54 +689,18 @@ func (computersystem *ComputerSystem) Update() error {
 	originalElement := reflect.ValueOf(cs).Elem()
 	currentElement := reflect.ValueOf(computersystem).Elem()
 
-	return computersystem.Entity.Update(originalElement, currentElement, readWriteFields)
+	return computersystem.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetComputerSystem will get a ComputerSystem instance from the service.
 func GetComputerSystem(c common.Client, uri string) (*ComputerSystem, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var computersystem ComputerSystem
-	err = json.NewDecoder(resp.Body).Decode(&computersystem)
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.Header["Etag"] != nil {
-		computersystem.etag = resp.Header["Etag"][0]
-	}
-
-	computersystem.SetClient(c)
-	return &computersystem, nil
+	return common.GetObject[ComputerSystem](c, uri)
 }
 
 // ListReferencedComputerSystems gets the collection of ComputerSystem from
 // a provided reference.
 func ListReferencedComputerSystems(c common.Client, link string) ([]*ComputerSystem, error) {
-	var result []*ComputerSystem
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-	collectionError := common.NewCollectionError()
-	for _, computersystemLink := range links.ItemLinks {
-		computersystem, err := GetComputerSystem(c, computersystemLink)
-		if err != nil {
-			collectionError.Failures[computersystemLink] = err
-		} else {
-			result = append(result, computersystem)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return common.ListReferenced[ComputerSystem](c, link)
 }
 
 // Bios gets the Bios information for this ComputerSystem.
@@ -743,21 +720,14 @@ func (computersystem *ComputerSystem) BootOptions() ([]*BootOption, error) {
 		return result, err
 	}
 
-	collectionError := common.NewCollectionError()
-	for _, bootOptionLink := range links.ItemLinks {
-		bootOption, err := GetBootOption(computersystem.Client, bootOptionLink)
-		if err != nil {
-			collectionError.Failures[bootOptionLink] = err
-		} else {
-			result = append(result, bootOption)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollection(computersystem.Client, links, func(c common.Client, link string) (interface{}, error) {
+		return GetBootOption(c, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*BootOption))
 	}
 
-	return result, collectionError
+	return result, err
 }
 
 // EthernetInterfaces get this system's ethernet interfaces.
@@ -789,43 +759,28 @@ func (computersystem *ComputerSystem) NetworkInterfaces() ([]*NetworkInterface,
 func (computersystem *ComputerSystem) PCIeDevices() ([]*PCIeDevice, error) {
 	var result []*PCIeDevice
 
-	collectionError := common.NewCollectionError()
-	for _, pciedeviceLink := range computersystem.pcieDevices {
-
-		pciedevice, err := GetPCIeDevice(computersystem.Client, pciedeviceLink)
-		if err != nil {
-			collectionError.Failures[pciedeviceLink] = err
-		} else {
-			result = append(result, pciedevice)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(computersystem.Client, computersystem.pcieDevices, func(link string) (interface{}, error) {
+		return GetPCIeDevice(computersystem.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*PCIeDevice))
 	}
 
-	return result, collectionError
+	return result, err
 }
 
 // PCIeFunctions gets all PCIeFunctions for this system.
 func (computersystem *ComputerSystem) PCIeFunctions() ([]*PCIeFunction, error) {
 	var result []*PCIeFunction
 
-	collectionError := common.NewCollectionError()
-	for _, pciefunctionLink := range computersystem.pcieFunctions {
-		pciefunction, err := GetPCIeFunction(computersystem.Client, pciefunctionLink)
-		if err != nil {
-			collectionError.Failures[pciefunctionLink] = err
-		} else {
-			result = append(result, pciefunction)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(computersystem.Client, computersystem.pcieFunctions, func(link string) (interface{}, error) {
+		return GetPCIeFunction(computersystem.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*PCIeFunction))
 	}
 
-	return result, collectionError
+	return result, err
 }
 
 // Processors returns a collection of processors from this system
@@ -843,7 +798,7 @@ func (computersystem *ComputerSystem) SecureBoot() (*SecureBoot, error) {
 }
 
 // SetBoot set a boot object based on a payload request
-func (computersystem *ComputerSystem) SetBoot(b Boot) error { // nolint
+func (computersystem *ComputerSystem) SetBoot(b Boot, opts ...common.UpdateOption) error { // nolint
 	type temp struct {
 		Boot Boot
 	}
@@ -851,16 +806,7 @@ func (computersystem *ComputerSystem) SetBoot(b Boot) error { // nolint
 		Boot: b,
 	}
 
-	var header = make(map[string]string)
-	if computersystem.etag != "" {
-		header["If-Match"] = computersystem.etag
-	}
-
-	resp, err := computersystem.Client.PatchWithHeaders(computersystem.ODataID, t, header)
-	if err == nil {
-		return resp.Body.Close()
-	}
-	return err
+	return computersystem.Patch(computersystem.ODataID, t, opts...)
 }
 
 // Reset shall perform a reset of the ComputerSystem. For systems which implement
@@ -868,8 +814,9 @@ func (computersystem *ComputerSystem) SetBoot(b Boot) error { // nolint
 // emulate an ACPI Power Button push. The ForceOff value shall remove power from
 // the system or perform an ACPI Power Button Override (commonly known as a
 // 4-second hold of the Power Button). The ForceRestart value shall perform a
-// ForceOff action followed by a On action.
-func (computersystem *ComputerSystem) Reset(resetType ResetType) error {
+// ForceOff action followed by a On action. The request carries the system ETag
+// in If-Match unless common.SkipETagMatch is given.
+func (computersystem *ComputerSystem) Reset(resetType ResetType, opts ...common.UpdateOption) error {
 	// Make sure the requested reset type is supported by the system
 	valid := false
 	if len(computersystem.SupportedResetTypes) > 0 {
@@ -896,35 +843,19 @@ func (computersystem *ComputerSystem) Reset(resetType ResetType) error {
 		ResetType: resetType,
 	}
 
-	var header = make(map[string]string)
-	if computersystem.etag != "" {
-		header["If-Match"] = computersystem.etag
-	}
-
-	resp, err := computersystem.Client.PostWithHeaders(computersystem.resetTarget, t, header)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+	return computersystem.Post(computersystem.resetTarget, t, opts...)
 }
 
 // SetDefaultBootOrder shall set the BootOrder array to the default settings.
-func (computersystem *ComputerSystem) SetDefaultBootOrder() error {
+// The request carries the system ETag in If-Match unless
+// common.SkipETagMatch is given.
+func (computersystem *ComputerSystem) SetDefaultBootOrder(opts ...common.UpdateOption) error {
 	// This action wasn't added until 1.5.0, make sure this is supported.
 	if computersystem.setDefaultBootOrderTarget == "" {
 		return fmt.Errorf("SetDefaultBootOrder is not supported by this system") // nolint:golint
 	}
 
-	var header = make(map[string]string)
-	if computersystem.etag != "" {
-		header["If-Match"] = computersystem.etag
-	}
-
-	resp, err := computersystem.Client.PostWithHeaders(computersystem.resetTarget, nil, header)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+	return computersystem.Post(computersystem.setDefaultBootOrderTarget, nil, opts...)
 }
 
 // SimpleStorages gets all simple storage services of this system.
@@ -939,7 +870,10 @@ func (computersystem *ComputerSystem) Storage() ([]*Storage, error) {
 
 // SmartStorage gét the smart storage of this system
 func (computersystem *ComputerSystem) SmartStorage() (*SmartStorage, error) {
-	return ListReferencedSmartStorages(computersystem.Client, computersystem.smartStorage)
+	if computersystem.smartStorage == "" {
+		return nil, nil
+	}
+	return GetSmartStorage(computersystem.Client, computersystem.smartStorage)
 }
 
 // CSLinks are references to resources that are related to, but not contained
@@ -6,6 +6,8 @@ package redfish
 
 import (
 	"encoding/json"
+	"io"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -275,6 +277,7 @@ func TestComputerSystemUpdate(t *testing.T) {
 	result.AssetTag = TestAssetTag
 	result.HostName = "TestHostName"
 	result.IndicatorLED = common.BlinkingIndicatorLED
+	result.Boot.BootSourceOverrideTarget = HddBootSourceOverrideTarget
 	err = result.Update()
 
 	if err != nil {
@@ -294,6 +297,213 @@ func TestComputerSystemUpdate(t *testing.T) {
 	if !strings.Contains(calls[0].Payload, "IndicatorLED:Blinking") {
 		t.Errorf("Unexpected IndicatorLED update payload: %s", calls[0].Payload)
 	}
+
+	if !strings.Contains(calls[0].Payload, "Boot:map[BootSourceOverrideTarget:Hdd]") {
+		t.Errorf("Unexpected Boot update payload: %s", calls[0].Payload)
+	}
+}
+
+// TestComputerSystemActionsETag tests actions are sent with the system ETag
+// and report conflicts like updates do.
+func TestComputerSystemActionsETag(t *testing.T) {
+	var result ComputerSystem
+	err := json.Unmarshal([]byte(computerSystemBody), &result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {nil, nil, nil, &http.Response{
+				StatusCode: http.StatusPreconditionFailed,
+				Body:       io.NopCloser(strings.NewReader(`{"error": {"code": "Base.1.8.PreconditionFailed"}}`)),
+			}},
+		},
+	}
+	result.SetClient(testClient)
+	result.SetETag(`W/"1"`)
+
+	if err := result.Reset(ForceRestartResetType); err != nil {
+		t.Fatalf("Error resetting the system: %s", err)
+	}
+	if err := result.Reset(ForceRestartResetType, common.SkipETagMatch()); err != nil {
+		t.Fatalf("Error resetting the system: %s", err)
+	}
+	if err := result.SetDefaultBootOrder(); err != nil {
+		t.Fatalf("Error setting the default boot order: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].CustomHeaders["If-Match"] != `W/"1"` {
+		t.Errorf("Expected the ETag to be sent, got: %v", calls[0].CustomHeaders)
+	}
+	if _, ok := calls[1].CustomHeaders["If-Match"]; ok {
+		t.Errorf("Expected no If-Match header, got: %v", calls[1].CustomHeaders)
+	}
+	if calls[2].URL != "/redfish/v1/Systems/System-1/Actions/ComputerSystem.SetDefaultBootOrder" ||
+		calls[2].CustomHeaders["If-Match"] != `W/"1"` {
+		t.Errorf("Unexpected SetDefaultBootOrder call: %+v", calls[2])
+	}
+
+	err = result.Reset(ForceRestartResetType)
+	if !common.IsConflict(err) {
+		t.Errorf("Expected a conflict error, got: %v", err)
+	}
+}
+
+// preconditionFailed is the reply of a service refusing an If-Match header.
+func preconditionFailed() *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusPreconditionFailed,
+		Body:       io.NopCloser(strings.NewReader(`{"error": {"code": "Base.1.8.PreconditionFailed"}}`)),
+	}
+}
+
+// TestActionsETag tests the actions of other resources are sent with their
+// ETag and report conflicts like those of the system.
+func TestActionsETag(t *testing.T) {
+	manager := &Manager{SupportedResetTypes: []ResetType{ForceRestartResetType}}
+	manager.resetTarget = "/redfish/v1/Managers/1/Actions/Manager.Reset"
+	chassis := &Chassis{SupportedResetTypes: []ResetType{ForceRestartResetType}}
+	chassis.resetTarget = "/redfish/v1/Chassis/1/Actions/Chassis.Reset"
+	media := &VirtualMedia{SupportsMediaEject: true, SupportsMediaInsert: true}
+	media.ejectMediaTarget = "/redfish/v1/Managers/1/VirtualMedia/1/Actions/VirtualMedia.EjectMedia"
+	media.insertMediaTarget = "/redfish/v1/Managers/1/VirtualMedia/1/Actions/VirtualMedia.InsertMedia"
+	logService := &LogService{}
+	logService.clearLogTarget = "/redfish/v1/Managers/1/LogServices/Log/Actions/LogService.ClearLog"
+	bios := &Bios{}
+	bios.changePasswordTarget = "/redfish/v1/Systems/1/Bios/Actions/Bios.ChangePassword"
+	bios.resetBiosTarget = "/redfish/v1/Systems/1/Bios/Actions/Bios.ResetBios"
+	drive := &Drive{}
+	drive.secureEraseTarget = "/redfish/v1/Systems/1/Storage/1/Drives/1/Actions/Drive.SecureErase"
+
+	tests := []struct {
+		name   string
+		entity *common.Entity
+		action func(opts ...common.UpdateOption) error
+	}{
+		{"manager reset", &manager.Entity, func(opts ...common.UpdateOption) error {
+			return manager.Reset(ForceRestartResetType, opts...)
+		}},
+		{"chassis reset", &chassis.Entity, func(opts ...common.UpdateOption) error {
+			return chassis.Reset(ForceRestartResetType, opts...)
+		}},
+		{"eject media", &media.Entity, media.EjectMedia},
+		{"insert media", &media.Entity, func(opts ...common.UpdateOption) error {
+			return media.InsertMedia("http://example.com/boot.iso", true, true, opts...)
+		}},
+		{"clear log", &logService.Entity, logService.ClearLog},
+		{"change BIOS password", &bios.Entity, func(opts ...common.UpdateOption) error {
+			return bios.ChangePassword("AdminPassword", "old", "new", opts...)
+		}},
+		{"reset BIOS", &bios.Entity, func(opts ...common.UpdateOption) error {
+			_, err := bios.ResetBios(opts...)
+			return err
+		}},
+		{"secure erase", &drive.Entity, func(opts ...common.UpdateOption) error {
+			_, err := drive.SecureErase(opts...)
+			return err
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testClient := &common.TestClient{
+				CustomReturnForActions: map[string][]interface{}{
+					http.MethodPost: {nil, nil, preconditionFailed()},
+				},
+			}
+			test.entity.SetClient(testClient)
+			test.entity.SetETag(`W/"1"`)
+
+			if err := test.action(); err != nil {
+				t.Fatalf("Error running the action: %s", err)
+			}
+			if err := test.action(common.SkipETagMatch()); err != nil {
+				t.Fatalf("Error running the action: %s", err)
+			}
+			if err := test.action(); !common.IsConflict(err) {
+				t.Errorf("Expected a conflict error, got: %v", err)
+			}
+
+			calls := testClient.CapturedCalls()
+			if calls[0].CustomHeaders["If-Match"] != `W/"1"` {
+				t.Errorf("Expected the ETag to be sent, got: %v", calls[0].CustomHeaders)
+			}
+			if _, ok := calls[1].CustomHeaders["If-Match"]; ok {
+				t.Errorf("Expected no If-Match header, got: %v", calls[1].CustomHeaders)
+			}
+		})
+	}
+}
+
+// TestComputerSystemMarshalJSON tests a system can be marshaled and read back
+// without losing its links and actions.
+func TestComputerSystemMarshalJSON(t *testing.T) {
+	var result ComputerSystem
+	err := json.Unmarshal([]byte(computerSystemBody), &result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	result.AssetTag = TestAssetTag
+	result.Boot.BootSourceOverrideTarget = HddBootSourceOverrideTarget
+	b, err := json.Marshal(&result)
+	if err != nil {
+		t.Fatalf("Error encoding JSON: %s", err)
+	}
+
+	var reloaded ComputerSystem
+	err = json.Unmarshal(b, &reloaded)
+	if err != nil {
+		t.Fatalf("Error decoding marshaled JSON: %s", err)
+	}
+	if reloaded.AssetTag != TestAssetTag || reloaded.Boot.BootSourceOverrideTarget != HddBootSourceOverrideTarget {
+		t.Errorf("Changes were not marshaled: %s", b)
+	}
+	if reloaded.resetTarget != result.resetTarget || len(reloaded.chassis) != 1 || len(reloaded.ManagedBy) != 1 {
+		t.Errorf("Links and actions were not marshaled: %s", b)
+	}
+	if !strings.Contains(string(b), "BootSourceOverrideTarget@Redfish.AllowableValues") {
+		t.Errorf("Properties that are not modeled were lost: %s", b)
+	}
+
+	b, err = json.Marshal(&reloaded)
+	if err != nil || string(b) != string(reloaded.rawData) {
+		t.Errorf("An unchanged system should marshal to its raw JSON: %v", err)
+	}
+}
+
+// TestBootMarshalJSON tests the boot settings can be marshaled on their own
+// without losing their links and the properties that are not modeled.
+func TestBootMarshalJSON(t *testing.T) {
+	var result Boot
+	err := json.Unmarshal([]byte(`{
+		"BootOptions": {"@odata.id": "/redfish/v1/Systems/1/BootOptions"},
+		"BootSourceOverrideTarget": "Pxe",
+		"BootSourceOverrideTarget@Redfish.AllowableValues": ["Pxe", "Hdd"]
+	}`), &result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	result.BootSourceOverrideTarget = HddBootSourceOverrideTarget
+	b, err := json.Marshal(&result)
+	if err != nil {
+		t.Fatalf("Error encoding JSON: %s", err)
+	}
+
+	var reloaded Boot
+	err = json.Unmarshal(b, &reloaded)
+	if err != nil {
+		t.Fatalf("Error decoding marshaled JSON: %s", err)
+	}
+	if reloaded.BootSourceOverrideTarget != HddBootSourceOverrideTarget || reloaded.bootOptions != result.bootOptions {
+		t.Errorf("Changes or links were not marshaled: %s", b)
+	}
+	if !strings.Contains(string(b), "BootSourceOverrideTarget@Redfish.AllowableValues") {
+		t.Errorf("Properties that are not modeled were lost: %s", b)
+	}
 }
 
 var bootOptionBody = `{
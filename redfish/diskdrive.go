@@ -137,8 +137,15 @@ func (drive *DiskDrive) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a DiskDrive object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (drive *DiskDrive) MarshalJSON() ([]byte, error) {
+	type temp DiskDrive
+	return common.MarshalObject(drive.rawData, new(DiskDrive), temp(*drive))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (drive *DiskDrive) Update() error {
+func (drive *DiskDrive) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(DiskDrive)
@@ -158,52 +165,15 @@ func (drive *DiskDrive) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(drive).Elem()
 
-	return drive.Entity.Update(originalElement, currentElement, readWriteFields)
+	return drive.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetDrive will get a Drive instance from the service.
 func GetDiskDrive(c common.Client, uri string) (*DiskDrive, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var drive DiskDrive
-	err = json.NewDecoder(resp.Body).Decode(&drive)
-	if err != nil {
-		return nil, err
-	}
-
-	drive.SetClient(c)
-	return &drive, nil
+	return common.GetObject[DiskDrive](c, uri)
 }
 
 // ListReferencedDrives gets the collection of Drives from a provided reference.
-func ListReferencedDiskDrives(c common.Client, link string) ([]*DiskDrive, error) { //nolint:dupl
-	var result []*DiskDrive
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, driveLink := range links.ItemLinks {
-		drive, err := GetDiskDrive(c, driveLink)
-		if err != nil {
-			collectionError.Failures[driveLink] = err
-		} else {
-			result = append(result, drive)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedDiskDrives(c common.Client, link string) ([]*DiskDrive, error) {
+	return common.ListReferenced[DiskDrive](c, link)
 }
@@ -303,8 +303,15 @@ func (drive *Drive) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a Drive object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (drive *Drive) MarshalJSON() ([]byte, error) {
+	type temp Drive
+	return common.MarshalObject(drive.rawData, new(Drive), temp(*drive))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (drive *Drive) Update() error {
+func (drive *Drive) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(Drive)
@@ -324,54 +331,17 @@ func (drive *Drive) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(drive).Elem()
 
-	return drive.Entity.Update(originalElement, currentElement, readWriteFields)
+	return drive.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetDrive will get a Drive instance from the service.
 func GetDrive(c common.Client, uri string) (*Drive, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var drive Drive
-	err = json.NewDecoder(resp.Body).Decode(&drive)
-	if err != nil {
-		return nil, err
-	}
-
-	drive.SetClient(c)
-	return &drive, nil
+	return common.GetObject[Drive](c, uri)
 }
 
 // ListReferencedDrives gets the collection of Drives from a provided reference.
-func ListReferencedDrives(c common.Client, link string) ([]*Drive, error) { //nolint:dupl
-	var result []*Drive
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, driveLink := range links.ItemLinks {
-		drive, err := GetDrive(c, driveLink)
-		if err != nil {
-			collectionError.Failures[driveLink] = err
-		} else {
-			result = append(result, drive)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedDrives(c common.Client, link string) ([]*Drive, error) {
+	return common.ListReferenced[Drive](c, link)
 }
 
 // Assembly gets the Assembly for this drive.
@@ -396,63 +366,42 @@ func (drive *Drive) Chassis() (*Chassis, error) {
 func (drive *Drive) Endpoints() ([]*Endpoint, error) {
 	var result []*Endpoint
 
-	collectionError := common.NewCollectionError()
-	for _, endpointLink := range drive.endpoints {
-		endpoint, err := GetEndpoint(drive.Client, endpointLink)
-		if err != nil {
-			collectionError.Failures[endpointLink] = err
-		} else {
-			result = append(result, endpoint)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(drive.Client, drive.endpoints, func(link string) (interface{}, error) {
+		return GetEndpoint(drive.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*Endpoint))
 	}
 
-	return result, collectionError
+	return result, err
 }
 
 // Volumes references the Volumes that this drive is associated with.
 func (drive *Drive) Volumes() ([]*Volume, error) {
 	var result []*Volume
 
-	collectionError := common.NewCollectionError()
-	for _, volumeLink := range drive.volumes {
-		volume, err := GetVolume(drive.Client, volumeLink)
-		if err != nil {
-			collectionError.Failures[volumeLink] = err
-		} else {
-			result = append(result, volume)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(drive.Client, drive.volumes, func(link string) (interface{}, error) {
+		return GetVolume(drive.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*Volume))
 	}
 
-	return result, collectionError
+	return result, err
 }
 
 // PCIeFunctions references the PCIeFunctions that this drive is associated with.
 func (drive *Drive) PCIeFunctions() ([]*PCIeFunction, error) {
 	var result []*PCIeFunction
 
-	collectionError := common.NewCollectionError()
-	for _, pcieFunctionLink := range drive.pcieFunctions {
-		pcieFunction, err := GetPCIeFunction(drive.Client, pcieFunctionLink)
-		if err != nil {
-			collectionError.Failures[pcieFunctionLink] = err
-		} else {
-			result = append(result, pcieFunction)
-		}
+	members, err := common.FetchCollectionMembers(drive.Client, drive.pcieFunctions, func(link string) (interface{}, error) {
+		return GetPCIeFunction(drive.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*PCIeFunction))
 	}
 
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return result, err
 }
 
 // // StoragePools references the StoragePools that this drive is associated with.
@@ -470,11 +419,11 @@ func (drive *Drive) PCIeFunctions() ([]*PCIeFunction, error) {
 // 	return result, nil
 // }
 
-// SecureErase shall perform a secure erase of the drive.
-func (drive *Drive) SecureErase() error {
-	resp, err := drive.Client.Post(drive.secureEraseTarget, nil)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+// SecureErase shall perform a secure erase of the drive. Services usually
+// erase the drive after replying, so the returned operation can be waited on
+// for its outcome.
+// The request carries the drive ETag in If-Match unless common.SkipETagMatch is
+// given.
+func (drive *Drive) SecureErase(opts ...common.UpdateOption) (*AsyncOperation, error) {
+	return newActionOperation(&drive.Entity, drive.secureEraseTarget, nil, opts)
 }
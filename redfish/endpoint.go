@@ -159,6 +159,8 @@ type Endpoint struct {
 	// connectedPorts []string
 	// ConnectedPortCount is the number of ConnectedPorts.
 	ConnectedPortsCount int
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a Endpoint object from the raw JSON.
@@ -212,55 +214,28 @@ func (endpoint *Endpoint) UnmarshalJSON(b []byte) error {
 	endpoint.ports = t.Links.Ports.ToStrings()
 	endpoint.PortsCount = t.Links.PortsCount
 
+	// Save the raw object data so the object can be marshaled back
+	endpoint.rawData = b
+
 	return nil
 }
 
+// MarshalJSON marshals a Endpoint object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (endpoint *Endpoint) MarshalJSON() ([]byte, error) {
+	type temp Endpoint
+	return common.MarshalObject(endpoint.rawData, new(Endpoint), temp(*endpoint))
+}
+
 // GetEndpoint will get a Endpoint instance from the service.
 func GetEndpoint(c common.Client, uri string) (*Endpoint, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var endpoint Endpoint
-	err = json.NewDecoder(resp.Body).Decode(&endpoint)
-	if err != nil {
-		return nil, err
-	}
-
-	endpoint.SetClient(c)
-	return &endpoint, nil
+	return common.GetObject[Endpoint](c, uri)
 }
 
 // ListReferencedEndpoints gets the collection of Endpoint from
 // a provided reference.
-func ListReferencedEndpoints(c common.Client, link string) ([]*Endpoint, error) { //nolint:dupl
-	var result []*Endpoint
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, endpointLink := range links.ItemLinks {
-		endpoint, err := GetEndpoint(c, endpointLink)
-		if err != nil {
-			collectionError.Failures[endpointLink] = err
-		} else {
-			result = append(result, endpoint)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedEndpoints(c common.Client, link string) ([]*Endpoint, error) {
+	return common.ListReferenced[Endpoint](c, link)
 }
 
 // GCID shall contain the Gen-Z Core Specification-defined Global
@@ -249,8 +249,15 @@ func (ethernetinterface *EthernetInterface) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a EthernetInterface object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (ethernetinterface *EthernetInterface) MarshalJSON() ([]byte, error) {
+	type temp EthernetInterface
+	return common.MarshalObject(ethernetinterface.rawData, new(EthernetInterface), temp(*ethernetinterface))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (ethernetinterface *EthernetInterface) Update() error {
+func (ethernetinterface *EthernetInterface) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(EthernetInterface)
@@ -265,64 +272,31 @@ func (ethernetinterface *EthernetInterface) Update() error {
 		"FullDuplex",
 		"HostName",
 		"InterfaceEnabled",
+		"IPv4StaticAddresses",
+		"IPv6StaticAddresses",
 		"MACAddress",
 		"MTUSize",
 		"SpeedMbps",
 		"StaticNameServers",
+		"VLAN.VLANEnable",
+		"VLAN.VLANId",
 	}
 
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(ethernetinterface).Elem()
 
-	return ethernetinterface.Entity.Update(originalElement, currentElement, readWriteFields)
+	return ethernetinterface.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetEthernetInterface will get a EthernetInterface instance from the service.
 func GetEthernetInterface(c common.Client, uri string) (*EthernetInterface, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var ethernetinterface EthernetInterface
-	err = json.NewDecoder(resp.Body).Decode(&ethernetinterface)
-	if err != nil {
-		return nil, err
-	}
-
-	ethernetinterface.SetClient(c)
-	return &ethernetinterface, nil
+	return common.GetObject[EthernetInterface](c, uri)
 }
 
 // ListReferencedEthernetInterfaces gets the collection of EthernetInterface from
 // a provided reference.
-func ListReferencedEthernetInterfaces(c common.Client, link string) ([]*EthernetInterface, error) { //nolint:dupl
-	var result []*EthernetInterface
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, ethernetinterfaceLink := range links.ItemLinks {
-		ethernetinterface, err := GetEthernetInterface(c, ethernetinterfaceLink)
-		if err != nil {
-			collectionError.Failures[ethernetinterfaceLink] = err
-		} else {
-			result = append(result, ethernetinterface)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedEthernetInterfaces(c common.Client, link string) ([]*EthernetInterface, error) {
+	return common.ListReferenced[EthernetInterface](c, link)
 }
 
 // IPv6AddressPolicyEntry describes and entry in the Address Selection Policy
@@ -238,8 +238,15 @@ func (eventdestination *EventDestination) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a EventDestination object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (eventdestination *EventDestination) MarshalJSON() ([]byte, error) {
+	type temp EventDestination
+	return common.MarshalObject(eventdestination.rawData, new(EventDestination), temp(*eventdestination))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (eventdestination *EventDestination) Update() error {
+func (eventdestination *EventDestination) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(EventDestination)
@@ -256,30 +263,12 @@ func (eventdestination *EventDestination) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(eventdestination).Elem()
 
-	return eventdestination.Entity.Update(originalElement, currentElement, readWriteFields)
+	return eventdestination.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetEventDestination will get a EventDestination instance from the service.
 func GetEventDestination(c common.Client, uri string) (*EventDestination, error) {
-	// validate uri
-	if strings.TrimSpace(uri) == "" {
-		return nil, fmt.Errorf("uri should not be empty")
-	}
-
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var eventdestination EventDestination
-	err = json.NewDecoder(resp.Body).Decode(&eventdestination)
-	if err != nil {
-		return nil, err
-	}
-
-	eventdestination.SetClient(c)
-	return &eventdestination, nil
+	return common.GetObject[EventDestination](c, uri)
 }
 
 // subscriptionPayload is the payload to create the event subscription
@@ -410,32 +399,8 @@ func DeleteEventDestination(c common.Client, uri string) error {
 
 // ListReferencedEventDestinations gets the collection of EventDestination from
 // a provided reference.
-func ListReferencedEventDestinations(c common.Client, link string) ([]*EventDestination, error) { //nolint:dupl
-	var result []*EventDestination
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, eventdestinationLink := range links.ItemLinks {
-		eventdestination, err := GetEventDestination(c, eventdestinationLink)
-		if err != nil {
-			collectionError.Failures[eventdestinationLink] = err
-		} else {
-			result = append(result, eventdestination)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedEventDestinations(c common.Client, link string) ([]*EventDestination, error) {
+	return common.ListReferenced[EventDestination](c, link)
 }
 
 // HTTPHeaderProperty shall a names and value of an HTTP header to be included
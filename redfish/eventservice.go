@@ -203,8 +203,15 @@ func (eventservice *EventService) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a EventService object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (eventservice *EventService) MarshalJSON() ([]byte, error) {
+	type temp EventService
+	return common.MarshalObject(eventservice.rawData, new(EventService), temp(*eventservice))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (eventservice *EventService) Update() error {
+func (eventservice *EventService) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(EventService)
@@ -222,55 +229,18 @@ func (eventservice *EventService) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(eventservice).Elem()
 
-	return eventservice.Entity.Update(originalElement, currentElement, readWriteFields)
+	return eventservice.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetEventService will get a EventService instance from the service.
 func GetEventService(c common.Client, uri string) (*EventService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var eventservice EventService
-	err = json.NewDecoder(resp.Body).Decode(&eventservice)
-	if err != nil {
-		return nil, err
-	}
-
-	eventservice.SetClient(c)
-	return &eventservice, nil
+	return common.GetObject[EventService](c, uri)
 }
 
 // ListReferencedEventServices gets the collection of EventService from
 // a provided reference.
-func ListReferencedEventServices(c common.Client, link string) ([]*EventService, error) { //nolint:dupl
-	var result []*EventService
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, eventserviceLink := range links.ItemLinks {
-		eventservice, err := GetEventService(c, eventserviceLink)
-		if err != nil {
-			collectionError.Failures[eventserviceLink] = err
-		} else {
-			result = append(result, eventservice)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedEventServices(c common.Client, link string) ([]*EventService, error) {
+	return common.ListReferenced[EventService](c, link)
 }
 
 // GetEventSubscriptions gets all the subscriptions using the event service.
@@ -331,7 +301,9 @@ func (eventservice *EventService) DeleteEventSubscription(uri string) error {
 // SubmitTestEvent shall add a test event to the event service with the event
 // data specified in the action parameters. This message should then be sent to
 // any appropriate ListenerDestination targets.
-func (eventservice *EventService) SubmitTestEvent(message string) error {
+// The request carries the event service ETag in If-Match unless
+// common.SkipETagMatch is given.
+func (eventservice *EventService) SubmitTestEvent(message string, opts ...common.UpdateOption) error {
 	type temp struct {
 		EventGroupID      string `json:"EventGroupId"`
 		EventID           string `json:"EventId"`
@@ -354,11 +326,7 @@ func (eventservice *EventService) SubmitTestEvent(message string) error {
 		Severity:          "Informational",
 	}
 
-	resp, err := eventservice.Client.Post(eventservice.submitTestEventTarget, t)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+	return eventservice.Post(eventservice.submitTestEventTarget, t, opts...)
 }
 
 // SSEFilterPropertiesSupported shall contain a set of properties that indicate
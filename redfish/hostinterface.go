@@ -155,8 +155,15 @@ func (hostinterface *HostInterface) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a HostInterface object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (hostinterface *HostInterface) MarshalJSON() ([]byte, error) {
+	type temp HostInterface
+	return common.MarshalObject(hostinterface.rawData, new(HostInterface), temp(*hostinterface))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (hostinterface *HostInterface) Update() error {
+func (hostinterface *HostInterface) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(HostInterface)
@@ -178,76 +185,32 @@ func (hostinterface *HostInterface) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(hostinterface).Elem()
 
-	return hostinterface.Entity.Update(originalElement, currentElement, readWriteFields)
+	return hostinterface.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetHostInterface will get a HostInterface instance from the service.
 func GetHostInterface(c common.Client, uri string) (*HostInterface, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var hostinterface HostInterface
-	err = json.NewDecoder(resp.Body).Decode(&hostinterface)
-	if err != nil {
-		return nil, err
-	}
-
-	hostinterface.SetClient(c)
-	return &hostinterface, nil
+	return common.GetObject[HostInterface](c, uri)
 }
 
 // ListReferencedHostInterfaces gets the collection of HostInterface from
 // a provided reference.
-func ListReferencedHostInterfaces(c common.Client, link string) ([]*HostInterface, error) { //nolint:dupl
-	var result []*HostInterface
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, hostinterfaceLink := range links.ItemLinks {
-		hostinterface, err := GetHostInterface(c, hostinterfaceLink)
-		if err != nil {
-			collectionError.Failures[hostinterfaceLink] = err
-		} else {
-			result = append(result, hostinterface)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedHostInterfaces(c common.Client, link string) ([]*HostInterface, error) {
+	return common.ListReferenced[HostInterface](c, link)
 }
 
 // ComputerSystems references the ComputerSystems that this host interface is associated with.
 func (hostinterface *HostInterface) ComputerSystems() ([]*ComputerSystem, error) {
 	var result []*ComputerSystem
 
-	collectionError := common.NewCollectionError()
-	for _, computerSystemLink := range hostinterface.computerSystems {
-		computerSystem, err := GetComputerSystem(hostinterface.Client, computerSystemLink)
-		if err != nil {
-			collectionError.Failures[computerSystemLink] = err
-		} else {
-			result = append(result, computerSystem)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(hostinterface.Client, hostinterface.computerSystems, func(link string) (interface{}, error) {
+		return GetComputerSystem(hostinterface.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*ComputerSystem))
 	}
 
-	return result, collectionError
+	return result, err
 }
 
 // HostNetworkInterfaces gets the network interface controllers or cards (NICs)
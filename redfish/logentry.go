@@ -390,6 +390,8 @@ type LogEntry struct {
 	// originOfCondition shall be an href that
 	// references the resource for which the log is associated.
 	originOfCondition string
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a LogEntry object from the raw JSON.
@@ -413,53 +415,26 @@ func (logentry *LogEntry) UnmarshalJSON(b []byte) error {
 	*logentry = LogEntry(t.temp)
 	logentry.originOfCondition = string(t.Links.OriginOfCondition)
 
+	// Save the raw object data so the object can be marshaled back
+	logentry.rawData = b
+
 	return nil
 }
 
+// MarshalJSON marshals a LogEntry object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (logentry *LogEntry) MarshalJSON() ([]byte, error) {
+	type temp LogEntry
+	return common.MarshalObject(logentry.rawData, new(LogEntry), temp(*logentry))
+}
+
 // GetLogEntry will get a LogEntry instance from the service.
 func GetLogEntry(c common.Client, uri string) (*LogEntry, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var logentry LogEntry
-	err = json.NewDecoder(resp.Body).Decode(&logentry)
-	if err != nil {
-		return nil, err
-	}
-
-	logentry.SetClient(c)
-	return &logentry, nil
+	return common.GetObject[LogEntry](c, uri)
 }
 
 // ListReferencedLogEntrys gets the collection of LogEntry from
 // a provided reference.
-func ListReferencedLogEntrys(c common.Client, link string) ([]*LogEntry, error) { //nolint:dupl
-	var result []*LogEntry
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, logentryLink := range links.ItemLinks {
-		logentry, err := GetLogEntry(c, logentryLink)
-		if err != nil {
-			collectionError.Failures[logentryLink] = err
-		} else {
-			result = append(result, logentry)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedLogEntrys(c common.Client, link string) ([]*LogEntry, error) {
+	return common.ListReferenced[LogEntry](c, link)
 }
@@ -6,7 +6,6 @@ package redfish
 
 import (
 	"encoding/json"
-	"fmt"
 
 	"github.com/trungng1992/gofish/common"
 )
@@ -36,6 +35,8 @@ type Logical struct {
 	DrivesCount int
 	// drives contains references to associated drives.
 	datadrive string
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a Volume object from the raw JSON.
@@ -63,34 +64,35 @@ func (logical *Logical) UnmarshalJSON(b []byte) error {
 	}
 	logical.datadrive = string(t.Links.DataDrives)
 
+	// Save the raw object data so the object can be marshaled back
+	logical.rawData = b
+
 	return nil
 }
 
+// MarshalJSON marshals a Logical object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (logical *Logical) MarshalJSON() ([]byte, error) {
+	type temp Logical
+	return common.MarshalObject(logical.rawData, new(Logical), temp(*logical))
+}
+
 // GetLogical will get a Volume instance from the service.
 func GetLogical(c common.Client, uri string) (*Logical, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var volume Logical
-	err = json.NewDecoder(resp.Body).Decode(&volume)
-	if err != nil {
-		return nil, err
-	}
+	return common.GetObject[Logical](c, uri)
+}
 
-	physicaldrive, _ := ListReferencedPhysicalDrive(c, volume.datadrive)
+// Complete reads the number of associated drives. It is called when the
+// volume is read or refreshed.
+func (logical *Logical) Complete(c common.Client) {
+	physicaldrive, _ := ListReferencedPhysicalDrive(c, logical.datadrive)
 	if physicaldrive != nil {
-		volume.DrivesCount = physicaldrive.DrivesCount
+		logical.DrivesCount = physicaldrive.DrivesCount
 	}
-
-	volume.SetClient(c)
-	return &volume, nil
 }
 
 // ListReferencedVolumes gets the collection of Volumes from a provided reference.
-func ListReferencedLogical(c common.Client, link string) ([]*Logical, error) { //nolint:dupl
+func ListReferencedLogical(c common.Client, link string) ([]*Logical, error) {
 	var result []*Logical
 	if link == "" {
 		return result, nil
@@ -101,23 +103,14 @@ func ListReferencedLogical(c common.Client, link string) ([]*Logical, error) { /
 		return result, err
 	}
 
-	collectionError := common.NewCollectionError()
-	for _, volumeLink := range links.ItemLinks {
-		volume, err := GetLogical(c, volumeLink)
-		if err != nil {
-			collectionError.Failures[volumeLink] = err
-		} else if volume == nil {
-			collectionError.Failures[volumeLink] = fmt.Errorf("volume %s not found", volumeLink)
-		} else {
-			result = append(result, volume)
-		}
+	members, err := common.FetchCollection(c, links, func(c common.Client, link string) (interface{}, error) {
+		return GetLogical(c, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*Logical))
 	}
 
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return result, err
 }
 
 // Drives references the Drives that this volume is associated with.
@@ -125,7 +118,7 @@ func (logical *Logical) Drives() ([]*DiskDrive, error) {
 	var result []*DiskDrive
 
 	physicaldrive, err := ListReferencedPhysicalDrive(logical.Client, logical.datadrive)
-	if err != nil {
+	if err != nil || physicaldrive == nil {
 		return result, err
 	}
 
@@ -15,6 +15,8 @@ type LogicalDrive struct {
 	ODataType   string `json:"@odata.type"`
 	VolumeCount int    `json:"Members@odata.count"`
 	volumes     []string
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 func (logicaldrive *LogicalDrive) UnmarshalJSON(b []byte) error {
@@ -31,56 +33,42 @@ func (logicaldrive *LogicalDrive) UnmarshalJSON(b []byte) error {
 	*logicaldrive = LogicalDrive(t.temp)
 	// Extract the links to other entities for lates
 	logicaldrive.volumes = t.Volumes.ToStrings()
+
+	// Save the raw object data so the object can be marshaled back
+	logicaldrive.rawData = b
+
 	return nil
 }
 
-func GetLogicalDrive(c common.Client, uri string) (*LogicalDrive, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-
-	defer resp.Body.Close()
-	var logicalDrive LogicalDrive
-	err = json.NewDecoder(resp.Body).Decode(&logicalDrive)
-	if err != nil {
-		return nil, err
-	}
+// MarshalJSON marshals a LogicalDrive object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (logicaldrive *LogicalDrive) MarshalJSON() ([]byte, error) {
+	type temp LogicalDrive
+	return common.MarshalObject(logicaldrive.rawData, new(LogicalDrive), temp(*logicaldrive))
+}
 
-	logicalDrive.SetClient(c)
-	return &logicalDrive, nil
+func GetLogicalDrive(c common.Client, uri string) (*LogicalDrive, error) {
+	return common.GetObject[LogicalDrive](c, uri)
 }
 
+// ListReferencedLogicalDrive gets the LogicalDrive resource listing the
+// logical drives at link, or nil if there is no link.
 func ListReferencedLogicalDrive(c common.Client, link string) (*LogicalDrive, error) {
-	var result *LogicalDrive
 	if link == "" {
-		return result, nil
-	}
-
-	logicaldrive, err := GetLogicalDrive(c, link)
-	if err != nil {
-		return logicaldrive, err
+		return nil, nil
 	}
-
-	return logicaldrive, nil
+	return GetLogicalDrive(c, link)
 }
 
 func (logicaldrive *LogicalDrive) Volumes() ([]*Logical, error) {
 	var result []*Logical
 
-	collectionError := common.NewCollectionError()
-	for _, volumeLink := range logicaldrive.volumes {
-		logical, err := GetLogical(logicaldrive.Client, volumeLink)
-		if err != nil {
-			collectionError.Failures[volumeLink] = err
-		} else {
-			result = append(result, logical)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(logicaldrive.Client, logicaldrive.volumes, func(link string) (interface{}, error) {
+		return GetLogical(logicaldrive.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*Logical))
 	}
 
-	return result, collectionError
+	return result, err
 }
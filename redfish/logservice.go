@@ -118,8 +118,15 @@ func (logservice *LogService) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a LogService object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (logservice *LogService) MarshalJSON() ([]byte, error) {
+	type temp LogService
+	return common.MarshalObject(logservice.rawData, new(LogService), temp(*logservice))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (logservice *LogService) Update() error {
+func (logservice *LogService) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(LogService)
@@ -137,64 +144,44 @@ func (logservice *LogService) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(logservice).Elem()
 
-	return logservice.Entity.Update(originalElement, currentElement, readWriteFields)
+	return logservice.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetLogService will get a LogService instance from the service.
 func GetLogService(c common.Client, uri string) (*LogService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var logservice LogService
-	err = json.NewDecoder(resp.Body).Decode(&logservice)
-	if err != nil {
-		return nil, err
-	}
-
-	logservice.SetClient(c)
-	return &logservice, nil
+	return common.GetObject[LogService](c, uri)
 }
 
 // ListReferencedLogServices gets the collection of LogService from a provided reference.
-func ListReferencedLogServices(c common.Client, link string) ([]*LogService, error) { //nolint:dupl
-	var result []*LogService
-	if link == "" {
-		return result, nil
-	}
+func ListReferencedLogServices(c common.Client, link string) ([]*LogService, error) {
+	return common.ListReferenced[LogService](c, link)
+}
 
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
+// Entries gets the log entries of this service.
+func (logservice *LogService) Entries() ([]*LogEntry, error) {
+	return ListReferencedLogEntrys(logservice.Client, logservice.entries)
+}
 
-	collectionError := common.NewCollectionError()
-	for _, logserviceLink := range links.ItemLinks {
-		logservice, err := GetLogService(c, logserviceLink)
-		if err != nil {
-			collectionError.Failures[logserviceLink] = err
-		} else {
-			result = append(result, logservice)
-		}
+// EntriesWithQuery gets the log entries of this service matching the query.
+// It can be used to read large logs in pages using $top and $skip.
+func (logservice *LogService) EntriesWithQuery(query *common.Query) ([]*LogEntry, error) {
+	if logservice.entries == "" {
+		return nil, nil
 	}
 
-	if collectionError.Empty() {
-		return result, nil
+	link, err := common.ApplyQuery(logservice.Client, logservice.entries, query)
+	if err != nil {
+		return nil, err
 	}
 
-	return result, collectionError
-}
-
-// Entries gets the log entries of this service.
-func (logservice *LogService) Entries() ([]*LogEntry, error) {
-	return ListReferencedLogEntrys(logservice.Client, logservice.entries)
+	return ListReferencedLogEntrys(logservice.Client, link)
 }
 
 // ClearLog shall delete all entries found in the Entries collection for this
 // Log Service.
-func (logservice *LogService) ClearLog() error {
+// The request carries the log service ETag in If-Match unless
+// common.SkipETagMatch is given.
+func (logservice *LogService) ClearLog(opts ...common.UpdateOption) error {
 	type temp struct {
 		Action string
 	}
@@ -202,9 +189,5 @@ func (logservice *LogService) ClearLog() error {
 		Action: "LogService.ClearLog",
 	}
 
-	resp, err := logservice.Client.Post(logservice.clearLogTarget, t)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+	return logservice.Post(logservice.clearLogTarget, t, opts...)
 }
@@ -160,6 +160,14 @@ type GraphicalConsole struct {
 	ServiceEnabled bool
 }
 
+// ManagerLinks are the links of a manager that can be changed.
+type ManagerLinks struct {
+	// ActiveSoftwareImage shall contain a link to the SoftwareInventory
+	// resource that represents the active firmware image for this manager.
+	// Changing it selects the image the manager runs after its next reset.
+	ActiveSoftwareImage common.Link
+}
+
 // Manager is a management subsystem. Examples of managers are BMCs, Enclosure
 // Managers, Management Controllers and other subsystems assigned manageability
 // functions.
@@ -197,6 +205,9 @@ type Manager struct {
 	// hostInterfaces shall be a link to a collection of type
 	// HostInterfaceCollection.
 	hostInterfaces string
+	// Links are the links to the resources related to this manager that can
+	// be changed.
+	Links ManagerLinks
 	// logServices shall contain a reference to a collection of type
 	// LogServiceCollection which are for the use of this manager.
 	logServices string
@@ -305,6 +316,7 @@ func (manager *Manager) UnmarshalJSON(b []byte) error {
 		Oem json.RawMessage // OEM actions will be stored here
 	}
 	type linkReference struct {
+		ActiveSoftwareImage     common.Link
 		ManagerForChassis       common.Links
 		ManagerForChassisCount  int `json:"ManagerForChassis@odata.count"`
 		ManagerForServers       common.Links
@@ -342,6 +354,7 @@ func (manager *Manager) UnmarshalJSON(b []byte) error {
 	manager.OemActions = t.Actions.Oem
 	manager.Oem = t.Oem
 	manager.OemLinks = t.Links.Oem
+	manager.Links = ManagerLinks{ActiveSoftwareImage: t.Links.ActiveSoftwareImage}
 	manager.remoteAccountService = string(t.RemoteAccountService)
 	manager.serialInterfaces = string(t.SerialInterfaces)
 	manager.virtualMedia = string(t.VirtualMedia)
@@ -361,8 +374,15 @@ func (manager *Manager) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a Manager object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (manager *Manager) MarshalJSON() ([]byte, error) {
+	type temp Manager
+	return common.MarshalObject(manager.rawData, new(Manager), temp(*manager))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (manager *Manager) Update() error {
+func (manager *Manager) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(Manager)
@@ -375,59 +395,29 @@ func (manager *Manager) Update() error {
 		"AutoDSTEnabled",
 		"DateTime",
 		"DateTimeLocalOffset",
+		"Links.ActiveSoftwareImage",
 	}
 
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(manager).Elem()
 
-	return manager.Entity.Update(originalElement, currentElement, readWriteFields)
+	return manager.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetManager will get a Manager instance from the Swordfish service.
 func GetManager(c common.Client, uri string) (*Manager, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var manager Manager
-	err = json.NewDecoder(resp.Body).Decode(&manager)
-	if err != nil {
-		return nil, err
-	}
-
-	manager.SetClient(c)
-	return &manager, nil
+	return common.GetObject[Manager](c, uri)
 }
 
 // ListReferencedManagers gets the collection of Managers
 func ListReferencedManagers(c common.Client, link string) ([]*Manager, error) {
-	var result []*Manager
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, managerLink := range links.ItemLinks {
-		manager, err := GetManager(c, managerLink)
-		if err != nil {
-			collectionError.Failures[managerLink] = err
-		} else {
-			result = append(result, manager)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return common.ListReferenced[Manager](c, link)
 }
 
 // Reset shall perform a reset of the manager.
-func (manager *Manager) Reset(resetType ResetType) error {
+// The request carries the manager ETag in If-Match unless common.SkipETagMatch
+// is given.
+func (manager *Manager) Reset(resetType ResetType, opts ...common.UpdateOption) error {
 	if len(manager.SupportedResetTypes) == 0 {
 		// reset directly without reset type. HPE server has the behavior
 		type temp struct {
@@ -437,11 +427,7 @@ func (manager *Manager) Reset(resetType ResetType) error {
 			Action: "Manager.Reset",
 		}
 
-		resp, err := manager.Client.Post(manager.resetTarget, t)
-		if err == nil {
-			defer resp.Body.Close()
-		}
-		return err
+		return manager.Post(manager.resetTarget, t, opts...)
 	}
 	// Make sure the requested reset type is supported by the manager.
 	valid := false
@@ -464,11 +450,7 @@ func (manager *Manager) Reset(resetType ResetType) error {
 		ResetType: resetType,
 	}
 
-	resp, err := manager.Client.Post(manager.resetTarget, t)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+	return manager.Post(manager.resetTarget, t, opts...)
 }
 
 // EthernetInterfaces get this system's ethernet interfaces.
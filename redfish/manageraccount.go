@@ -117,8 +117,15 @@ func (manageraccount *ManagerAccount) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a ManagerAccount object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (manageraccount *ManagerAccount) MarshalJSON() ([]byte, error) {
+	type temp ManagerAccount
+	return common.MarshalObject(manageraccount.rawData, new(ManagerAccount), temp(*manageraccount))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (manageraccount *ManagerAccount) Update() error {
+func (manageraccount *ManagerAccount) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(ManagerAccount)
@@ -142,55 +149,18 @@ func (manageraccount *ManagerAccount) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(manageraccount).Elem()
 
-	return manageraccount.Entity.Update(originalElement, currentElement, readWriteFields)
+	return manageraccount.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetManagerAccount will get a ManagerAccount instance from the service.
 func GetManagerAccount(c common.Client, uri string) (*ManagerAccount, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var manageraccount ManagerAccount
-	err = json.NewDecoder(resp.Body).Decode(&manageraccount)
-	if err != nil {
-		return nil, err
-	}
-
-	manageraccount.SetClient(c)
-	return &manageraccount, nil
+	return common.GetObject[ManagerAccount](c, uri)
 }
 
 // ListReferencedManagerAccounts gets the collection of ManagerAccount from
 // a provided reference.
-func ListReferencedManagerAccounts(c common.Client, link string) ([]*ManagerAccount, error) { //nolint:dupl
-	var result []*ManagerAccount
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, manageraccountLink := range links.ItemLinks {
-		manageraccount, err := GetManagerAccount(c, manageraccountLink)
-		if err != nil {
-			collectionError.Failures[manageraccountLink] = err
-		} else {
-			result = append(result, manageraccount)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedManagerAccounts(c common.Client, link string) ([]*ManagerAccount, error) {
+	return common.ListReferenced[ManagerAccount](c, link)
 }
 
 // SNMPUserInfo is shall contain the SNMP settings for an account.
@@ -430,8 +430,15 @@ func (memory *Memory) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a Memory object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (memory *Memory) MarshalJSON() ([]byte, error) {
+	type temp Memory
+	return common.MarshalObject(memory.rawData, new(Memory), temp(*memory))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (memory *Memory) Update() error {
+func (memory *Memory) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(Memory)
@@ -447,56 +454,18 @@ func (memory *Memory) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(memory).Elem()
 
-	return memory.Entity.Update(originalElement, currentElement, readWriteFields)
+	return memory.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetMemory will get a Memory instance from the service.
 func GetMemory(c common.Client, uri string) (*Memory, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var memory Memory
-	err = json.NewDecoder(resp.Body).Decode(&memory)
-	if err != nil {
-		return nil, err
-	}
-
-	memory.SetClient(c)
-	return &memory, nil
+	return common.GetObject[Memory](c, uri)
 }
 
 // ListReferencedMemorys gets the collection of Memory from
 // a provided reference.
-func ListReferencedMemorys(c common.Client, link string) ([]*Memory, error) { //nolint:dupl
-	var result []*Memory
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-
-	for _, memoryLink := range links.ItemLinks {
-		memory, err := GetMemory(c, memoryLink)
-		if err != nil {
-			collectionError.Failures[memoryLink] = err
-		} else {
-			result = append(result, memory)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedMemorys(c common.Client, link string) ([]*Memory, error) {
+	return common.ListReferenced[Memory](c, link)
 }
 
 // Assembly gets this memory's assembly.
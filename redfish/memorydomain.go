@@ -37,6 +37,8 @@ type MemoryDomain struct {
 	InterleavableMemorySets []MemorySet
 	// memoryChunks shall be a link to a collection of type MemoryChunkCollection.
 	memoryChunks string
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a MemoryDomain object from the raw JSON.
@@ -56,55 +58,28 @@ func (memorydomain *MemoryDomain) UnmarshalJSON(b []byte) error {
 	*memorydomain = MemoryDomain(t.temp)
 	memorydomain.memoryChunks = string(t.MemoryChunks)
 
+	// Save the raw object data so the object can be marshaled back
+	memorydomain.rawData = b
+
 	return nil
 }
 
+// MarshalJSON marshals a MemoryDomain object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (memorydomain *MemoryDomain) MarshalJSON() ([]byte, error) {
+	type temp MemoryDomain
+	return common.MarshalObject(memorydomain.rawData, new(MemoryDomain), temp(*memorydomain))
+}
+
 // GetMemoryDomain will get a MemoryDomain instance from the service.
 func GetMemoryDomain(c common.Client, uri string) (*MemoryDomain, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var memorydomain MemoryDomain
-	err = json.NewDecoder(resp.Body).Decode(&memorydomain)
-	if err != nil {
-		return nil, err
-	}
-
-	memorydomain.SetClient(c)
-	return &memorydomain, nil
+	return common.GetObject[MemoryDomain](c, uri)
 }
 
 // ListReferencedMemoryDomains gets the collection of MemoryDomain from
 // a provided reference.
-func ListReferencedMemoryDomains(c common.Client, link string) ([]*MemoryDomain, error) { //nolint:dupl
-	var result []*MemoryDomain
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, memorydomainLink := range links.ItemLinks {
-		memorydomain, err := GetMemoryDomain(c, memorydomainLink)
-		if err != nil {
-			collectionError.Failures[memorydomainLink] = err
-		} else {
-			result = append(result, memorydomain)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedMemoryDomains(c common.Client, link string) ([]*MemoryDomain, error) {
+	return common.ListReferenced[MemoryDomain](c, link)
 }
 
 // MemorySet shall represent the interleave sets for a memory chunk.
@@ -113,6 +88,8 @@ type MemorySet struct {
 	memorySet []string
 	// MemorySetCount is the number of memory sets.
 	MemorySetCount int `json:"MemorySet@odata.count"`
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a MemorySet object from the raw JSON.
@@ -132,5 +109,15 @@ func (memoryset *MemorySet) UnmarshalJSON(b []byte) error {
 	*memoryset = MemorySet(t.temp)
 	memoryset.memorySet = t.MemorySet.ToStrings()
 
+	// Save the raw object data so the object can be marshaled back
+	memoryset.rawData = b
+
 	return nil
 }
+
+// MarshalJSON marshals a MemorySet object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (memoryset *MemorySet) MarshalJSON() ([]byte, error) {
+	type temp MemorySet
+	return common.MarshalObject(memoryset.rawData, new(MemorySet), temp(*memoryset))
+}
@@ -5,8 +5,6 @@
 package redfish
 
 import (
-	"encoding/json"
-
 	"github.com/trungng1992/gofish/common"
 )
 
@@ -102,48 +100,11 @@ type MemoryMetrics struct {
 
 // GetMemoryMetrics will get a MemoryMetrics instance from the service.
 func GetMemoryMetrics(c common.Client, uri string) (*MemoryMetrics, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var memorymetrics MemoryMetrics
-	err = json.NewDecoder(resp.Body).Decode(&memorymetrics)
-	if err != nil {
-		return nil, err
-	}
-
-	memorymetrics.SetClient(c)
-	return &memorymetrics, nil
+	return common.GetObject[MemoryMetrics](c, uri)
 }
 
 // ListReferencedMemoryMetricss gets the collection of MemoryMetrics from
 // a provided reference.
-func ListReferencedMemoryMetricss(c common.Client, link string) ([]*MemoryMetrics, error) { //nolint:dupl
-	var result []*MemoryMetrics
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, memorymetricsLink := range links.ItemLinks {
-		memorymetrics, err := GetMemoryMetrics(c, memorymetricsLink)
-		if err != nil {
-			collectionError.Failures[memorymetricsLink] = err
-		} else {
-			result = append(result, memorymetrics)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedMemoryMetricss(c common.Client, link string) ([]*MemoryMetrics, error) {
+	return common.ListReferenced[MemoryMetrics](c, link)
 }
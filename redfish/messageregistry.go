@@ -5,7 +5,6 @@
 package redfish
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -68,23 +67,8 @@ type MessageRegistry struct {
 }
 
 // GetMessageRegistry will get a MessageRegistry instance from the Redfish service.
-func GetMessageRegistry(
-	c common.Client,
-	uri string,
-) (*MessageRegistry, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var t MessageRegistry
-	err = json.NewDecoder(resp.Body).Decode(&t)
-	if err != nil {
-		return nil, err
-	}
-
-	return &t, nil
+func GetMessageRegistry(c common.Client, uri string) (*MessageRegistry, error) {
+	return common.GetObject[MessageRegistry](c, uri)
 }
 
 // ListReferencedMessageRegistries gets the collection of MessageRegistry.
@@ -92,28 +76,7 @@ func ListReferencedMessageRegistries(
 	c common.Client,
 	link string,
 ) ([]*MessageRegistry, error) {
-	var result []*MessageRegistry
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return nil, err
-	}
-
-	for _, sLink := range links.ItemLinks {
-		mrf, err := GetMessageRegistryFile(c, sLink)
-		if err != nil {
-			return nil, err
-		}
-		// get message registry from all location
-		for _, location := range mrf.Location {
-			mr, err := GetMessageRegistry(c, location.URI)
-			if err != nil {
-				return nil, err
-			}
-			result = append(result, mr)
-		}
-	}
-
-	return result, nil
+	return listReferencedMessageRegistries(c, link, "")
 }
 
 // ListReferencedMessageRegistriesByLanguage gets the collection of MessageRegistry.
@@ -128,30 +91,46 @@ func ListReferencedMessageRegistriesByLanguage(
 		return nil, fmt.Errorf("received empty language")
 	}
 
-	var result []*MessageRegistry
-	links, err := common.GetCollection(c, link)
-	if err != nil {
+	return listReferencedMessageRegistries(c, link, language)
+}
+
+// listReferencedMessageRegistries gets the message registries from every
+// location of the registry files in the collection. If language is not
+// empty, only the registries in that language are fetched.
+func listReferencedMessageRegistries(c common.Client, link, language string) ([]*MessageRegistry, error) {
+	files, err := ListReferencedMessageRegistryFiles(c, link)
+	if err != nil && len(files) == 0 {
 		return nil, err
 	}
 
-	for _, sLink := range links.ItemLinks {
-		mrf, err := GetMessageRegistryFile(c, sLink)
-		if err != nil {
-			return nil, err
-		}
-		// get message registry by language
+	var locations []string
+	for _, mrf := range files {
 		for _, location := range mrf.Location {
-			if location.Language == language {
-				mr, err := GetMessageRegistry(c, location.URI)
-				if err != nil {
-					return nil, err
-				}
-				result = append(result, mr)
+			if language == "" || location.Language == language {
+				locations = append(locations, location.URI)
 			}
 		}
 	}
 
-	return result, nil
+	var result []*MessageRegistry
+	members, registryErr := common.FetchCollectionMembers(c, locations, func(link string) (interface{}, error) {
+		return GetMessageRegistry(c, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*MessageRegistry))
+	}
+
+	// Report the registry files that failed along with the registries
+	if fileErr, ok := err.(*common.CollectionError); ok {
+		if registryErr, ok := registryErr.(*common.CollectionError); ok {
+			for link, failure := range registryErr.Failures {
+				fileErr.Failures[link] = failure
+			}
+		}
+		return result, fileErr
+	}
+
+	return result, registryErr
 }
 
 // GetMessageRegistryByLanguage gets the message registry by language.
@@ -226,12 +205,29 @@ func GetMessageFromMessageRegistryByLanguage(
 		return nil, fmt.Errorf("received empty language")
 	}
 
+	// validate messageID
+	if len(strings.Split(messageID, ".")) != MessageIDSectionLength {
+		return nil, fmt.Errorf("received invalid messageID %s", messageID)
+	}
+
+	allMessageRegistryByLanguage, err := ListReferencedMessageRegistriesByLanguage(c, link, language)
+	if err != nil {
+		return nil, err
+	}
+
+	_, message, err := findRegistryMessage(allMessageRegistryByLanguage, messageID)
+	return message, err
+}
+
+// findRegistryMessage finds the message with the messageID in the registries,
+// and the registry defining it.
+func findRegistryMessage(registries []*MessageRegistry, messageID string) (*MessageRegistry, *MessageRegistryMessage, error) {
 	// split messageID
 	messageIDSplitted := strings.Split(messageID, ".")
 
 	// validate messageID
 	if len(messageIDSplitted) != MessageIDSectionLength {
-		return nil, fmt.Errorf("received invalid messageID %s", messageID)
+		return nil, nil, fmt.Errorf("received invalid messageID %s", messageID)
 	}
 
 	// get information from the messageID
@@ -241,18 +237,14 @@ func GetMessageFromMessageRegistryByLanguage(
 	registryMajorMinorVersion := registryMajorVersion + "." + registryMinorVersion
 	registryMessageKey := messageIDSplitted[3]
 
-	allMessageRegistryByLanguage, err := ListReferencedMessageRegistriesByLanguage(c, link, language)
-	if err != nil {
-		return nil, err
-	}
-	for _, mr := range allMessageRegistryByLanguage {
+	for _, mr := range registries {
 		if mr.RegistryPrefix == registryPrefix &&
 			strings.HasPrefix(mr.RegistryVersion, registryMajorMinorVersion) {
 			if m, ok := mr.Messages[registryMessageKey]; ok {
-				return &m, nil
+				return mr, &m, nil
 			}
 		}
 	}
 
-	return nil, fmt.Errorf("message not found")
+	return nil, nil, fmt.Errorf("message not found")
 }
@@ -5,8 +5,6 @@
 package redfish
 
 import (
-	"encoding/json"
-
 	"github.com/trungng1992/gofish/common"
 )
 
@@ -39,49 +37,11 @@ type MessageRegistryFile struct {
 
 // GetMessageRegistryFile will get a MessageRegistryFile
 // instance from the Redfish service.
-func GetMessageRegistryFile(
-	c common.Client,
-	uri string,
-) (*MessageRegistryFile, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var t MessageRegistryFile
-	err = json.NewDecoder(resp.Body).Decode(&t)
-	if err != nil {
-		return nil, err
-	}
-
-	return &t, nil
+func GetMessageRegistryFile(c common.Client, uri string) (*MessageRegistryFile, error) {
+	return common.GetObject[MessageRegistryFile](c, uri)
 }
 
 // ListReferencedMessageRegistryFiles gets the collection of MessageRegistryFile.
-func ListReferencedMessageRegistryFiles(
-	c common.Client,
-	link string,
-) ([]*MessageRegistryFile, error) {
-	var result []*MessageRegistryFile
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, sLink := range links.ItemLinks {
-		s, err := GetMessageRegistryFile(c, sLink)
-		if err != nil {
-			collectionError.Failures[sLink] = err
-		} else {
-			result = append(result, s)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedMessageRegistryFiles(c common.Client, link string) ([]*MessageRegistryFile, error) {
+	return common.ListReferenced[MessageRegistryFile](c, link)
 }
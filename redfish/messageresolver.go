@@ -0,0 +1,81 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"sync"
+
+	"github.com/trungng1992/gofish/common"
+)
+
+// MessageResolver finds messages in the message registries of a service. It
+// implements common.MessageRegistryLookup, so it can be given to
+// common.ResolveError to format the messages of an error.
+//
+// The registries are fetched on first use and kept for later lookups.
+type MessageResolver struct {
+	client   common.Client
+	link     string
+	language string
+
+	mu         sync.Mutex
+	registries []*MessageRegistry
+}
+
+// NewMessageResolver creates a resolver for the message registries of the
+// collection at link. language is the RFC5646-conformant language code of the
+// registries to use, or empty for all of them.
+func NewMessageResolver(c common.Client, link, language string) *MessageResolver {
+	return &MessageResolver{
+		client:   c,
+		link:     link,
+		language: language,
+	}
+}
+
+// load fetches the registries if they were not already. Registries that
+// could not be fetched are skipped, unless none could.
+func (r *MessageResolver) load() ([]*MessageRegistry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.registries != nil {
+		return r.registries, nil
+	}
+
+	registries, err := listReferencedMessageRegistries(r.client, r.link, r.language)
+	if len(registries) == 0 {
+		return nil, err
+	}
+
+	r.registries = registries
+	return registries, nil
+}
+
+// LookupMessage returns the definition of the message with the messageID,
+// such as Base.1.8.PropertyUnknown.
+func (r *MessageResolver) LookupMessage(messageID string) (*common.RegistryMessage, error) {
+	registries, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	registry, message, err := findRegistryMessage(registries, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	severity := message.MessageSeverity
+	if severity == "" {
+		severity = message.Severity
+	}
+
+	return &common.RegistryMessage{
+		Registry:   registry.RegistryPrefix + "." + registry.RegistryVersion,
+		Message:    message.Message,
+		Severity:   severity,
+		Resolution: message.Resolution,
+	}, nil
+}
@@ -0,0 +1,62 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/trungng1992/gofish/common"
+)
+
+func jsonResponse(body string) *http.Response {
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+// TestMessageResolver tests messages are found in the registries of the
+// service, which are only fetched once.
+func TestMessageResolver(t *testing.T) {
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				jsonResponse(`{"Members@odata.count": 1, "Members": [{"@odata.id": "/redfish/v1/Registries/MyRegistry"}]}`),
+				jsonResponse(`{"Registry": "MyRegistry.2.2", "Location": [{"Language": "en", "Uri": "/registries/MyRegistry.json"}]}`),
+				jsonResponse(messageRegistryBody),
+			},
+		},
+	}
+
+	resolver := NewMessageResolver(testClient, "/redfish/v1/Registries", "en")
+
+	err := common.ConstructError(http.StatusBadRequest, []byte(`{"error": {"@Message.ExtendedInfo": [
+		{"MessageId": "MyRegistry.2.2.ThirdMessage", "MessageArgs": ["one", "two"]},
+		{"MessageId": "MyRegistry.2.2.FirstMessage", "Message": "Sent by the service.", "RelatedProperties": ["#/Name"]},
+		{"MessageId": "Other.1.0.Unknown", "Message": "Not in a registry."}
+	]}}`))
+
+	messages := common.ResolveError(err, resolver)
+	expected := []string{
+		"MyRegistry.2.2.0 Warning: This message has two args: one and two Resolution: The resolution for the third message.",
+		"MyRegistry.2.2.0 OK: Sent by the service. (#/Name) Resolution: The resolution for the first message.",
+		"Other.1.0 Not in a registry.",
+	}
+	if len(messages) != len(expected) {
+		t.Fatalf("Expected %d messages, got %d", len(expected), len(messages))
+	}
+	for i := range expected {
+		if messages[i].String() != expected[i] {
+			t.Errorf("Expected message %q, got %q", expected[i], messages[i].String())
+		}
+	}
+	if messages[2].LookupErr == nil {
+		t.Error("The unknown message should report the lookup error")
+	}
+
+	if calls := len(testClient.CapturedCalls()); calls != 3 {
+		t.Errorf("The registries should be fetched once, got %d calls", calls)
+	}
+}
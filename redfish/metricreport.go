@@ -24,7 +24,7 @@ type MetricReport struct {
 	metricReportDefinition string
 	MetricValues           []MetricValue
 	Name                   string
-	// rawData holds the original serialized JSON so we can compare updates.
+	// rawData holds the original serialized JSON so the object can be marshaled back.
 	rawData []byte
 }
 
@@ -46,48 +46,18 @@ func (metricReport *MetricReport) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a MetricReport object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (metricReport *MetricReport) MarshalJSON() ([]byte, error) {
+	type temp MetricReport
+	return common.MarshalObject(metricReport.rawData, new(MetricReport), temp(*metricReport))
+}
+
 // GetMetricReport will get a metric report instance from the service.
 func GetMetricReports(c common.Client, uri string) (*MetricReport, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var metricReport MetricReport
-	err = json.NewDecoder(resp.Body).Decode(&metricReport)
-	if err != nil {
-		return nil, err
-	}
-
-	metricReport.SetClient(c)
-	return &metricReport, nil
+	return common.GetObject[MetricReport](c, uri)
 }
 
 func ListReferencedMetricReports(c common.Client, link string) ([]*MetricReport, error) {
-	var result []*MetricReport
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, metricReportsLink := range links.ItemLinks {
-		metricReports, err := GetMetricReports(c, metricReportsLink)
-		if err != nil {
-			collectionError.Failures[metricReportsLink] = err
-		} else {
-			result = append(result, metricReports)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return common.ListReferenced[MetricReport](c, link)
 }
@@ -107,6 +107,8 @@ type Controllers struct {
 	pcieDevices []string
 	// PCIeDevicesCount is the number of PCIeDevices.
 	PCIeDevicesCount int
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a Controllers object from the raw JSON.
@@ -140,9 +142,19 @@ func (controllers *Controllers) UnmarshalJSON(b []byte) error {
 	controllers.pcieDevices = t.Links.NetworkDeviceFunctions.ToStrings()
 	controllers.PCIeDevicesCount = t.Links.NetworkDeviceFunctionsCount
 
+	// Save the raw object data so the object can be marshaled back
+	controllers.rawData = b
+
 	return nil
 }
 
+// MarshalJSON marshals a Controllers object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (controllers *Controllers) MarshalJSON() ([]byte, error) {
+	type temp Controllers
+	return common.MarshalObject(controllers.rawData, new(Controllers), temp(*controllers))
+}
+
 // DataCenterBridging shall describe the capability, status,
 // and configuration values related to Data Center Bridging (DCB) for a
 // controller.
@@ -203,6 +215,8 @@ type NetworkAdapter struct {
 	Status common.Status
 	// resetSettingsToDefaultTarget is the URL for sending a ResetSettingsToDefault action
 	resetSettingsToDefaultTarget string
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a NetworkAdapter object from the raw JSON.
@@ -233,50 +247,27 @@ func (networkadapter *NetworkAdapter) UnmarshalJSON(b []byte) error {
 	networkadapter.networkPorts = string(t.NetworkPorts)
 	networkadapter.resetSettingsToDefaultTarget = t.Actions.ResetSettingsToDefault.Target
 
+	// Save the raw object data so the object can be marshaled back
+	networkadapter.rawData = b
+
 	return nil
 }
 
+// MarshalJSON marshals a NetworkAdapter object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (networkadapter *NetworkAdapter) MarshalJSON() ([]byte, error) {
+	type temp NetworkAdapter
+	return common.MarshalObject(networkadapter.rawData, new(NetworkAdapter), temp(*networkadapter))
+}
+
 // GetNetworkAdapter will get a NetworkAdapter instance from the Redfish service.
 func GetNetworkAdapter(c common.Client, uri string) (*NetworkAdapter, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var networkAdapter NetworkAdapter
-	err = json.NewDecoder(resp.Body).Decode(&networkAdapter)
-	if err != nil {
-		return nil, err
-	}
-
-	networkAdapter.SetClient(c)
-	return &networkAdapter, nil
+	return common.GetObject[NetworkAdapter](c, uri)
 }
 
 // ListReferencedNetworkAdapter gets the collection of Chassis from a provided reference.
 func ListReferencedNetworkAdapter(c common.Client, link string) ([]*NetworkAdapter, error) {
-	var result []*NetworkAdapter
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, networkAdapterLink := range links.ItemLinks {
-		networkAdapter, err := GetNetworkAdapter(c, networkAdapterLink)
-		if err != nil {
-			collectionError.Failures[networkAdapterLink] = err
-		} else {
-			result = append(result, networkAdapter)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return common.ListReferenced[NetworkAdapter](c, link)
 }
 
 // Assembly gets this adapter's assembly.
@@ -299,10 +290,8 @@ func (networkadapter *NetworkAdapter) NetworkPorts() ([]*NetworkPort, error) {
 
 // ResetSettingsToDefault shall perform a reset of all active and pending
 // settings back to factory default settings upon reset of the network adapter.
-func (networkadapter *NetworkAdapter) ResetSettingsToDefault() error {
-	resp, err := networkadapter.Client.Post(networkadapter.resetSettingsToDefaultTarget, nil)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+// The request carries the network adapter ETag in If-Match unless
+// common.SkipETagMatch is given.
+func (networkadapter *NetworkAdapter) ResetSettingsToDefault(opts ...common.UpdateOption) error {
+	return networkadapter.Post(networkadapter.resetSettingsToDefaultTarget, nil, opts...)
 }
@@ -110,6 +110,8 @@ type BootTargets struct {
 	// WWPN shall be World-Wide Port Name
 	// (WWPN) to boot from.
 	WWPN string
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a BootTargets object from the raw JSON.
@@ -128,9 +130,19 @@ func (boottargets *BootTargets) UnmarshalJSON(b []byte) error {
 
 	// Extract the links to other entities for later
 
+	// Save the raw object data so the object can be marshaled back
+	boottargets.rawData = b
+
 	return nil
 }
 
+// MarshalJSON marshals a BootTargets object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (boottargets *BootTargets) MarshalJSON() ([]byte, error) {
+	type temp BootTargets
+	return common.MarshalObject(boottargets.rawData, new(BootTargets), temp(*boottargets))
+}
+
 // Ethernet shall describe the Ethernet capabilities, status, and configuration
 // values for a network device function.
 type Ethernet struct {
@@ -153,6 +165,8 @@ type Ethernet struct {
 	vlan string
 	// VLANs is used, the VLANEnabled and VLANId property shall not be used.
 	vlans string
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a Ethernet object from the raw JSON.
@@ -175,9 +189,19 @@ func (ethernet *Ethernet) UnmarshalJSON(b []byte) error {
 	ethernet.vlan = string(t.VLAN)
 	ethernet.vlans = string(t.VLANs)
 
+	// Save the raw object data so the object can be marshaled back
+	ethernet.rawData = b
+
 	return nil
 }
 
+// MarshalJSON marshals a Ethernet object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (ethernet *Ethernet) MarshalJSON() ([]byte, error) {
+	type temp Ethernet
+	return common.MarshalObject(ethernet.rawData, new(Ethernet), temp(*ethernet))
+}
+
 // TODO: Add functions to get VLAN information.
 
 // FibreChannel shall describe the Fibre Channel capabilities, status, and
@@ -326,8 +350,15 @@ func (networkdevicefunction *NetworkDeviceFunction) UnmarshalJSON(b []byte) erro
 	return nil
 }
 
+// MarshalJSON marshals a NetworkDeviceFunction object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (networkdevicefunction *NetworkDeviceFunction) MarshalJSON() ([]byte, error) {
+	type temp NetworkDeviceFunction
+	return common.MarshalObject(networkdevicefunction.rawData, new(NetworkDeviceFunction), temp(*networkdevicefunction))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (networkdevicefunction *NetworkDeviceFunction) Update() error {
+func (networkdevicefunction *NetworkDeviceFunction) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(NetworkDeviceFunction)
@@ -345,55 +376,18 @@ func (networkdevicefunction *NetworkDeviceFunction) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(networkdevicefunction).Elem()
 
-	return networkdevicefunction.Entity.Update(originalElement, currentElement, readWriteFields)
+	return networkdevicefunction.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetNetworkDeviceFunction will get a NetworkDeviceFunction instance from the service.
 func GetNetworkDeviceFunction(c common.Client, uri string) (*NetworkDeviceFunction, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var networkdevicefunction NetworkDeviceFunction
-	err = json.NewDecoder(resp.Body).Decode(&networkdevicefunction)
-	if err != nil {
-		return nil, err
-	}
-
-	networkdevicefunction.SetClient(c)
-	return &networkdevicefunction, nil
+	return common.GetObject[NetworkDeviceFunction](c, uri)
 }
 
 // ListReferencedNetworkDeviceFunctions gets the collection of NetworkDeviceFunction from
 // a provided reference.
-func ListReferencedNetworkDeviceFunctions(c common.Client, link string) ([]*NetworkDeviceFunction, error) { //nolint:dupl
-	var result []*NetworkDeviceFunction
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, networkdevicefunctionLink := range links.ItemLinks {
-		networkdevicefunction, err := GetNetworkDeviceFunction(c, networkdevicefunctionLink)
-		if err != nil {
-			collectionError.Failures[networkdevicefunctionLink] = err
-		} else {
-			result = append(result, networkdevicefunction)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedNetworkDeviceFunctions(c common.Client, link string) ([]*NetworkDeviceFunction, error) {
+	return common.ListReferenced[NetworkDeviceFunction](c, link)
 }
 
 // ISCSIBoot shall describe the iSCSI boot capabilities, status, and
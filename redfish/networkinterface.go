@@ -41,6 +41,8 @@ type NetworkInterface struct {
 	networkPorts string
 	// Status shall contain any status or health properties of the resource.
 	Status common.Status
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a NetworkInterface object from the raw JSON.
@@ -64,55 +66,28 @@ func (networkinterface *NetworkInterface) UnmarshalJSON(b []byte) error {
 	networkinterface.networkDeviceFunctions = string(t.NetworkDeviceFunctions)
 	networkinterface.networkPorts = string(t.NetworkPorts)
 
+	// Save the raw object data so the object can be marshaled back
+	networkinterface.rawData = b
+
 	return nil
 }
 
+// MarshalJSON marshals a NetworkInterface object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (networkinterface *NetworkInterface) MarshalJSON() ([]byte, error) {
+	type temp NetworkInterface
+	return common.MarshalObject(networkinterface.rawData, new(NetworkInterface), temp(*networkinterface))
+}
+
 // GetNetworkInterface will get a NetworkInterface instance from the service.
 func GetNetworkInterface(c common.Client, uri string) (*NetworkInterface, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var networkinterface NetworkInterface
-	err = json.NewDecoder(resp.Body).Decode(&networkinterface)
-	if err != nil {
-		return nil, err
-	}
-
-	networkinterface.SetClient(c)
-	return &networkinterface, nil
+	return common.GetObject[NetworkInterface](c, uri)
 }
 
 // ListReferencedNetworkInterfaces gets the collection of NetworkInterface from
 // a provided reference.
-func ListReferencedNetworkInterfaces(c common.Client, link string) ([]*NetworkInterface, error) { //nolint:dupl
-	var result []*NetworkInterface
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, networkinterfaceLink := range links.ItemLinks {
-		networkinterface, err := GetNetworkInterface(c, networkinterfaceLink)
-		if err != nil {
-			collectionError.Failures[networkinterfaceLink] = err
-		} else {
-			result = append(result, networkinterface)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedNetworkInterfaces(c common.Client, link string) ([]*NetworkInterface, error) {
+	return common.ListReferenced[NetworkInterface](c, link)
 }
 
 // NetworkAdapter gets the NetworkAdapter for this interface.
@@ -223,8 +223,15 @@ func (networkport *NetworkPort) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a NetworkPort object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (networkport *NetworkPort) MarshalJSON() ([]byte, error) {
+	type temp NetworkPort
+	return common.MarshalObject(networkport.rawData, new(NetworkPort), temp(*networkport))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (networkport *NetworkPort) Update() error {
+func (networkport *NetworkPort) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(NetworkPort)
@@ -244,55 +251,18 @@ func (networkport *NetworkPort) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(networkport).Elem()
 
-	return networkport.Entity.Update(originalElement, currentElement, readWriteFields)
+	return networkport.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetNetworkPort will get a NetworkPort instance from the service.
 func GetNetworkPort(c common.Client, uri string) (*NetworkPort, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var networkport NetworkPort
-	err = json.NewDecoder(resp.Body).Decode(&networkport)
-	if err != nil {
-		return nil, err
-	}
-
-	networkport.SetClient(c)
-	return &networkport, nil
+	return common.GetObject[NetworkPort](c, uri)
 }
 
 // ListReferencedNetworkPorts gets the collection of NetworkPort from
 // a provided reference.
-func ListReferencedNetworkPorts(c common.Client, link string) ([]*NetworkPort, error) { //nolint:dupl
-	var result []*NetworkPort
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, networkportLink := range links.ItemLinks {
-		networkport, err := GetNetworkPort(c, networkportLink)
-		if err != nil {
-			collectionError.Failures[networkportLink] = err
-		} else {
-			result = append(result, networkport)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedNetworkPorts(c common.Client, link string) ([]*NetworkPort, error) {
+	return common.ListReferenced[NetworkPort](c, link)
 }
 
 // SupportedLinkCapabilities shall describe the static capabilities of an
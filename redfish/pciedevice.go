@@ -6,7 +6,6 @@ package redfish
 
 import (
 	"encoding/json"
-	"fmt"
 	"reflect"
 
 	"github.com/trungng1992/gofish/common"
@@ -128,8 +127,15 @@ func (pciedevice *PCIeDevice) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a PCIeDevice object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (pciedevice *PCIeDevice) MarshalJSON() ([]byte, error) {
+	type temp PCIeDevice
+	return common.MarshalObject(pciedevice.rawData, new(PCIeDevice), temp(*pciedevice))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (pciedevice *PCIeDevice) Update() error {
+func (pciedevice *PCIeDevice) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(PCIeDevice)
@@ -145,55 +151,18 @@ func (pciedevice *PCIeDevice) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(pciedevice).Elem()
 
-	return pciedevice.Entity.Update(originalElement, currentElement, readWriteFields)
+	return pciedevice.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetPCIeDevice will get a PCIeDevice instance from the service.
 func GetPCIeDevice(c common.Client, uri string) (*PCIeDevice, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var pciedevice PCIeDevice
-	err = json.NewDecoder(resp.Body).Decode(&pciedevice)
-	if err != nil {
-		return nil, err
-	}
-
-	pciedevice.SetClient(c)
-	return &pciedevice, nil
+	return common.GetObject[PCIeDevice](c, uri)
 }
 
 // ListReferencedPCIeDevices gets the collection of PCIeDevice from
 // a provided reference.
-func ListReferencedPCIeDevices(c common.Client, link string) ([]*PCIeDevice, error) { //nolint:dupl
-	var result []*PCIeDevice
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-	fmt.Println(links)
-	collectionError := common.NewCollectionError()
-	for _, pciedeviceLink := range links.ItemLinks {
-		pciedevice, err := GetPCIeDevice(c, pciedeviceLink)
-		if err != nil {
-			collectionError.Failures[pciedeviceLink] = err
-		} else {
-			result = append(result, pciedevice)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedPCIeDevices(c common.Client, link string) ([]*PCIeDevice, error) {
+	return common.ListReferenced[PCIeDevice](c, link)
 }
 
 // PCIeInterface properties shall be the definition for a PCIe Interface for a
@@ -222,40 +191,26 @@ func (pciedevice *PCIeDevice) Assembly() (*Assembly, error) {
 func (pciedevice *PCIeDevice) Chassis() ([]*Chassis, error) {
 	var result []*Chassis
 
-	collectionError := common.NewCollectionError()
-	for _, chassisLink := range pciedevice.chassis {
-		chassis, err := GetChassis(pciedevice.Client, chassisLink)
-		if err != nil {
-			collectionError.Failures[chassisLink] = err
-		} else {
-			result = append(result, chassis)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(pciedevice.Client, pciedevice.chassis, func(link string) (interface{}, error) {
+		return GetChassis(pciedevice.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*Chassis))
 	}
 
-	return result, collectionError
+	return result, err
 }
 
 // PCIeFunctions get the PCIe functions that this device exposes.
 func (pciedevice *PCIeDevice) PCIeFunctions() ([]*PCIeDevice, error) {
 	var result []*PCIeDevice
 
-	collectionError := common.NewCollectionError()
-	for _, funcLink := range pciedevice.pcieFunctions {
-		pciFunction, err := GetPCIeDevice(pciedevice.Client, funcLink)
-		if err != nil {
-			collectionError.Failures[funcLink] = err
-		} else {
-			result = append(result, pciFunction)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(pciedevice.Client, pciedevice.pcieFunctions, func(link string) (interface{}, error) {
+		return GetPCIeDevice(pciedevice.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*PCIeDevice))
 	}
 
-	return result, collectionError
+	return result, err
 }
@@ -133,6 +133,8 @@ type PCIeFunction struct {
 	storageControllers []string
 	// StorageControllersCount is the number of storage controllers.
 	StorageControllersCount int
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a PCIeFunction object from the raw JSON.
@@ -174,118 +176,70 @@ func (pciefunction *PCIeFunction) UnmarshalJSON(b []byte) error {
 	pciefunction.storageControllers = t.Links.StorageControllers.ToStrings()
 	pciefunction.StorageControllersCount = t.Links.StorageControllersCount
 
+	// Save the raw object data so the object can be marshaled back
+	pciefunction.rawData = b
+
 	return nil
 }
 
+// MarshalJSON marshals a PCIeFunction object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (pciefunction *PCIeFunction) MarshalJSON() ([]byte, error) {
+	type temp PCIeFunction
+	return common.MarshalObject(pciefunction.rawData, new(PCIeFunction), temp(*pciefunction))
+}
+
 // GetPCIeFunction will get a PCIeFunction instance from the service.
 func GetPCIeFunction(c common.Client, uri string) (*PCIeFunction, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var pciefunction PCIeFunction
-	err = json.NewDecoder(resp.Body).Decode(&pciefunction)
-	if err != nil {
-		return nil, err
-	}
-
-	pciefunction.SetClient(c)
-	return &pciefunction, nil
+	return common.GetObject[PCIeFunction](c, uri)
 }
 
 // ListReferencedPCIeFunctions gets the collection of PCIeFunction from
 // a provided reference.
-func ListReferencedPCIeFunctions(c common.Client, link string) ([]*PCIeFunction, error) { //nolint:dupl
-	var result []*PCIeFunction
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, pciefunctionLink := range links.ItemLinks {
-		pciefunction, err := GetPCIeFunction(c, pciefunctionLink)
-		if err != nil {
-			collectionError.Failures[pciefunctionLink] = err
-		} else {
-			result = append(result, pciefunction)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedPCIeFunctions(c common.Client, link string) ([]*PCIeFunction, error) {
+	return common.ListReferenced[PCIeFunction](c, link)
 }
 
 // Drives gets the PCIe function's drives.
 func (pciefunction *PCIeFunction) Drives() ([]*Drive, error) {
 	var result []*Drive
 
-	collectionError := common.NewCollectionError()
-	for _, driveLink := range pciefunction.drives {
-		drive, err := GetDrive(pciefunction.Client, driveLink)
-		if err != nil {
-			collectionError.Failures[driveLink] = err
-		} else {
-			result = append(result, drive)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(pciefunction.Client, pciefunction.drives, func(link string) (interface{}, error) {
+		return GetDrive(pciefunction.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*Drive))
 	}
 
-	return result, collectionError
+	return result, err
 }
 
 // EthernetInterfaces gets the PCIe function's ethernet interfaces.
 func (pciefunction *PCIeFunction) EthernetInterfaces() ([]*EthernetInterface, error) {
 	var result []*EthernetInterface
 
-	collectionError := common.NewCollectionError()
-	for _, ethLink := range pciefunction.ethernetInterfaces {
-		eth, err := GetEthernetInterface(pciefunction.Client, ethLink)
-		if err != nil {
-			collectionError.Failures[ethLink] = err
-		} else {
-			result = append(result, eth)
-		}
+	members, err := common.FetchCollectionMembers(pciefunction.Client, pciefunction.ethernetInterfaces, func(link string) (interface{}, error) {
+		return GetEthernetInterface(pciefunction.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*EthernetInterface))
 	}
 
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return result, err
 }
 
 // NetworkDeviceFunctions gets the PCIe function's ethernet interfaces.
 func (pciefunction *PCIeFunction) NetworkDeviceFunctions() ([]*NetworkDeviceFunction, error) {
 	var result []*NetworkDeviceFunction
 
-	collectionError := common.NewCollectionError()
-	for _, netLink := range pciefunction.networkDeviceFunctions {
-		net, err := GetNetworkDeviceFunction(pciefunction.Client, netLink)
-		if err != nil {
-			collectionError.Failures[netLink] = err
-		} else {
-			result = append(result, net)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(pciefunction.Client, pciefunction.networkDeviceFunctions, func(link string) (interface{}, error) {
+		return GetNetworkDeviceFunction(pciefunction.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*NetworkDeviceFunction))
 	}
 
-	return result, collectionError
+	return result, err
 }
 
 // PCIeDevice gets the associated PCIe device for this function.
@@ -300,19 +254,12 @@ func (pciefunction *PCIeFunction) PCIeDevice() (*PCIeDevice, error) {
 func (pciefunction *PCIeFunction) StorageControllers() ([]*StorageController, error) {
 	var result []*StorageController
 
-	collectionError := common.NewCollectionError()
-	for _, scLink := range pciefunction.storageControllers {
-		sc, err := GetStorageController(pciefunction.Client, scLink)
-		if err != nil {
-			collectionError.Failures[scLink] = err
-		} else {
-			result = append(result, sc)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(pciefunction.Client, pciefunction.storageControllers, func(link string) (interface{}, error) {
+		return GetStorageController(pciefunction.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*StorageController))
 	}
 
-	return result, collectionError
+	return result, err
 }
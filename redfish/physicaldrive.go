@@ -17,6 +17,8 @@ type PhysicalDrive struct {
 	DrivesCount int `json:"Members@odata.count"`
 
 	drives []string
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshallJSON unmarshalls a PhysicalDrive object from the raw JSON
@@ -36,61 +38,46 @@ func (physicaldrive *PhysicalDrive) UnmarshalJSON(b []byte) error {
 
 	// Extract the links to other entities for lates
 	physicaldrive.drives = t.Drives.ToStrings()
+
+	// Save the raw object data so the object can be marshaled back
+	physicaldrive.rawData = b
+
 	return nil
 }
 
+// MarshalJSON marshals a PhysicalDrive object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (physicaldrive *PhysicalDrive) MarshalJSON() ([]byte, error) {
+	type temp PhysicalDrive
+	return common.MarshalObject(physicaldrive.rawData, new(PhysicalDrive), temp(*physicaldrive))
+}
+
 func (physicaldrive *PhysicalDrive) GetListDrives() []string {
 	return physicaldrive.drives
 }
 
 func GetPhysicalDrive(c common.Client, uri string) (*PhysicalDrive, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-
-	defer resp.Body.Close()
-
-	var physicalDrive PhysicalDrive
-	err = json.NewDecoder(resp.Body).Decode(&physicalDrive)
-	if err != nil {
-		return nil, err
-	}
-
-	physicalDrive.SetClient(c)
-	return &physicalDrive, nil
+	return common.GetObject[PhysicalDrive](c, uri)
 }
 
+// ListReferencedPhysicalDrive gets the PhysicalDrive resource listing the
+// drives at link, or nil if there is no link.
 func ListReferencedPhysicalDrive(c common.Client, link string) (*PhysicalDrive, error) {
-	var result *PhysicalDrive
 	if link == "" {
-		return result, nil
+		return nil, nil
 	}
-
-	physicaldrive, err := GetPhysicalDrive(c, link)
-	if err != nil {
-		return physicaldrive, err
-	}
-
-	return physicaldrive, nil
+	return GetPhysicalDrive(c, link)
 }
 
 func (physicaldrive *PhysicalDrive) Drives() ([]*DiskDrive, error) {
 	var result []*DiskDrive
 
-	collectionError := common.NewCollectionError()
-	for _, driveLink := range physicaldrive.drives {
-		drive, err := GetDiskDrive(physicaldrive.Client, driveLink)
-		if err != nil {
-			collectionError.Failures[driveLink] = err
-		} else {
-			result = append(result, drive)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(physicaldrive.Client, physicaldrive.drives, func(link string) (interface{}, error) {
+		return GetDiskDrive(physicaldrive.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*DiskDrive))
 	}
 
-	return result, collectionError
+	return result, err
 }
@@ -148,50 +148,13 @@ type Power struct {
 
 // GetPower will get a Power instance from the service.
 func GetPower(c common.Client, uri string) (*Power, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var power Power
-	err = json.NewDecoder(resp.Body).Decode(&power)
-	if err != nil {
-		return nil, err
-	}
-
-	power.SetClient(c)
-	return &power, nil
+	return common.GetObject[Power](c, uri)
 }
 
 // ListReferencedPowers gets the collection of Power from
 // a provided reference.
-func ListReferencedPowers(c common.Client, link string) ([]*Power, error) { //nolint:dupl
-	var result []*Power
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, powerLink := range links.ItemLinks {
-		power, err := GetPower(c, powerLink)
-		if err != nil {
-			collectionError.Failures[powerLink] = err
-		} else {
-			result = append(result, power)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedPowers(c common.Client, link string) ([]*Power, error) {
+	return common.ListReferenced[Power](c, link)
 }
 
 // PowerControl is
@@ -231,6 +194,8 @@ type PowerControl struct {
 	// Status shall contain any status or health properties
 	// of the resource.
 	Status common.Status
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a PowerControl object from the raw JSON.
@@ -263,9 +228,19 @@ func (powercontrol *PowerControl) UnmarshalJSON(b []byte) error { // nolint:dupl
 	// Extract the links to other entities for later
 	*powercontrol = PowerControl(t.temp)
 
+	// Save the raw object data so the object can be marshaled back
+	powercontrol.rawData = b
+
 	return nil
 }
 
+// MarshalJSON marshals a PowerControl object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (powercontrol *PowerControl) MarshalJSON() ([]byte, error) {
+	type temp PowerControl
+	return common.MarshalObject(powercontrol.rawData, new(PowerControl), temp(*powercontrol))
+}
+
 // PowerLimit shall contain power limit status and
 // configuration information for this chassis.
 type PowerLimit struct {
@@ -428,8 +403,15 @@ func (powersupply *PowerSupply) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a PowerSupply object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (powersupply *PowerSupply) MarshalJSON() ([]byte, error) {
+	type temp PowerSupply
+	return common.MarshalObject(powersupply.rawData, new(PowerSupply), temp(*powersupply))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (powersupply *PowerSupply) Update() error {
+func (powersupply *PowerSupply) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(PowerSupply)
@@ -445,7 +427,7 @@ func (powersupply *PowerSupply) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(powersupply).Elem()
 
-	return powersupply.Entity.Update(originalElement, currentElement, readWriteFields)
+	return powersupply.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // Voltage is a voltage representation.
@@ -501,6 +483,8 @@ type Voltage struct {
 	// the present reading is above the normal range but is not critical.
 	// Units shall use the same units as the related ReadingVolts property.
 	UpperThresholdNonCritical float32
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a Voltage object from the raw JSON.
@@ -533,5 +517,15 @@ func (voltage *Voltage) UnmarshalJSON(b []byte) error { // nolint:dupl
 	// Extract the links to other entities for later
 	*voltage = Voltage(t.temp)
 
+	// Save the raw object data so the object can be marshaled back
+	voltage.rawData = b
+
 	return nil
 }
+
+// MarshalJSON marshals a Voltage object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (voltage *Voltage) MarshalJSON() ([]byte, error) {
+	type temp Voltage
+	return common.MarshalObject(voltage.rawData, new(Voltage), temp(*voltage))
+}
@@ -366,6 +366,8 @@ type Processor struct {
 	pcieFunctions []string
 	// PCIeFunctions@odata.count is
 	PCIeFunctionsCount int
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a Processor object from the raw JSON.
@@ -434,50 +436,27 @@ func (processor *Processor) UnmarshalJSON(b []byte) error {
 	processor.subProcessors = string(t.SubProcessors)
 	processor.metrics = string(t.Metrics)
 
+	// Save the raw object data so the object can be marshaled back
+	processor.rawData = b
+
 	return nil
 }
 
+// MarshalJSON marshals a Processor object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (processor *Processor) MarshalJSON() ([]byte, error) {
+	type temp Processor
+	return common.MarshalObject(processor.rawData, new(Processor), temp(*processor))
+}
+
 // GetProcessor will get a Processor instance from the system
 func GetProcessor(c common.Client, uri string) (*Processor, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var processor Processor
-	err = json.NewDecoder(resp.Body).Decode(&processor)
-	if err != nil {
-		return nil, err
-	}
-
-	processor.SetClient(c)
-	return &processor, nil
+	return common.GetObject[Processor](c, uri)
 }
 
 // ListReferencedProcessors gets the collection of Processor from a provided reference.
 func ListReferencedProcessors(c common.Client, link string) ([]*Processor, error) {
-	var result []*Processor
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, processorLink := range links.ItemLinks {
-		processor, err := GetProcessor(c, processorLink)
-		if err != nil {
-			collectionError.Failures[processorLink] = err
-		} else {
-			result = append(result, processor)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return common.ListReferenced[Processor](c, link)
 }
 
 // ProcessorID shall contain identification information for a processor.
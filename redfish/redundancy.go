@@ -92,8 +92,15 @@ func (redundancy *Redundancy) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a Redundancy object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (redundancy *Redundancy) MarshalJSON() ([]byte, error) {
+	type temp Redundancy
+	return common.MarshalObject(redundancy.rawData, new(Redundancy), temp(*redundancy))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (redundancy *Redundancy) Update() error {
+func (redundancy *Redundancy) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(Redundancy)
@@ -110,53 +117,16 @@ func (redundancy *Redundancy) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(redundancy).Elem()
 
-	return redundancy.Entity.Update(originalElement, currentElement, readWriteFields)
+	return redundancy.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetRedundancy will get a Redundancy instance from the service.
 func GetRedundancy(c common.Client, uri string) (*Redundancy, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var redundancy Redundancy
-	err = json.NewDecoder(resp.Body).Decode(&redundancy)
-	if err != nil {
-		return nil, err
-	}
-
-	redundancy.SetClient(c)
-	return &redundancy, nil
+	return common.GetObject[Redundancy](c, uri)
 }
 
 // ListReferencedRedundancies gets the collection of Redundancy from
 // a provided reference.
-func ListReferencedRedundancies(c common.Client, link string) ([]*Redundancy, error) { //nolint:dupl
-	var result []*Redundancy
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, redundancyLink := range links.ItemLinks {
-		redundancy, err := GetRedundancy(c, redundancyLink)
-		if err != nil {
-			collectionError.Failures[redundancyLink] = err
-		} else {
-			result = append(result, redundancy)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedRedundancies(c common.Client, link string) ([]*Redundancy, error) {
+	return common.ListReferenced[Redundancy](c, link)
 }
@@ -84,8 +84,15 @@ func (role *Role) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a Role object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (role *Role) MarshalJSON() ([]byte, error) {
+	type temp Role
+	return common.MarshalObject(role.rawData, new(Role), temp(*role))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (role *Role) Update() error {
+func (role *Role) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(Role)
@@ -102,53 +109,16 @@ func (role *Role) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(role).Elem()
 
-	return role.Entity.Update(originalElement, currentElement, readWriteFields)
+	return role.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetRole will get a Role instance from the service.
 func GetRole(c common.Client, uri string) (*Role, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var role Role
-	err = json.NewDecoder(resp.Body).Decode(&role)
-	if err != nil {
-		return nil, err
-	}
-
-	role.SetClient(c)
-	return &role, nil
+	return common.GetObject[Role](c, uri)
 }
 
 // ListReferencedRoles gets the collection of Role from
 // a provided reference.
-func ListReferencedRoles(c common.Client, link string) ([]*Role, error) { //nolint:dupl
-	var result []*Role
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, roleLink := range links.ItemLinks {
-		role, err := GetRole(c, roleLink)
-		if err != nil {
-			collectionError.Failures[roleLink] = err
-		} else {
-			result = append(result, role)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedRoles(c common.Client, link string) ([]*Role, error) {
+	return common.ListReferenced[Role](c, link)
 }
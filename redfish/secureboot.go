@@ -106,8 +106,15 @@ func (secureboot *SecureBoot) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a SecureBoot object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (secureboot *SecureBoot) MarshalJSON() ([]byte, error) {
+	type temp SecureBoot
+	return common.MarshalObject(secureboot.rawData, new(SecureBoot), temp(*secureboot))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (secureboot *SecureBoot) Update() error {
+func (secureboot *SecureBoot) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(SecureBoot)
@@ -123,55 +130,18 @@ func (secureboot *SecureBoot) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(secureboot).Elem()
 
-	return secureboot.Entity.Update(originalElement, currentElement, readWriteFields)
+	return secureboot.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetSecureBoot will get a SecureBoot instance from the service.
 func GetSecureBoot(c common.Client, uri string) (*SecureBoot, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var secureboot SecureBoot
-	err = json.NewDecoder(resp.Body).Decode(&secureboot)
-	if err != nil {
-		return nil, err
-	}
-
-	secureboot.SetClient(c)
-	return &secureboot, nil
+	return common.GetObject[SecureBoot](c, uri)
 }
 
 // ListReferencedSecureBoots gets the collection of SecureBoot from
 // a provided reference.
-func ListReferencedSecureBoots(c common.Client, link string) ([]*SecureBoot, error) { //nolint:dupl
-	var result []*SecureBoot
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, securebootLink := range links.ItemLinks {
-		secureboot, err := GetSecureBoot(c, securebootLink)
-		if err != nil {
-			collectionError.Failures[securebootLink] = err
-		} else {
-			result = append(result, secureboot)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedSecureBoots(c common.Client, link string) ([]*SecureBoot, error) {
+	return common.ListReferenced[SecureBoot](c, link)
 }
 
 // ResetKeys shall perform a reset of the Secure Boot key databases. The
@@ -179,15 +149,13 @@ func ListReferencedSecureBoots(c common.Client, link string) ([]*SecureBoot, err
 // their default values. The DeleteAllKeys value shall delete the content of the
 // UEFI Secure Boot key databases. The DeletePK value shall delete the content
 // of the PK Secure boot key.
-func (secureboot *SecureBoot) ResetKeys(resetType ResetKeysType) error {
+// The request carries the Secure Boot ETag in If-Match unless
+// common.SkipETagMatch is given.
+func (secureboot *SecureBoot) ResetKeys(resetType ResetKeysType, opts ...common.UpdateOption) error {
 	type temp struct {
 		ResetKeysType ResetKeysType
 	}
 	t := temp{ResetKeysType: resetType}
 
-	resp, err := secureboot.Client.Post(secureboot.resetKeysTarget, t)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+	return secureboot.Post(secureboot.resetKeysTarget, t, opts...)
 }
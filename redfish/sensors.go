@@ -28,7 +28,7 @@ type Sensors struct {
 	ThresholdUpperCaution  float64
 	ThresholdUpperCritical float64
 	Status                 common.Status
-	// rawData holds the original serialized JSON so we can compare updates.
+	// rawData holds the original serialized JSON so the object can be marshaled back.
 	rawData []byte
 }
 
@@ -80,48 +80,18 @@ func (sensors *Sensors) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a Sensors object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (sensors *Sensors) MarshalJSON() ([]byte, error) {
+	type temp Sensors
+	return common.MarshalObject(sensors.rawData, new(Sensors), temp(*sensors))
+}
+
 // GetMetricReport will get a metric report instance from the service.
 func GetSensors(c common.Client, uri string) (*Sensors, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var sensors Sensors
-	err = json.NewDecoder(resp.Body).Decode(&sensors)
-	if err != nil {
-		return nil, err
-	}
-
-	sensors.SetClient(c)
-	return &sensors, nil
+	return common.GetObject[Sensors](c, uri)
 }
 
 func ListReferencedSensors(c common.Client, link string) ([]*Sensors, error) {
-	var result []*Sensors
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, sensorsLink := range links.ItemLinks {
-		sensors, err := GetSensors(c, sensorsLink)
-		if err != nil {
-			collectionError.Failures[sensorsLink] = err
-		} else {
-			result = append(result, sensors)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return common.ListReferenced[Sensors](c, link)
 }
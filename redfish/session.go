@@ -5,7 +5,6 @@
 package redfish
 
 import (
-	"encoding/json"
 	"net/url"
 
 	"github.com/trungng1992/gofish/common"
@@ -114,42 +113,10 @@ func DeleteSession(c common.Client, sessionURL string) (err error) {
 
 // GetSession will get a Session instance from the Redfish service.
 func GetSession(c common.Client, uri string) (*Session, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var t Session
-	err = json.NewDecoder(resp.Body).Decode(&t)
-	if err != nil {
-		return nil, err
-	}
-
-	return &t, nil
+	return common.GetObject[Session](c, uri)
 }
 
 // ListReferencedSessions gets the collection of Sessions
 func ListReferencedSessions(c common.Client, link string) ([]*Session, error) {
-	var result []*Session
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, sLink := range links.ItemLinks {
-		s, err := GetSession(c, sLink)
-		if err != nil {
-			collectionError.Failures[sLink] = err
-		} else {
-			result = append(result, s)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return common.ListReferenced[Session](c, link)
 }
@@ -52,6 +52,8 @@ type SimpleStorage struct {
 	// chassis shall be a reference to a resource of type Chassis that
 	// represent the physical container associated with this Simple Storage.
 	chassis string
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a SimpleStorage object from the raw JSON.
@@ -75,55 +77,28 @@ func (simplestorage *SimpleStorage) UnmarshalJSON(b []byte) error {
 	*simplestorage = SimpleStorage(t.temp)
 	simplestorage.chassis = string(t.Links.Chassis)
 
+	// Save the raw object data so the object can be marshaled back
+	simplestorage.rawData = b
+
 	return nil
 }
 
+// MarshalJSON marshals a SimpleStorage object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (simplestorage *SimpleStorage) MarshalJSON() ([]byte, error) {
+	type temp SimpleStorage
+	return common.MarshalObject(simplestorage.rawData, new(SimpleStorage), temp(*simplestorage))
+}
+
 // GetSimpleStorage will get a SimpleStorage instance from the service.
 func GetSimpleStorage(c common.Client, uri string) (*SimpleStorage, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var simplestorage SimpleStorage
-	err = json.NewDecoder(resp.Body).Decode(&simplestorage)
-	if err != nil {
-		return nil, err
-	}
-
-	simplestorage.SetClient(c)
-	return &simplestorage, nil
+	return common.GetObject[SimpleStorage](c, uri)
 }
 
 // ListReferencedSimpleStorages gets the collection of SimpleStorage from
 // a provided reference.
-func ListReferencedSimpleStorages(c common.Client, link string) ([]*SimpleStorage, error) { //nolint:dupl
-	var result []*SimpleStorage
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, simplestorageLink := range links.ItemLinks {
-		simplestorage, err := GetSimpleStorage(c, simplestorageLink)
-		if err != nil {
-			collectionError.Failures[simplestorageLink] = err
-		} else {
-			result = append(result, simplestorage)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedSimpleStorages(c common.Client, link string) ([]*SimpleStorage, error) {
+	return common.ListReferenced[SimpleStorage](c, link)
 }
 
 // Chassis gets the chassis containing this storage service.
@@ -21,6 +21,8 @@ type SmartStorage struct {
 	// ArrayController
 	arraycontroller string
 	Status          common.Status
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a Storage object from the raw JSON.
@@ -49,29 +51,27 @@ func (storage *SmartStorage) UnmarshalJSON(b []byte) error {
 		storage.arraycontroller = t.Members.ToStrings()[0]
 	}
 
+	// Save the raw object data so the object can be marshaled back
+	storage.rawData = b
+
 	return nil
 }
 
+// MarshalJSON marshals a SmartStorage object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (storage *SmartStorage) MarshalJSON() ([]byte, error) {
+	type temp SmartStorage
+	return common.MarshalObject(storage.rawData, new(SmartStorage), temp(*storage))
+}
+
 // GetSmartStorage will get a Storage instance from the service.
 func GetSmartStorage(c common.Client, uri string) (*SmartStorage, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var smartStorage SmartStorage
-	err = json.NewDecoder(resp.Body).Decode(&smartStorage)
-	if err != nil {
-		return nil, err
-	}
-
-	smartStorage.SetClient(c)
-	return &smartStorage, nil
+	return common.GetObject[SmartStorage](c, uri)
 }
 
-// ListReferencedSmartStorages gets the collection of Storage from a provided
-// reference.
+// ListReferencedSmartStorages gets the SmartStorage resource at link, or nil
+// if there is no link. A failure is returned in a *common.CollectionError, as
+// with the other ListReferenced helpers.
 func ListReferencedSmartStorages(c common.Client, link string) (*SmartStorage, error) { //nolint:dupl
 	var result *SmartStorage
 	if link == "" {
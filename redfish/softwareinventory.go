@@ -5,8 +5,6 @@
 package redfish
 
 import (
-	"encoding/json"
-
 	"github.com/trungng1992/gofish/common"
 )
 
@@ -63,48 +61,11 @@ type SoftwareInventory struct {
 
 // GetSoftwareInventory will get a SoftwareInventory instance from the service.
 func GetSoftwareInventory(c common.Client, uri string) (*SoftwareInventory, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var softwareinventory SoftwareInventory
-	err = json.NewDecoder(resp.Body).Decode(&softwareinventory)
-	if err != nil {
-		return nil, err
-	}
-
-	softwareinventory.SetClient(c)
-	return &softwareinventory, nil
+	return common.GetObject[SoftwareInventory](c, uri)
 }
 
 // ListReferencedSoftwareInventories gets the collection of SoftwareInventory from
 // a provided reference.
-func ListReferencedSoftwareInventories(c common.Client, link string) ([]*SoftwareInventory, error) { //nolint:dupl
-	var result []*SoftwareInventory
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, softwareinventoryLink := range links.ItemLinks {
-		softwareinventory, err := GetSoftwareInventory(c, softwareinventoryLink)
-		if err != nil {
-			collectionError.Failures[softwareinventoryLink] = err
-		} else {
-			result = append(result, softwareinventory)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedSoftwareInventories(c common.Client, link string) ([]*SoftwareInventory, error) {
+	return common.ListReferenced[SoftwareInventory](c, link)
 }
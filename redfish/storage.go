@@ -63,6 +63,8 @@ type Storage struct {
 	EnclosuresCount int
 	// setEncryptionKeyTarget is the URL to send SetEncryptionKey requests.
 	setEncryptionKeyTarget string
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a Storage object from the raw JSON.
@@ -99,76 +101,42 @@ func (storage *Storage) UnmarshalJSON(b []byte) error {
 	storage.volumes = string(t.Volumes)
 	storage.setEncryptionKeyTarget = t.Actions.SetEncryptionKey.Target
 
+	// Save the raw object data so the object can be marshaled back
+	storage.rawData = b
+
 	return nil
 }
 
+// MarshalJSON marshals a Storage object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (storage *Storage) MarshalJSON() ([]byte, error) {
+	type temp Storage
+	return common.MarshalObject(storage.rawData, new(Storage), temp(*storage))
+}
+
 // GetStorage will get a Storage instance from the service.
 func GetStorage(c common.Client, uri string) (*Storage, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var storage Storage
-	err = json.NewDecoder(resp.Body).Decode(&storage)
-	if err != nil {
-		return nil, err
-	}
-
-	storage.SetClient(c)
-	return &storage, nil
+	return common.GetObject[Storage](c, uri)
 }
 
 // ListReferencedStorages gets the collection of Storage from a provided
 // reference.
-func ListReferencedStorages(c common.Client, link string) ([]*Storage, error) { //nolint:dupl
-	var result []*Storage
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, storageLink := range links.ItemLinks {
-		storage, err := GetStorage(c, storageLink)
-		if err != nil {
-			collectionError.Failures[storageLink] = err
-		} else {
-			result = append(result, storage)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedStorages(c common.Client, link string) ([]*Storage, error) {
+	return common.ListReferenced[Storage](c, link)
 }
 
 // Enclosures gets the physical containers attached to this resource.
 func (storage *Storage) Enclosures() ([]*Chassis, error) {
 	var result []*Chassis
 
-	collectionError := common.NewCollectionError()
-	for _, chassisLink := range storage.enclosures {
-		chassis, err := GetChassis(storage.Client, chassisLink)
-		if err != nil {
-			collectionError.Failures[chassisLink] = err
-		} else {
-			result = append(result, chassis)
-		}
+	members, err := common.FetchCollectionMembers(storage.Client, storage.enclosures, func(link string) (interface{}, error) {
+		return GetChassis(storage.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*Chassis))
 	}
 
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return result, err
 }
 
 // Drives gets the drives attached to the storage controllers that this
@@ -176,21 +144,14 @@ func (storage *Storage) Enclosures() ([]*Chassis, error) {
 func (storage *Storage) Drives() ([]*Drive, error) {
 	var result []*Drive
 
-	collectionError := common.NewCollectionError()
-	for _, driveLink := range storage.drives {
-		drive, err := GetDrive(storage.Client, driveLink)
-		if err != nil {
-			collectionError.Failures[driveLink] = err
-		} else {
-			result = append(result, drive)
-		}
+	members, err := common.FetchCollectionMembers(storage.Client, storage.drives, func(link string) (interface{}, error) {
+		return GetDrive(storage.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*Drive))
 	}
 
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return result, err
 }
 
 // Volumes gets the volumes associated with this storage subsystem.
@@ -199,17 +160,15 @@ func (storage *Storage) Volumes() ([]*Volume, error) {
 }
 
 // SetEncryptionKey shall set the encryption key for the storage subsystem.
-func (storage *Storage) SetEncryptionKey(key string) error {
+// The request carries the storage ETag in If-Match unless common.SkipETagMatch
+// is given.
+func (storage *Storage) SetEncryptionKey(key string, opts ...common.UpdateOption) error {
 	type temp struct {
 		EncryptionKey string
 	}
 	t := temp{EncryptionKey: key}
 
-	resp, err := storage.Client.Post(storage.setEncryptionKeyTarget, t)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+	return storage.Post(storage.setEncryptionKeyTarget, t, opts...)
 }
 
 // GetOperationApplyTimeValues returns the OperationApplyTime values applicable for this storage
@@ -325,8 +284,15 @@ func (storagecontroller *StorageController) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a StorageController object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (storagecontroller *StorageController) MarshalJSON() ([]byte, error) {
+	type temp StorageController
+	return common.MarshalObject(storagecontroller.rawData, new(StorageController), temp(*storagecontroller))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (storagecontroller *StorageController) Update() error {
+func (storagecontroller *StorageController) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(StorageController)
@@ -342,55 +308,18 @@ func (storagecontroller *StorageController) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(storagecontroller).Elem()
 
-	return storagecontroller.Entity.Update(originalElement, currentElement, readWriteFields)
+	return storagecontroller.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetStorageController will get a Storage controller instance from the service.
 func GetStorageController(c common.Client, uri string) (*StorageController, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var storage StorageController
-	err = json.NewDecoder(resp.Body).Decode(&storage)
-	if err != nil {
-		return nil, err
-	}
-
-	storage.SetClient(c)
-	return &storage, nil
+	return common.GetObject[StorageController](c, uri)
 }
 
 // ListReferencedStorageControllers gets the collection of StorageControllers
 // from a provided reference.
-func ListReferencedStorageControllers(c common.Client, link string) ([]*StorageController, error) { //nolint:dupl
-	var result []*StorageController
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, storageLink := range links.ItemLinks {
-		storage, err := GetStorageController(c, storageLink)
-		if err != nil {
-			collectionError.Failures[storageLink] = err
-		} else {
-			result = append(result, storage)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedStorageControllers(c common.Client, link string) ([]*StorageController, error) {
+	return common.ListReferenced[StorageController](c, link)
 }
 
 // Assembly gets the storage controller's assembly.
@@ -405,19 +334,12 @@ func (storagecontroller *StorageController) Assembly() (*Assembly, error) {
 func (storagecontroller *StorageController) Endpoints() ([]*Endpoint, error) {
 	var result []*Endpoint
 
-	collectionError := common.NewCollectionError()
-	for _, endpointLink := range storagecontroller.endpoints {
-		endpoint, err := GetEndpoint(storagecontroller.Client, endpointLink)
-		if err != nil {
-			collectionError.Failures[endpointLink] = err
-		} else {
-			result = append(result, endpoint)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(storagecontroller.Client, storagecontroller.endpoints, func(link string) (interface{}, error) {
+		return GetEndpoint(storagecontroller.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*Endpoint))
 	}
 
-	return result, collectionError
+	return result, err
 }
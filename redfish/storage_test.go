@@ -6,6 +6,9 @@ package redfish
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -188,3 +191,25 @@ func TestStorageControllerUpdate(t *testing.T) {
 		t.Errorf("Unexpected AssetTag update payload: %s", calls[0].Payload)
 	}
 }
+
+// TestListReferencedSmartStorages tests a failure to get the SmartStorage is
+// returned in a CollectionError.
+func TestListReferencedSmartStorages(t *testing.T) {
+	link := "/redfish/v1/Systems/1/SmartStorage"
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {&http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(""))}},
+		},
+	}
+
+	smartStorage, err := ListReferencedSmartStorages(testClient, link)
+	var collectionError *common.CollectionError
+	if smartStorage != nil || !errors.As(err, &collectionError) || collectionError.Failures[link] == nil {
+		t.Errorf("Expected the failure in a CollectionError, got: %v %v", smartStorage, err)
+	}
+
+	smartStorage, err = ListReferencedSmartStorages(testClient, "")
+	if smartStorage != nil || err != nil {
+		t.Errorf("No link should mean no SmartStorage, got: %v %v", smartStorage, err)
+	}
+}
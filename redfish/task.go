@@ -86,9 +86,8 @@ type Task struct {
 	// returned normally. If this property is not specified when the Task is
 	// created, the default value shall be False.
 	HidePayload bool
-	// messages shall be an array of messages associated with the task.
-	// TODO: Add processing of messages.
-	messages []string
+	// Messages shall be an array of messages associated with the task.
+	Messages []common.Message
 	// Payload shall contain information detailing the HTTP and JSON payload
 	// information for executing this task. This object shall not be included in
 	// the response if the HidePayload property is set to True.
@@ -129,6 +128,8 @@ type Task struct {
 	// Status section of the Redfish specification and shall not be set until
 	// the task has completed.
 	TaskStatus common.Health
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a Task object from the raw JSON.
@@ -136,7 +137,6 @@ func (task *Task) UnmarshalJSON(b []byte) error {
 	type temp Task
 	var t struct {
 		temp
-		Messages common.Links
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -144,57 +144,28 @@ func (task *Task) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	// Extract the links to other entities for later
 	*task = Task(t.temp)
-	task.messages = t.Messages.ToStrings()
+
+	// Save the raw object data so the object can be marshaled back
+	task.rawData = b
 
 	return nil
 }
 
+// MarshalJSON marshals a Task object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (task *Task) MarshalJSON() ([]byte, error) {
+	type temp Task
+	return common.MarshalObject(task.rawData, new(Task), temp(*task))
+}
+
 // GetTask will get a Task instance from the service.
 func GetTask(c common.Client, uri string) (*Task, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var task Task
-	err = json.NewDecoder(resp.Body).Decode(&task)
-	if err != nil {
-		return nil, err
-	}
-
-	task.SetClient(c)
-	return &task, nil
+	return common.GetObject[Task](c, uri)
 }
 
 // ListReferencedTasks gets the collection of Task from
 // a provided reference.
-func ListReferencedTasks(c common.Client, link string) ([]*Task, error) { //nolint:dupl
-	var result []*Task
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, taskLink := range links.ItemLinks {
-		task, err := GetTask(c, taskLink)
-		if err != nil {
-			collectionError.Failures[taskLink] = err
-		} else {
-			result = append(result, task)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedTasks(c common.Client, link string) ([]*Task, error) {
+	return common.ListReferenced[Task](c, link)
 }
@@ -31,7 +31,7 @@ type TelemetryService struct {
 	metricDefinitions       string
 	netricReportDefinitions string
 	metricReports           string
-	// rawData holds the original serialized JSON so we can compare updates.
+	// rawData holds the original serialized JSON so the object can be marshaled back.
 	rawData []byte
 }
 
@@ -68,48 +68,21 @@ func (telemetryService *TelemetryService) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a TelemetryService object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (telemetryService *TelemetryService) MarshalJSON() ([]byte, error) {
+	type temp TelemetryService
+	return common.MarshalObject(telemetryService.rawData, new(TelemetryService), temp(*telemetryService))
+}
+
 // ListReferencedTelemetryService gets the collection of TelemetryServices
 func ListReferencedTelemetryService(c common.Client, link string) ([]*TelemetryService, error) {
-	var result []*TelemetryService
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, telemetryServiceLink := range links.ItemLinks {
-
-		telemetryService, err := GetTelemetryService(c, telemetryServiceLink)
-		if err != nil {
-			collectionError.Failures[telemetryServiceLink] = err
-		} else {
-			result = append(result, telemetryService)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return common.ListReferenced[TelemetryService](c, link)
 }
 
 // GetTelemetryService will get a TelemetryService instance from the Redfish service.
 func GetTelemetryService(c common.Client, uri string) (*TelemetryService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var telemetryService TelemetryService
-	err = json.NewDecoder(resp.Body).Decode(&telemetryService)
-	if err != nil {
-		return nil, err
-	}
-
-	telemetryService.SetClient(c)
-	return &telemetryService, nil
+	return common.GetObject[TelemetryService](c, uri)
 }
 
 func (telemetryService *TelemetryService) MetricReports() ([]*MetricReport, error) {
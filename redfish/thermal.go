@@ -109,6 +109,8 @@ type Fan struct {
 	// range but is not critical. The units shall be the same units as the
 	// related Reading property.
 	UpperThresholdNonCritical float32
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a Fan object from the raw JSON.
@@ -142,9 +144,20 @@ func (fan *Fan) UnmarshalJSON(b []byte) error {
 	if string(t.Units) != "" {
 		fan.ReadingUnits = t.Units
 	}
+
+	// Save the raw object data so the object can be marshaled back
+	fan.rawData = b
+
 	return nil
 }
 
+// MarshalJSON marshals a Fan object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (fan *Fan) MarshalJSON() ([]byte, error) {
+	type temp Fan
+	return common.MarshalObject(fan.rawData, new(Fan), temp(*fan))
+}
+
 // TODO: Decide if it's worth adding a Client object to this non-Entity object.
 // // Assembly gets the assembly object for this fan.
 // func (fan *Fan) Assembly() (*Assembly, error) {
@@ -314,6 +327,13 @@ func (thermal *Thermal) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a Thermal object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (thermal *Thermal) MarshalJSON() ([]byte, error) {
+	type temp Thermal
+	return common.MarshalObject(thermal.rawData, new(Thermal), temp(*thermal))
+}
+
 // // Update commits updates to this object's properties to the running system.
 // func (thermal *Thermal) Update() error {
 
@@ -335,47 +355,10 @@ func (thermal *Thermal) UnmarshalJSON(b []byte) error {
 
 // GetThermal will get a Thermal instance from the service.
 func GetThermal(c common.Client, uri string) (*Thermal, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var thermal Thermal
-	err = json.NewDecoder(resp.Body).Decode(&thermal)
-	if err != nil {
-		return nil, err
-	}
-
-	thermal.SetClient(c)
-	return &thermal, nil
+	return common.GetObject[Thermal](c, uri)
 }
 
 // ListReferencedThermals gets the collection of Thermal from a provided reference.
-func ListReferencedThermals(c common.Client, link string) ([]*Thermal, error) { //nolint:dupl
-	var result []*Thermal
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, thermalLink := range links.ItemLinks {
-		thermal, err := GetThermal(c, thermalLink)
-		if err != nil {
-			collectionError.Failures[thermalLink] = err
-		} else {
-			result = append(result, thermal)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedThermals(c common.Client, link string) ([]*Thermal, error) {
+	return common.ListReferenced[Thermal](c, link)
 }
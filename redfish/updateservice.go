@@ -5,7 +5,12 @@
 package redfish
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 
 	"github.com/trungng1992/gofish/common"
 )
@@ -42,7 +47,7 @@ type UpdateService struct {
 	// this object contains shall conform to the Redfish Specification
 	// described requirements.
 	Oem json.RawMessage
-	// rawData holds the original serialized JSON so we can compare updates.
+	// rawData holds the original serialized JSON so the object can be marshaled back.
 	rawData []byte
 }
 
@@ -81,20 +86,16 @@ func (updateService *UpdateService) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a UpdateService object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (updateService *UpdateService) MarshalJSON() ([]byte, error) {
+	type temp UpdateService
+	return common.MarshalObject(updateService.rawData, new(UpdateService), temp(*updateService))
+}
+
 // GetUpdateService will get a UpdateService instance from the service.
 func GetUpdateService(c common.Client, uri string) (*UpdateService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	var updateService UpdateService
-	err = json.NewDecoder(resp.Body).Decode(&updateService)
-	if err != nil {
-		return nil, err
-	}
-	updateService.SetClient(c)
-	return &updateService, nil
+	return common.GetObject[UpdateService](c, uri)
 }
 
 // SoftwareInventories gets the collection of software inventories of this update service
@@ -106,3 +107,102 @@ func (updateService *UpdateService) SoftwareInventories() ([]*SoftwareInventory,
 func (updateService *UpdateService) FirmwareInventories() ([]*SoftwareInventory, error) {
 	return ListReferencedSoftwareInventories(updateService.Client, updateService.FirmwareInventory)
 }
+
+// UpdateParameters are the parameters of an update pushed to the
+// MultipartHttpPushUri.
+type UpdateParameters struct {
+	// Targets are the URIs of the resources to update. The service chooses
+	// them if empty.
+	Targets []string `json:",omitempty"`
+	// OperationApplyTime is when to apply the update.
+	OperationApplyTime common.OperationApplyTime `json:"@Redfish.OperationApplyTime,omitempty"`
+	// ForceUpdate asks the service to apply the update even if the image is
+	// not newer than the installed one.
+	ForceUpdate bool `json:",omitempty"`
+	// Oem contains OEM specific parameters.
+	Oem interface{} `json:",omitempty"`
+}
+
+// SimpleUpdateParameters are the parameters of the SimpleUpdate action.
+type SimpleUpdateParameters struct {
+	// ImageURI is the URI of the image the service fetches.
+	ImageURI string
+	// TransferProtocol is the protocol used to fetch the image if ImageURI
+	// does not say.
+	TransferProtocol string `json:",omitempty"`
+	// Targets are the URIs of the resources to update. The service chooses
+	// them if empty.
+	Targets []string `json:",omitempty"`
+	// Username is the user name to fetch the image with.
+	Username string `json:",omitempty"`
+	// Password is the password to fetch the image with.
+	Password string `json:",omitempty"`
+}
+
+// errUploadNotSupported is returned when the client cannot stream uploads.
+var errUploadNotSupported = errors.New("client does not support streaming uploads")
+
+// SimpleUpdate asks the service to fetch an image and update the firmware
+// with it. The returned operation can be waited on for the outcome of the
+// update.
+// The request carries the update service ETag in If-Match unless
+// common.SkipETagMatch is given.
+func (updateService *UpdateService) SimpleUpdate(parameters *SimpleUpdateParameters, opts ...common.UpdateOption) (*AsyncOperation, error) {
+	if updateService.UpdateServiceTarget == "" {
+		return nil, fmt.Errorf("SimpleUpdate action is not supported by this system")
+	}
+	return newActionOperation(&updateService.Entity, updateService.UpdateServiceTarget, parameters, opts)
+}
+
+// PushUpdate pushes an image to the HttpPushUri of the service. The image is
+// streamed from r. progress, if not nil, is called as it is sent. The
+// returned operation can be waited on for the outcome of the update.
+func (updateService *UpdateService) PushUpdate(ctx context.Context, r io.Reader, progress common.ProgressFunc) (*AsyncOperation, error) {
+	if updateService.HTTPPushURI == "" {
+		return nil, fmt.Errorf("HttpPushUri updates are not supported by this system")
+	}
+
+	uploader, ok := updateService.Client.(common.Uploader)
+	if !ok {
+		return nil, errUploadNotSupported
+	}
+
+	resp, err := uploader.PostBinary(ctx, updateService.HTTPPushURI, r, "application/octet-stream", progress, nil)
+	if err != nil {
+		return nil, err
+	}
+	return NewAsyncOperation(updateService.Client, resp)
+}
+
+// MultipartPushUpdate pushes an image to the MultipartHttpPushUri of the
+// service. The image is streamed from r and sent with the file name, which
+// most services require. progress, if not nil, is called as it is sent. The
+// returned operation can be waited on for the outcome of the update.
+func (updateService *UpdateService) MultipartPushUpdate(ctx context.Context, parameters *UpdateParameters, fileName string, r io.Reader, progress common.ProgressFunc) (*AsyncOperation, error) {
+	if updateService.MultipartHTTPPushURI == "" {
+		return nil, fmt.Errorf("MultipartHttpPushUri updates are not supported by this system")
+	}
+
+	uploader, ok := updateService.Client.(common.Uploader)
+	if !ok {
+		return nil, errUploadNotSupported
+	}
+
+	if parameters == nil {
+		parameters = &UpdateParameters{}
+	}
+	payload, err := json.Marshal(parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := []common.MultipartPart{
+		{Name: "UpdateParameters", ContentType: "application/json", Reader: bytes.NewReader(payload)},
+		{Name: "UpdateFile", FileName: fileName, ContentType: "application/octet-stream", Reader: r},
+	}
+	resp, err := uploader.PostMultipartParts(ctx, updateService.MultipartHTTPPushURI, parts, progress, nil)
+	if err != nil {
+		return nil, err
+	}
+	return NewAsyncOperation(updateService.Client, resp)
+}
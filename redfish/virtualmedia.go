@@ -170,8 +170,15 @@ func (virtualmedia *VirtualMedia) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a VirtualMedia object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (virtualmedia *VirtualMedia) MarshalJSON() ([]byte, error) {
+	type temp VirtualMedia
+	return common.MarshalObject(virtualmedia.rawData, new(VirtualMedia), temp(*virtualmedia))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (virtualmedia *VirtualMedia) Update() error {
+func (virtualmedia *VirtualMedia) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(VirtualMedia)
@@ -193,24 +200,24 @@ func (virtualmedia *VirtualMedia) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(virtualmedia).Elem()
 
-	return virtualmedia.Entity.Update(originalElement, currentElement, readWriteFields)
+	return virtualmedia.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // EjectMedia sends a request to eject the media.
-func (virtualmedia *VirtualMedia) EjectMedia() error {
+// The request carries the virtual media ETag in If-Match unless
+// common.SkipETagMatch is given.
+func (virtualmedia *VirtualMedia) EjectMedia(opts ...common.UpdateOption) error {
 	if !virtualmedia.SupportsMediaEject {
 		return errors.New("redfish service does not support VirtualMedia.EjectMedia calls")
 	}
 
-	resp, err := virtualmedia.Client.Post(virtualmedia.ejectMediaTarget, struct{}{})
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+	return virtualmedia.Post(virtualmedia.ejectMediaTarget, struct{}{}, opts...)
 }
 
 // InsertMedia sends a request to insert virtual media.
-func (virtualmedia *VirtualMedia) InsertMedia(image string, inserted, writeProtected bool) error {
+// The request carries the virtual media ETag in If-Match unless
+// common.SkipETagMatch is given.
+func (virtualmedia *VirtualMedia) InsertMedia(image string, inserted, writeProtected bool, opts ...common.UpdateOption) error {
 	if !virtualmedia.SupportsMediaInsert {
 		return errors.New("redfish service does not support VirtualMedia.InsertMedia calls")
 	}
@@ -226,11 +233,7 @@ func (virtualmedia *VirtualMedia) InsertMedia(image string, inserted, writeProte
 		WriteProtected: writeProtected,
 	}
 
-	resp, err := virtualmedia.Client.Post(virtualmedia.insertMediaTarget, t)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+	return virtualmedia.Post(virtualmedia.insertMediaTarget, t, opts...)
 }
 
 // VirtualMediaConfig is an struct used to pass config data to build the HTTP body when inserting media
@@ -245,60 +248,22 @@ type VirtualMediaConfig struct {
 }
 
 // InsertMediaConfig sends a request to insert virtual media using the VirtualMediaConfig struct
-func (virtualmedia *VirtualMedia) InsertMediaConfig(config VirtualMediaConfig) error { //nolint
+// The request carries the virtual media ETag in If-Match unless
+// common.SkipETagMatch is given.
+func (virtualmedia *VirtualMedia) InsertMediaConfig(config VirtualMediaConfig, opts ...common.UpdateOption) error { //nolint
 	if !virtualmedia.SupportsMediaInsert {
 		return errors.New("redfish service does not support VirtualMedia.InsertMedia calls")
 	}
-	resp, err := virtualmedia.Client.Post(virtualmedia.insertMediaTarget, config)
-	if err == nil {
-		defer resp.Body.Close()
-	}
-	return err
+	return virtualmedia.Post(virtualmedia.insertMediaTarget, config, opts...)
 }
 
 // GetVirtualMedia will get a VirtualMedia instance from the service.
 func GetVirtualMedia(c common.Client, uri string) (*VirtualMedia, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var virtualmedia VirtualMedia
-	err = json.NewDecoder(resp.Body).Decode(&virtualmedia)
-	if err != nil {
-		return nil, err
-	}
-
-	virtualmedia.SetClient(c)
-	return &virtualmedia, nil
+	return common.GetObject[VirtualMedia](c, uri)
 }
 
 // ListReferencedVirtualMedias gets the collection of VirtualMedia from
 // a provided reference.
-func ListReferencedVirtualMedias(c common.Client, link string) ([]*VirtualMedia, error) { //nolint:dupl
-	var result []*VirtualMedia
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, virtualmediaLink := range links.ItemLinks {
-		virtualmedia, err := GetVirtualMedia(c, virtualmediaLink)
-		if err != nil {
-			collectionError.Failures[virtualmediaLink] = err
-		} else {
-			result = append(result, virtualmedia)
-		}
-	}
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedVirtualMedias(c common.Client, link string) ([]*VirtualMedia, error) {
+	return common.ListReferenced[VirtualMedia](c, link)
 }
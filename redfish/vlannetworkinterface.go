@@ -59,8 +59,15 @@ func (vlannetworkinterface *VLanNetworkInterface) UnmarshalJSON(b []byte) error
 	return nil
 }
 
+// MarshalJSON marshals a VLanNetworkInterface object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (vlannetworkinterface *VLanNetworkInterface) MarshalJSON() ([]byte, error) {
+	type temp VLanNetworkInterface
+	return common.MarshalObject(vlannetworkinterface.rawData, new(VLanNetworkInterface), temp(*vlannetworkinterface))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (vlannetworkinterface *VLanNetworkInterface) Update() error {
+func (vlannetworkinterface *VLanNetworkInterface) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(VLanNetworkInterface)
@@ -77,53 +84,16 @@ func (vlannetworkinterface *VLanNetworkInterface) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(vlannetworkinterface).Elem()
 
-	return vlannetworkinterface.Entity.Update(originalElement, currentElement, readWriteFields)
+	return vlannetworkinterface.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetVLanNetworkInterface will get a VLanNetworkInterface instance from the service.
 func GetVLanNetworkInterface(c common.Client, uri string) (*VLanNetworkInterface, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var vlannetworkinterface VLanNetworkInterface
-	err = json.NewDecoder(resp.Body).Decode(&vlannetworkinterface)
-	if err != nil {
-		return nil, err
-	}
-
-	vlannetworkinterface.SetClient(c)
-	return &vlannetworkinterface, nil
+	return common.GetObject[VLanNetworkInterface](c, uri)
 }
 
 // ListReferencedVLanNetworkInterfaces gets the collection of VLanNetworkInterface from
 // a provided reference.
-func ListReferencedVLanNetworkInterfaces(c common.Client, link string) ([]*VLanNetworkInterface, error) { //nolint:dupl
-	var result []*VLanNetworkInterface
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, vlannetworkinterfaceLink := range links.ItemLinks {
-		vlannetworkinterface, err := GetVLanNetworkInterface(c, vlannetworkinterfaceLink)
-		if err != nil {
-			collectionError.Failures[vlannetworkinterfaceLink] = err
-		} else {
-			result = append(result, vlannetworkinterface)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedVLanNetworkInterfaces(c common.Client, link string) ([]*VLanNetworkInterface, error) {
+	return common.ListReferenced[VLanNetworkInterface](c, link)
 }
@@ -190,6 +190,8 @@ type Volume struct {
 	DrivesCount int
 	// drives contains references to associated drives.
 	drives []string
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a Volume object from the raw JSON.
@@ -215,75 +217,41 @@ func (volume *Volume) UnmarshalJSON(b []byte) error {
 	volume.DrivesCount = t.DrivesCount
 	volume.drives = t.Links.Drives.ToStrings()
 
+	// Save the raw object data so the object can be marshaled back
+	volume.rawData = b
+
 	return nil
 }
 
+// MarshalJSON marshals a Volume object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (volume *Volume) MarshalJSON() ([]byte, error) {
+	type temp Volume
+	return common.MarshalObject(volume.rawData, new(Volume), temp(*volume))
+}
+
 // GetVolume will get a Volume instance from the service.
 func GetVolume(c common.Client, uri string) (*Volume, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var volume Volume
-	err = json.NewDecoder(resp.Body).Decode(&volume)
-	if err != nil {
-		return nil, err
-	}
-
-	volume.SetClient(c)
-	return &volume, nil
+	return common.GetObject[Volume](c, uri)
 }
 
 // ListReferencedVolumes gets the collection of Volumes from a provided reference.
-func ListReferencedVolumes(c common.Client, link string) ([]*Volume, error) { //nolint:dupl
-	var result []*Volume
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, volumeLink := range links.ItemLinks {
-		volume, err := GetVolume(c, volumeLink)
-		if err != nil {
-			collectionError.Failures[volumeLink] = err
-		} else {
-			result = append(result, volume)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedVolumes(c common.Client, link string) ([]*Volume, error) {
+	return common.ListReferenced[Volume](c, link)
 }
 
 // Drives references the Drives that this volume is associated with.
 func (volume *Volume) Drives() ([]*Drive, error) {
 	var result []*Drive
 
-	collectionError := common.NewCollectionError()
-	for _, driveLink := range volume.drives {
-		drive, err := GetDrive(volume.Client, driveLink)
-		if err != nil {
-			collectionError.Failures[driveLink] = err
-		} else {
-			result = append(result, drive)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(volume.Client, volume.drives, func(link string) (interface{}, error) {
+		return GetDrive(volume.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*Drive))
 	}
 
-	return result, collectionError
+	return result, err
 }
 
 // AllowedVolumesUpdateApplyTimes returns the set of allowed apply times to request when setting the volumes values
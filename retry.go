@@ -0,0 +1,180 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/trungng1992/gofish/common"
+)
+
+// RetryPolicy controls how the APIClient retries requests that failed
+// because the service was busy or the connection was dropped.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made for a request,
+	// including the first one. Values below 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry. Each following retry
+	// doubles the previous wait.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the computed wait between two attempts.
+	MaxBackoff time.Duration
+	// Jitter is the fraction (0 to 1) of the computed wait that is randomized
+	// to avoid many clients retrying in lockstep.
+	Jitter float64
+	// RetryStatusCodes are the HTTP status codes that trigger a retry. If
+	// empty, 429, 502 and 503 are retried.
+	RetryStatusCodes []int
+	// RetryNonIdempotent allows POST and PATCH requests to be retried. Only
+	// enable this when replaying the request cannot cause harm.
+	RetryNonIdempotent bool
+	// IgnoreRetryAfter disables honoring the Retry-After header returned by
+	// the service.
+	IgnoreRetryAfter bool
+	// MaxRetryAfter caps the wait asked for by a Retry-After header. If zero,
+	// DefaultMaxRetryAfter is used.
+	MaxRetryAfter time.Duration
+}
+
+// DefaultMaxRetryAfter is the longest wait a Retry-After header can ask for
+// when the policy does not set its own cap.
+const DefaultMaxRetryAfter = time.Minute
+
+// DefaultRetryPolicy returns a retry policy suitable for most BMCs: up to
+// four attempts, starting at half a second and capped at ten seconds.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Jitter:         0.2,
+	}
+}
+
+// defaultRetryStatusCodes are the status codes retried when the policy does
+// not list its own.
+var defaultRetryStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+}
+
+// canRetryMethod reports whether requests using the given method may be
+// replayed.
+func (p *RetryPolicy) canRetryMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost, http.MethodPatch:
+		return p.RetryNonIdempotent
+	}
+	return false
+}
+
+// isRetryStatus reports whether the status code should be retried.
+func (p *RetryPolicy) isRetryStatus(statusCode int) bool {
+	codes := p.RetryStatusCodes
+	if len(codes) == 0 {
+		codes = defaultRetryStatusCodes
+	}
+	for _, code := range codes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// isRetryError reports whether a transport error is worth retrying. Only
+// dropped connections are retried, context errors never are.
+func isRetryError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// backoff computes the wait before the given retry (starting at 1).
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	wait := p.InitialBackoff
+	for i := 1; i < retry && (p.MaxBackoff <= 0 || wait < p.MaxBackoff); i++ {
+		wait *= 2
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+
+	if p.Jitter > 0 && wait > 0 {
+		jitter := float64(wait) * p.Jitter
+		wait += time.Duration(jitter * (2*rand.Float64() - 1)) // nolint:gosec
+	}
+	if wait < 0 {
+		wait = 0
+	}
+
+	return wait
+}
+
+// nextAttempt decides whether the request that just ran as the given
+// attempt should be retried, and how long to wait before doing so.
+func (p *RetryPolicy) nextAttempt(attempt int, method string, resp *http.Response, err error) (time.Duration, bool) {
+	if p == nil || attempt >= p.MaxAttempts || !p.canRetryMethod(method) {
+		return 0, false
+	}
+
+	if err != nil {
+		if !isRetryError(err) {
+			return 0, false
+		}
+		return p.backoff(attempt), true
+	}
+
+	if resp == nil || !p.isRetryStatus(resp.StatusCode) {
+		return 0, false
+	}
+
+	wait := p.backoff(attempt)
+	if !p.IgnoreRetryAfter {
+		if retryAfter, ok := common.ParseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			wait = retryAfter
+			maxWait := p.MaxRetryAfter
+			if maxWait <= 0 {
+				maxWait = DefaultMaxRetryAfter
+			}
+			if wait > maxWait {
+				wait = maxWait
+			}
+		}
+	}
+
+	return wait, true
+}
+
+// sleepContext waits for the given duration unless the context is done first.
+func sleepContext(ctx context.Context, wait time.Duration) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,208 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/trungng1992/gofish/common"
+)
+
+const retryServiceRoot = `{
+	"@odata.id": "/redfish/v1/",
+	"Id": "RootService",
+	"Name": "Root Service",
+	"RedfishVersion": "1.6.0"
+}`
+
+// newRetryTestClient creates a client against the test server without
+// fetching the service root.
+func newRetryTestClient(ts *httptest.Server, policy *RetryPolicy) *APIClient {
+	return &APIClient{
+		ctx:         context.Background(),
+		endpoint:    ts.URL,
+		HTTPClient:  ts.Client(),
+		retryPolicy: policy,
+	}
+}
+
+func fastRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+	}
+}
+
+// TestRetryOnServiceUnavailable tests a GET is retried until it succeeds.
+func TestRetryOnServiceUnavailable(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(retryServiceRoot)) // nolint
+	}))
+	defer ts.Close()
+
+	_, err := Connect(ClientConfig{Endpoint: ts.URL, HTTPClient: ts.Client(), RetryPolicy: fastRetryPolicy()})
+	if err != nil {
+		t.Errorf("Connect should have succeeded after retries: %v", err)
+	}
+
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+}
+
+// TestRetryExhausted tests the final error reports the number of attempts.
+func TestRetryExhausted(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte("busy")) // nolint
+	}))
+	defer ts.Close()
+
+	client := newRetryTestClient(ts, fastRetryPolicy())
+	_, err := client.Get("/redfish/v1/") // nolint:bodyclose
+	if err == nil {
+		t.Fatal("Request should have failed")
+	}
+
+	var rfErr *common.Error
+	if !errors.As(err, &rfErr) {
+		t.Fatalf("Expected common.Error, got: %v", err)
+	}
+	if rfErr.Attempts != 3 || calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d (server saw %d)", rfErr.Attempts, calls)
+	}
+	if err.Error() != "429: busy (after 3 attempts)" {
+		t.Errorf("Unexpected error message: %s", err.Error())
+	}
+}
+
+// TestRetryNonIdempotent tests POST is only retried when allowed.
+func TestRetryNonIdempotent(t *testing.T) {
+	var calls int32
+	var bodies []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client := newRetryTestClient(ts, fastRetryPolicy())
+	_, err := client.Post("/redfish/v1/Actions", map[string]string{"Key": "Value"}) // nolint:bodyclose
+	if err == nil || calls != 1 {
+		t.Errorf("POST should not be retried by default, got %d calls", calls)
+	}
+
+	calls = 0
+	bodies = nil
+	policy := fastRetryPolicy()
+	policy.RetryNonIdempotent = true
+	client = newRetryTestClient(ts, policy)
+	resp, err := client.Post("/redfish/v1/Actions", map[string]string{"Key": "Value"})
+	if err != nil {
+		t.Fatalf("POST should have been retried: %v", err)
+	}
+	resp.Body.Close()
+
+	if len(bodies) != 2 || bodies[0] != bodies[1] || !strings.Contains(bodies[1], "Value") {
+		t.Errorf("Payload should be rewound between attempts: %#v", bodies)
+	}
+}
+
+// TestRetryAfterHeader tests the Retry-After header is honored.
+func TestRetryAfterHeader(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(retryServiceRoot)) // nolint
+	}))
+	defer ts.Close()
+
+	client := newRetryTestClient(ts, fastRetryPolicy())
+	start := time.Now()
+	resp, err := client.Get("/redfish/v1/")
+	if err != nil {
+		t.Fatalf("Request should have succeeded: %v", err)
+	}
+	resp.Body.Close()
+
+	if time.Since(start) < time.Second {
+		t.Errorf("Retry-After should have delayed the retry, took %s", time.Since(start))
+	}
+}
+
+// TestRetryAfterCap tests the wait asked for by a Retry-After header is capped.
+func TestRetryAfterCap(t *testing.T) {
+	tests := []struct {
+		name          string
+		retryAfter    string
+		maxRetryAfter time.Duration
+		expected      time.Duration
+	}{
+		{"below the default cap", "30", 0, 30 * time.Second},
+		{"above the default cap", "3600", 0, DefaultMaxRetryAfter},
+		{"below the cap", "1", 2 * time.Second, time.Second},
+		{"above the cap", "30", 2 * time.Second, 2 * time.Second},
+		{"date above the cap", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat), 2 * time.Second, 2 * time.Second},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy := fastRetryPolicy()
+			policy.MaxRetryAfter = test.maxRetryAfter
+			resp := &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Header:     http.Header{"Retry-After": {test.retryAfter}},
+			}
+			wait, retry := policy.nextAttempt(1, http.MethodGet, resp, nil)
+			if !retry || wait != test.expected {
+				t.Errorf("Expected a retry after %s, got: %s %t", test.expected, wait, retry)
+			}
+		})
+	}
+}
+
+// TestRetryContextCancel tests waiting for a retry stops when the context ends.
+func TestRetryContextCancel(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	client := newRetryTestClient(ts, fastRetryPolicy())
+	client.ctx = ctx
+	_, err := client.Get("/redfish/v1/") // nolint:bodyclose
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context deadline error, got: %v", err)
+	}
+}
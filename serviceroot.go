@@ -37,6 +37,26 @@ type Expand struct {
 	NoLinks bool
 }
 
+// CollectionQuery returns the $expand value used to inline the members of a
+// collection, or an empty string if the service does not support it.
+func (expand *Expand) CollectionQuery() string {
+	var query string
+	switch {
+	case expand.NoLinks:
+		query = "."
+	case expand.ExpandAll:
+		query = "*"
+	default:
+		return ""
+	}
+
+	if expand.Levels {
+		query += "($levels=1)"
+	}
+
+	return query
+}
+
 // ProtocolFeaturesSupported contains information about protocol features
 // supported by the service.
 type ProtocolFeaturesSupported struct {
@@ -57,6 +77,20 @@ type ProtocolFeaturesSupported struct {
 	// SelectQuery shall be a boolean indicating whether this service supports
 	// the use of the $select query parameter as described by the specification.
 	SelectQuery bool
+	// TopSkipQuery shall be a boolean indicating whether this service supports
+	// the use of the $top and $skip query parameters as described by the
+	// specification.
+	TopSkipQuery bool
+}
+
+// QueryFeatures returns the query parameters supported by the service.
+func (features *ProtocolFeaturesSupported) QueryFeatures() common.QueryFeatures {
+	return common.QueryFeatures{
+		Select:  features.SelectQuery,
+		Filter:  features.FilterQuery,
+		TopSkip: features.TopSkipQuery,
+		Only:    features.OnlyMemberQuery,
+	}
 }
 
 // Service represents the root Redfish service. All values for resources
@@ -150,6 +184,8 @@ type Service struct {
 	Vendor string
 	// Sessions shall contain the link to a collection of Sessions.
 	sessions string
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a Service object from the raw JSON.
@@ -209,9 +245,19 @@ func (serviceroot *Service) UnmarshalJSON(b []byte) error {
 	serviceroot.updateService = string(t.UpdateService)
 	serviceroot.Oem = t.Oem
 
+	// Save the raw object data so the object can be marshaled back
+	serviceroot.rawData = b
+
 	return nil
 }
 
+// MarshalJSON marshals a Service object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (serviceroot *Service) MarshalJSON() ([]byte, error) {
+	type temp Service
+	return common.MarshalObject(serviceroot.rawData, new(Service), temp(*serviceroot))
+}
+
 // ServiceRoot will get a Service instance from the service.
 func ServiceRoot(c common.Client) (*Service, error) {
 	resp, err := c.Get(common.DefaultServiceRoot)
@@ -323,6 +369,14 @@ func (serviceroot *Service) MessageByLanguage(messageID, language string) (*redf
 	return redfish.GetMessageFromMessageRegistryByLanguage(serviceroot.Client, serviceroot.registries, messageID, language)
 }
 
+// MessageResolver returns a resolver of the messages of the service, to
+// format the errors it returns with common.ResolveError.
+// language is the RFC5646-conformant language code for the message registry, for example: "en",
+// or empty to use the registries in every language.
+func (serviceroot *Service) MessageResolver(language string) *redfish.MessageResolver {
+	return redfish.NewMessageResolver(serviceroot.Client, serviceroot.registries, language)
+}
+
 // Systems get the system instances from the service
 func (serviceroot *Service) Systems() ([]*redfish.ComputerSystem, error) {
 	return redfish.ListReferencedComputerSystems(serviceroot.Client, serviceroot.systems)
@@ -237,3 +237,24 @@ func TestServiceRootOEM(t *testing.T) {
 		t.Errorf("Expect\n%s\n,Obtain\n%s", oemExp, oemObt)
 	}
 }
+
+// TestExpandCollectionQuery tests the $expand value built from the service
+// capabilities.
+func TestExpandCollectionQuery(t *testing.T) {
+	tests := []struct {
+		expand   Expand
+		expected string
+	}{
+		{Expand{}, ""},
+		{Expand{NoLinks: true}, "."},
+		{Expand{NoLinks: true, ExpandAll: true, Levels: true, MaxLevels: 6}, ".($levels=1)"},
+		{Expand{ExpandAll: true, Levels: true}, "*($levels=1)"},
+		{Expand{Links: true, Levels: true}, ""},
+	}
+
+	for _, test := range tests {
+		if query := test.expand.CollectionQuery(); query != test.expected {
+			t.Errorf("Expected '%s' for %#v, got '%s'", test.expected, test.expand, query)
+		}
+	}
+}
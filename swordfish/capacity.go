@@ -84,6 +84,8 @@ type CapacitySource struct {
 	// ProvidingVolumes if present, the value shall be a reference to a
 	// contributing volume or volumes.
 	providingVolumes string
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a CapacitySource object from the raw JSON.
@@ -114,49 +116,28 @@ func (capacitysource *CapacitySource) UnmarshalJSON(b []byte) error {
 	capacitysource.providingPools = string(t.ProvidingPools)
 	capacitysource.providingVolumes = string(t.ProvidingVolumes)
 
+	// Save the raw object data so the object can be marshaled back
+	capacitysource.rawData = b
+
 	return nil
 }
 
+// MarshalJSON marshals a CapacitySource object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (capacitysource *CapacitySource) MarshalJSON() ([]byte, error) {
+	type temp CapacitySource
+	return common.MarshalObject(capacitysource.rawData, new(CapacitySource), temp(*capacitysource))
+}
+
 // GetCapacitySource will get a CapacitySource instance from the service.
 func GetCapacitySource(c common.Client, uri string) (*CapacitySource, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var capacitysource CapacitySource
-	err = json.NewDecoder(resp.Body).Decode(&capacitysource)
-	if err != nil {
-		return nil, err
-	}
-
-	capacitysource.SetClient(c)
-	return &capacitysource, nil
+	return common.GetObject[CapacitySource](c, uri)
 }
 
 // ListReferencedCapacitySources gets the collection of CapacitySources from
 // a provided reference.
 func ListReferencedCapacitySources(c common.Client, link string) ([]*CapacitySource, error) {
-	var result []*CapacitySource
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	for _, capSourceLink := range links.ItemLinks {
-		capSource, err := GetCapacitySource(c, capSourceLink)
-		if err != nil {
-			return result, err
-		}
-		result = append(result, capSource)
-	}
-
-	return result, nil
+	return common.ListReferenced[CapacitySource](c, link)
 }
 
 // ProvidedClassOfService gets the ClassOfService from the ProvidingDrives,
@@ -55,6 +55,8 @@ type ClassOfService struct {
 	IOPerformanceLinesOfServiceCount int `json:"IOPerformanceLinesOfService@odata.count"`
 	// Identifier shall be unique within the managed ecosystem.
 	Identifier common.Identifier
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a ClassOfService object from the raw JSON.
@@ -82,55 +84,28 @@ func (classofservice *ClassOfService) UnmarshalJSON(b []byte) error {
 	classofservice.ioConnectivityLinesOfService = t.IOConnectivityLinesOfService.ToStrings()
 	classofservice.ioPerformanceLinesOfService = t.IOPerformanceLinesOfService.ToStrings()
 
+	// Save the raw object data so the object can be marshaled back
+	classofservice.rawData = b
+
 	return nil
 }
 
+// MarshalJSON marshals a ClassOfService object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (classofservice *ClassOfService) MarshalJSON() ([]byte, error) {
+	type temp ClassOfService
+	return common.MarshalObject(classofservice.rawData, new(ClassOfService), temp(*classofservice))
+}
+
 // GetClassOfService will get a ClassOfService instance from the service.
 func GetClassOfService(c common.Client, uri string) (*ClassOfService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var classofservice ClassOfService
-	err = json.NewDecoder(resp.Body).Decode(&classofservice)
-	if err != nil {
-		return nil, err
-	}
-
-	classofservice.SetClient(c)
-	return &classofservice, nil
+	return common.GetObject[ClassOfService](c, uri)
 }
 
 // ListReferencedClassOfServices gets the collection of ClassOfService from
 // a provided reference.
-func ListReferencedClassOfServices(c common.Client, link string) ([]*ClassOfService, error) { //nolint:dupl
-	var result []*ClassOfService
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, classofserviceLink := range links.ItemLinks {
-		classofservice, err := GetClassOfService(c, classofserviceLink)
-		if err != nil {
-			collectionError.Failures[classofserviceLink] = err
-		} else {
-			result = append(result, classofservice)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedClassOfServices(c common.Client, link string) ([]*ClassOfService, error) {
+	return common.ListReferenced[ClassOfService](c, link)
 }
 
 // DataProtectionLinesOfServices gets the DataProtectionLinesOfService that are
@@ -138,21 +113,14 @@ func ListReferencedClassOfServices(c common.Client, link string) ([]*ClassOfServ
 func (classofservice *ClassOfService) DataProtectionLinesOfServices() ([]*DataProtectionLineOfService, error) {
 	var result []*DataProtectionLineOfService
 
-	collectionError := common.NewCollectionError()
-	for _, dpLosLink := range classofservice.dataProtectionLinesOfService {
-		dpLos, err := GetDataProtectionLineOfService(classofservice.Client, dpLosLink)
-		if err != nil {
-			collectionError.Failures[dpLosLink] = err
-		} else {
-			result = append(result, dpLos)
-		}
+	members, err := common.FetchCollectionMembers(classofservice.Client, classofservice.dataProtectionLinesOfService, func(link string) (interface{}, error) {
+		return GetDataProtectionLineOfService(classofservice.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*DataProtectionLineOfService))
 	}
 
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return result, err
 }
 
 // DataSecurityLinesOfServices gets the DataSecurityLinesOfService that are
@@ -160,21 +128,14 @@ func (classofservice *ClassOfService) DataProtectionLinesOfServices() ([]*DataPr
 func (classofservice *ClassOfService) DataSecurityLinesOfServices() ([]*DataSecurityLineOfService, error) {
 	var result []*DataSecurityLineOfService
 
-	collectionError := common.NewCollectionError()
-	for _, dsLosLink := range classofservice.dataSecurityLinesOfService {
-		dsLos, err := GetDataSecurityLineOfService(classofservice.Client, dsLosLink)
-		if err != nil {
-			collectionError.Failures[dsLosLink] = err
-		} else {
-			result = append(result, dsLos)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(classofservice.Client, classofservice.dataSecurityLinesOfService, func(link string) (interface{}, error) {
+		return GetDataSecurityLineOfService(classofservice.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*DataSecurityLineOfService))
 	}
 
-	return result, collectionError
+	return result, err
 }
 
 // DataStorageLinesOfServices gets the DataStorageLinesOfService that are
@@ -182,21 +143,14 @@ func (classofservice *ClassOfService) DataSecurityLinesOfServices() ([]*DataSecu
 func (classofservice *ClassOfService) DataStorageLinesOfServices() ([]*DataStorageLineOfService, error) {
 	var result []*DataStorageLineOfService
 
-	collectionError := common.NewCollectionError()
-	for _, dsLosLink := range classofservice.dataStorageLinesOfService {
-		dsLos, err := GetDataStorageLineOfService(classofservice.Client, dsLosLink)
-		if err != nil {
-			collectionError.Failures[dsLosLink] = err
-		} else {
-			result = append(result, dsLos)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(classofservice.Client, classofservice.dataStorageLinesOfService, func(link string) (interface{}, error) {
+		return GetDataStorageLineOfService(classofservice.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*DataStorageLineOfService))
 	}
 
-	return result, collectionError
+	return result, err
 }
 
 // IOConnectivityLinesOfServices gets the IOConnectivityLinesOfService that are
@@ -204,21 +158,14 @@ func (classofservice *ClassOfService) DataStorageLinesOfServices() ([]*DataStora
 func (classofservice *ClassOfService) IOConnectivityLinesOfServices() ([]*IOConnectivityLineOfService, error) {
 	var result []*IOConnectivityLineOfService
 
-	collectionError := common.NewCollectionError()
-	for _, ioLosLink := range classofservice.dataSecurityLinesOfService {
-		ioLos, err := GetIOConnectivityLineOfService(classofservice.Client, ioLosLink)
-		if err != nil {
-			collectionError.Failures[ioLosLink] = err
-		} else {
-			result = append(result, ioLos)
-		}
+	members, err := common.FetchCollectionMembers(classofservice.Client, classofservice.dataSecurityLinesOfService, func(link string) (interface{}, error) {
+		return GetIOConnectivityLineOfService(classofservice.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*IOConnectivityLineOfService))
 	}
 
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return result, err
 }
 
 // IOPerformanceLinesOfServices gets the IOPerformanceLinesOfService that are
@@ -226,19 +173,12 @@ func (classofservice *ClassOfService) IOConnectivityLinesOfServices() ([]*IOConn
 func (classofservice *ClassOfService) IOPerformanceLinesOfServices() ([]*IOPerformanceLineOfService, error) {
 	var result []*IOPerformanceLineOfService
 
-	collectionError := common.NewCollectionError()
-	for _, ioLosLink := range classofservice.dataSecurityLinesOfService {
-		ioLos, err := GetIOPerformanceLineOfService(classofservice.Client, ioLosLink)
-		if err != nil {
-			collectionError.Failures[ioLosLink] = err
-		} else {
-			result = append(result, ioLos)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(classofservice.Client, classofservice.dataSecurityLinesOfService, func(link string) (interface{}, error) {
+		return GetIOPerformanceLineOfService(classofservice.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*IOPerformanceLineOfService))
 	}
 
-	return result, collectionError
+	return result, err
 }
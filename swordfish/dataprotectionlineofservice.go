@@ -5,8 +5,6 @@
 package swordfish
 
 import (
-	"encoding/json"
-
 	"github.com/trungng1992/gofish/common"
 )
 
@@ -61,50 +59,13 @@ type DataProtectionLineOfService struct {
 
 // GetDataProtectionLineOfService will get a DataProtectionLineOfService instance from the service.
 func GetDataProtectionLineOfService(c common.Client, uri string) (*DataProtectionLineOfService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var dataprotectionlineofservice DataProtectionLineOfService
-	err = json.NewDecoder(resp.Body).Decode(&dataprotectionlineofservice)
-	if err != nil {
-		return nil, err
-	}
-
-	dataprotectionlineofservice.SetClient(c)
-	return &dataprotectionlineofservice, nil
+	return common.GetObject[DataProtectionLineOfService](c, uri)
 }
 
 // ListReferencedDataProtectionLineOfServices gets the collection of DataProtectionLineOfService from
 // a provided reference.
-func ListReferencedDataProtectionLineOfServices(c common.Client, link string) ([]*DataProtectionLineOfService, error) { //nolint:dupl
-	var result []*DataProtectionLineOfService
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, dataprotectionlineofserviceLink := range links.ItemLinks {
-		dataprotectionlineofservice, err := GetDataProtectionLineOfService(c, dataprotectionlineofserviceLink)
-		if err != nil {
-			collectionError.Failures[dataprotectionlineofserviceLink] = err
-		} else {
-			result = append(result, dataprotectionlineofservice)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedDataProtectionLineOfServices(c common.Client, link string) ([]*DataProtectionLineOfService, error) {
+	return common.ListReferenced[DataProtectionLineOfService](c, link)
 }
 
 // ReplicaRequest is a request for a replica.
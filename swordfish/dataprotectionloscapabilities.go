@@ -149,8 +149,15 @@ func (dataprotectionloscapabilities *DataProtectionLoSCapabilities) UnmarshalJSO
 	return nil
 }
 
+// MarshalJSON marshals a DataProtectionLoSCapabilities object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (dataprotectionloscapabilities *DataProtectionLoSCapabilities) MarshalJSON() ([]byte, error) {
+	type temp DataProtectionLoSCapabilities
+	return common.MarshalObject(dataprotectionloscapabilities.rawData, new(DataProtectionLoSCapabilities), temp(*dataprotectionloscapabilities))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (dataprotectionloscapabilities *DataProtectionLoSCapabilities) Update() error {
+func (dataprotectionloscapabilities *DataProtectionLoSCapabilities) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(DataProtectionLoSCapabilities)
@@ -172,95 +179,44 @@ func (dataprotectionloscapabilities *DataProtectionLoSCapabilities) Update() err
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(dataprotectionloscapabilities).Elem()
 
-	return dataprotectionloscapabilities.Entity.Update(originalElement, currentElement, readWriteFields)
+	return dataprotectionloscapabilities.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetDataProtectionLoSCapabilities will get a DataProtectionLoSCapabilities instance from the service.
 func GetDataProtectionLoSCapabilities(c common.Client, uri string) (*DataProtectionLoSCapabilities, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var dataprotectionloscapabilities DataProtectionLoSCapabilities
-	err = json.NewDecoder(resp.Body).Decode(&dataprotectionloscapabilities)
-	if err != nil {
-		return nil, err
-	}
-
-	dataprotectionloscapabilities.SetClient(c)
-	return &dataprotectionloscapabilities, nil
+	return common.GetObject[DataProtectionLoSCapabilities](c, uri)
 }
 
 // ListReferencedDataProtectionLoSCapabilities gets the collection of DataProtectionLoSCapabilities from
 // a provided reference.
-func ListReferencedDataProtectionLoSCapabilities(c common.Client, link string) ([]*DataProtectionLoSCapabilities, error) { //nolint:dupl
-	var result []*DataProtectionLoSCapabilities
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, dataprotectionloscapabilitiesLink := range links.ItemLinks {
-		dataprotectionloscapabilities, err := GetDataProtectionLoSCapabilities(c, dataprotectionloscapabilitiesLink)
-		if err != nil {
-			collectionError.Failures[dataprotectionloscapabilitiesLink] = err
-		} else {
-			result = append(result, dataprotectionloscapabilities)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedDataProtectionLoSCapabilities(c common.Client, link string) ([]*DataProtectionLoSCapabilities, error) {
+	return common.ListReferenced[DataProtectionLoSCapabilities](c, link)
 }
 
 // SupportedReplicaOptions gets the support replica ClassesOfService.
 func (dataprotectionloscapabilities *DataProtectionLoSCapabilities) SupportedReplicaOptions() ([]*ClassOfService, error) {
 	var result []*ClassOfService
 
-	collectionError := common.NewCollectionError()
-	for _, link := range dataprotectionloscapabilities.supportedReplicaOptions {
-		classOfService, err := GetClassOfService(dataprotectionloscapabilities.Client, link)
-		if err != nil {
-			collectionError.Failures[link] = err
-		} else {
-			result = append(result, classOfService)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(dataprotectionloscapabilities.Client, dataprotectionloscapabilities.supportedReplicaOptions, func(link string) (interface{}, error) {
+		return GetClassOfService(dataprotectionloscapabilities.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*ClassOfService))
 	}
 
-	return result, collectionError
+	return result, err
 }
 
 // SupportedLinesOfService gets the supported lines of service.
 func (dataprotectionloscapabilities *DataProtectionLoSCapabilities) SupportedLinesOfService() ([]*DataProtectionLineOfService, error) {
 	var result []*DataProtectionLineOfService
 
-	collectionError := common.NewCollectionError()
-	for _, link := range dataprotectionloscapabilities.supportedLinesOfService {
-		lineOfService, err := GetDataProtectionLineOfService(dataprotectionloscapabilities.Client, link)
-		if err != nil {
-			collectionError.Failures[link] = err
-		} else {
-			result = append(result, lineOfService)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(dataprotectionloscapabilities.Client, dataprotectionloscapabilities.supportedLinesOfService, func(link string) (interface{}, error) {
+		return GetDataProtectionLineOfService(dataprotectionloscapabilities.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*DataProtectionLineOfService))
 	}
 
-	return result, collectionError
+	return result, err
 }
@@ -5,8 +5,6 @@
 package swordfish
 
 import (
-	"encoding/json"
-
 	"github.com/trungng1992/gofish/common"
 )
 
@@ -47,42 +45,11 @@ type DataSecurityLineOfService struct {
 
 // GetDataSecurityLineOfService will get a DataSecurityLineOfService instance from the service.
 func GetDataSecurityLineOfService(c common.Client, uri string) (*DataSecurityLineOfService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var datasecuritylineofservice DataSecurityLineOfService
-	err = json.NewDecoder(resp.Body).Decode(&datasecuritylineofservice)
-	if err != nil {
-		return nil, err
-	}
-
-	datasecuritylineofservice.SetClient(c)
-	return &datasecuritylineofservice, nil
+	return common.GetObject[DataSecurityLineOfService](c, uri)
 }
 
 // ListReferencedDataSecurityLineOfServices gets the collection of DataSecurityLineOfService from
 // a provided reference.
 func ListReferencedDataSecurityLineOfServices(c common.Client, link string) ([]*DataSecurityLineOfService, error) {
-	var result []*DataSecurityLineOfService
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	for _, datasecuritylineofserviceLink := range links.ItemLinks {
-		datasecuritylineofservice, err := GetDataSecurityLineOfService(c, datasecuritylineofserviceLink)
-		if err != nil {
-			return result, err
-		}
-		result = append(result, datasecuritylineofservice)
-	}
-
-	return result, nil
+	return common.ListReferenced[DataSecurityLineOfService](c, link)
 }
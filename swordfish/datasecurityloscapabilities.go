@@ -5,8 +5,6 @@
 package swordfish
 
 import (
-	"encoding/json"
-
 	"github.com/trungng1992/gofish/common"
 )
 
@@ -201,48 +199,11 @@ type DataSecurityLoSCapabilities struct {
 
 // GetDataSecurityLoSCapabilities will get a DataSecurityLoSCapabilities instance from the service.
 func GetDataSecurityLoSCapabilities(c common.Client, uri string) (*DataSecurityLoSCapabilities, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var datasecurityloscapabilities DataSecurityLoSCapabilities
-	err = json.NewDecoder(resp.Body).Decode(&datasecurityloscapabilities)
-	if err != nil {
-		return nil, err
-	}
-
-	datasecurityloscapabilities.SetClient(c)
-	return &datasecurityloscapabilities, nil
+	return common.GetObject[DataSecurityLoSCapabilities](c, uri)
 }
 
 // ListReferencedDataSecurityLoSCapabilities gets the collection of DataSecurityLoSCapabilities from
 // a provided reference.
-func ListReferencedDataSecurityLoSCapabilities(c common.Client, link string) ([]*DataSecurityLoSCapabilities, error) { //nolint:dupl
-	var result []*DataSecurityLoSCapabilities
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, datasecurityloscapabilitiesLink := range links.ItemLinks {
-		datasecurityloscapabilities, err := GetDataSecurityLoSCapabilities(c, datasecurityloscapabilitiesLink)
-		if err != nil {
-			collectionError.Failures[datasecurityloscapabilitiesLink] = err
-		} else {
-			result = append(result, datasecurityloscapabilities)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedDataSecurityLoSCapabilities(c common.Client, link string) ([]*DataSecurityLoSCapabilities, error) {
+	return common.ListReferenced[DataSecurityLoSCapabilities](c, link)
 }
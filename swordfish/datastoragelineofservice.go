@@ -47,6 +47,8 @@ type DataStorageLineOfService struct {
 	// 'offline'. The expectation is that the services required to implement
 	// this capability are part of the advertising system.
 	RecoveryTimeObjectives RecoveryAccessScope
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a DataStorageLineOfService object from the raw JSON.
@@ -65,53 +67,26 @@ func (datastoragelineofservice *DataStorageLineOfService) UnmarshalJSON(b []byte
 
 	// Extract the links to other entities for later
 
+	// Save the raw object data so the object can be marshaled back
+	datastoragelineofservice.rawData = b
+
 	return nil
 }
 
+// MarshalJSON marshals a DataStorageLineOfService object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (datastoragelineofservice *DataStorageLineOfService) MarshalJSON() ([]byte, error) {
+	type temp DataStorageLineOfService
+	return common.MarshalObject(datastoragelineofservice.rawData, new(DataStorageLineOfService), temp(*datastoragelineofservice))
+}
+
 // GetDataStorageLineOfService will get a DataStorageLineOfService instance from the service.
 func GetDataStorageLineOfService(c common.Client, uri string) (*DataStorageLineOfService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var datastoragelineofservice DataStorageLineOfService
-	err = json.NewDecoder(resp.Body).Decode(&datastoragelineofservice)
-	if err != nil {
-		return nil, err
-	}
-
-	datastoragelineofservice.SetClient(c)
-	return &datastoragelineofservice, nil
+	return common.GetObject[DataStorageLineOfService](c, uri)
 }
 
 // ListReferencedDataStorageLineOfServices gets the collection of DataStorageLineOfService from
 // a provided reference.
-func ListReferencedDataStorageLineOfServices(c common.Client, link string) ([]*DataStorageLineOfService, error) { //nolint:dupl
-	var result []*DataStorageLineOfService
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, datastoragelineofserviceLink := range links.ItemLinks {
-		datastoragelineofservice, err := GetDataStorageLineOfService(c, datastoragelineofserviceLink)
-		if err != nil {
-			collectionError.Failures[datastoragelineofserviceLink] = err
-		} else {
-			result = append(result, datastoragelineofservice)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedDataStorageLineOfServices(c common.Client, link string) ([]*DataStorageLineOfService, error) {
+	return common.ListReferenced[DataStorageLineOfService](c, link)
 }
@@ -103,8 +103,15 @@ func (datastorageloscapabilities *DataStorageLoSCapabilities) UnmarshalJSON(b []
 	return nil
 }
 
+// MarshalJSON marshals a DataStorageLoSCapabilities object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (datastorageloscapabilities *DataStorageLoSCapabilities) MarshalJSON() ([]byte, error) {
+	type temp DataStorageLoSCapabilities
+	return common.MarshalObject(datastorageloscapabilities.rawData, new(DataStorageLoSCapabilities), temp(*datastorageloscapabilities))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (datastorageloscapabilities *DataStorageLoSCapabilities) Update() error {
+func (datastorageloscapabilities *DataStorageLoSCapabilities) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(DataStorageLoSCapabilities)
@@ -125,53 +132,16 @@ func (datastorageloscapabilities *DataStorageLoSCapabilities) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(datastorageloscapabilities).Elem()
 
-	return datastorageloscapabilities.Entity.Update(originalElement, currentElement, readWriteFields)
+	return datastorageloscapabilities.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetDataStorageLoSCapabilities will get a DataStorageLoSCapabilities instance from the service.
 func GetDataStorageLoSCapabilities(c common.Client, uri string) (*DataStorageLoSCapabilities, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var datastorageloscapabilities DataStorageLoSCapabilities
-	err = json.NewDecoder(resp.Body).Decode(&datastorageloscapabilities)
-	if err != nil {
-		return nil, err
-	}
-
-	datastorageloscapabilities.SetClient(c)
-	return &datastorageloscapabilities, nil
+	return common.GetObject[DataStorageLoSCapabilities](c, uri)
 }
 
 // ListReferencedDataStorageLoSCapabilities gets the collection of DataStorageLoSCapabilities from
 // a provided reference.
-func ListReferencedDataStorageLoSCapabilities(c common.Client, link string) ([]*DataStorageLoSCapabilities, error) { //nolint:dupl
-	var result []*DataStorageLoSCapabilities
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, datastorageloscapabilitiesLink := range links.ItemLinks {
-		datastorageloscapabilities, err := GetDataStorageLoSCapabilities(c, datastorageloscapabilitiesLink)
-		if err != nil {
-			collectionError.Failures[datastorageloscapabilitiesLink] = err
-		} else {
-			result = append(result, datastorageloscapabilities)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedDataStorageLoSCapabilities(c common.Client, link string) ([]*DataStorageLoSCapabilities, error) {
+	return common.ListReferenced[DataStorageLoSCapabilities](c, link)
 }
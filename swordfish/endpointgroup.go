@@ -104,8 +104,15 @@ func (endpointgroup *EndpointGroup) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a EndpointGroup object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (endpointgroup *EndpointGroup) MarshalJSON() ([]byte, error) {
+	type temp EndpointGroup
+	return common.MarshalObject(endpointgroup.rawData, new(EndpointGroup), temp(*endpointgroup))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (endpointgroup *EndpointGroup) Update() error {
+func (endpointgroup *EndpointGroup) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(EndpointGroup)
@@ -125,55 +132,18 @@ func (endpointgroup *EndpointGroup) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(endpointgroup).Elem()
 
-	return endpointgroup.Entity.Update(originalElement, currentElement, readWriteFields)
+	return endpointgroup.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetEndpointGroup will get a EndpointGroup instance from the service.
 func GetEndpointGroup(c common.Client, uri string) (*EndpointGroup, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var endpointgroup EndpointGroup
-	err = json.NewDecoder(resp.Body).Decode(&endpointgroup)
-	if err != nil {
-		return nil, err
-	}
-
-	endpointgroup.SetClient(c)
-	return &endpointgroup, nil
+	return common.GetObject[EndpointGroup](c, uri)
 }
 
 // ListReferencedEndpointGroups gets the collection of EndpointGroup from
 // a provided reference.
-func ListReferencedEndpointGroups(c common.Client, link string) ([]*EndpointGroup, error) { //nolint:dupl
-	var result []*EndpointGroup
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, endpointgroupLink := range links.ItemLinks {
-		endpointgroup, err := GetEndpointGroup(c, endpointgroupLink)
-		if err != nil {
-			collectionError.Failures[endpointgroupLink] = err
-		} else {
-			result = append(result, endpointgroup)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedEndpointGroups(c common.Client, link string) ([]*EndpointGroup, error) {
+	return common.ListReferenced[EndpointGroup](c, link)
 }
 
 // Endpoints gets the group's endpoints.
@@ -133,8 +133,15 @@ func (fileshare *FileShare) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a FileShare object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (fileshare *FileShare) MarshalJSON() ([]byte, error) {
+	type temp FileShare
+	return common.MarshalObject(fileshare.rawData, new(FileShare), temp(*fileshare))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (fileshare *FileShare) Update() error {
+func (fileshare *FileShare) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(FileShare)
@@ -153,49 +160,18 @@ func (fileshare *FileShare) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(fileshare).Elem()
 
-	return fileshare.Entity.Update(originalElement, currentElement, readWriteFields)
+	return fileshare.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetFileShare will get a FileShare instance from the service.
 func GetFileShare(c common.Client, uri string) (*FileShare, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var fileshare FileShare
-	err = json.NewDecoder(resp.Body).Decode(&fileshare)
-	if err != nil {
-		return nil, err
-	}
-
-	fileshare.SetClient(c)
-	return &fileshare, nil
+	return common.GetObject[FileShare](c, uri)
 }
 
 // ListReferencedFileShares gets the collection of FileShare from a provided
 // reference.
 func ListReferencedFileShares(c common.Client, link string) ([]*FileShare, error) {
-	var result []*FileShare
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	for _, fileshareLink := range links.ItemLinks {
-		fileshare, err := GetFileShare(c, fileshareLink)
-		if err != nil {
-			return result, err
-		}
-		result = append(result, fileshare)
-	}
-
-	return result, nil
+	return common.ListReferenced[FileShare](c, link)
 }
 
 // ClassOfService gets the file share's class of service.
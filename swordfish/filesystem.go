@@ -228,8 +228,15 @@ func (filesystem *FileSystem) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a FileSystem object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (filesystem *FileSystem) MarshalJSON() ([]byte, error) {
+	type temp FileSystem
+	return common.MarshalObject(filesystem.rawData, new(FileSystem), temp(*filesystem))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (filesystem *FileSystem) Update() error {
+func (filesystem *FileSystem) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(FileSystem)
@@ -254,55 +261,18 @@ func (filesystem *FileSystem) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(filesystem).Elem()
 
-	return filesystem.Entity.Update(originalElement, currentElement, readWriteFields)
+	return filesystem.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetFileSystem will get a FileSystem instance from the service.
 func GetFileSystem(c common.Client, uri string) (*FileSystem, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var filesystem FileSystem
-	err = json.NewDecoder(resp.Body).Decode(&filesystem)
-	if err != nil {
-		return nil, err
-	}
-
-	filesystem.SetClient(c)
-	return &filesystem, nil
+	return common.GetObject[FileSystem](c, uri)
 }
 
 // ListReferencedFileSystems gets the collection of FileSystem from
 // a provided reference.
-func ListReferencedFileSystems(c common.Client, link string) ([]*FileSystem, error) { //nolint:dupl
-	var result []*FileSystem
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, filesystemLink := range links.ItemLinks {
-		filesystem, err := GetFileSystem(c, filesystemLink)
-		if err != nil {
-			collectionError.Failures[filesystemLink] = err
-		} else {
-			result = append(result, filesystem)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedFileSystems(c common.Client, link string) ([]*FileSystem, error) {
+	return common.ListReferenced[FileSystem](c, link)
 }
 
 // ExportedShares gets the exported file shares for this file system.
@@ -323,19 +293,12 @@ func (filesystem *FileSystem) ClassOfService() (*ClassOfService, error) {
 func (filesystem *FileSystem) SpareResourceSets() ([]*SpareResourceSet, error) {
 	var result []*SpareResourceSet
 
-	collectionError := common.NewCollectionError()
-	for _, rsLink := range filesystem.spareResourceSets {
-		rs, err := GetSpareResourceSet(filesystem.Client, rsLink)
-		if err != nil {
-			collectionError.Failures[rsLink] = err
-		} else {
-			result = append(result, rs)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(filesystem.Client, filesystem.spareResourceSets, func(link string) (interface{}, error) {
+		return GetSpareResourceSet(filesystem.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*SpareResourceSet))
 	}
 
-	return result, collectionError
+	return result, err
 }
@@ -5,8 +5,6 @@
 package swordfish
 
 import (
-	"encoding/json"
-
 	"github.com/trungng1992/gofish/common"
 )
 
@@ -37,48 +35,11 @@ type IOConnectivityLineOfService struct {
 
 // GetIOConnectivityLineOfService will get a IOConnectivityLineOfService instance from the service.
 func GetIOConnectivityLineOfService(c common.Client, uri string) (*IOConnectivityLineOfService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var ioconnectivitylineofservice IOConnectivityLineOfService
-	err = json.NewDecoder(resp.Body).Decode(&ioconnectivitylineofservice)
-	if err != nil {
-		return nil, err
-	}
-
-	ioconnectivitylineofservice.SetClient(c)
-	return &ioconnectivitylineofservice, nil
+	return common.GetObject[IOConnectivityLineOfService](c, uri)
 }
 
 // ListReferencedIOConnectivityLineOfServices gets the collection of IOConnectivityLineOfService from
 // a provided reference.
-func ListReferencedIOConnectivityLineOfServices(c common.Client, link string) ([]*IOConnectivityLineOfService, error) { //nolint:dupl
-	var result []*IOConnectivityLineOfService
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, ioconnectivitylineofserviceLink := range links.ItemLinks {
-		ioconnectivitylineofservice, err := GetIOConnectivityLineOfService(c, ioconnectivitylineofserviceLink)
-		if err != nil {
-			collectionError.Failures[ioconnectivitylineofserviceLink] = err
-		} else {
-			result = append(result, ioconnectivitylineofservice)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedIOConnectivityLineOfServices(c common.Client, link string) ([]*IOConnectivityLineOfService, error) {
+	return common.ListReferenced[IOConnectivityLineOfService](c, link)
 }
@@ -64,8 +64,15 @@ func (ioconnectivityloscapabilities *IOConnectivityLoSCapabilities) UnmarshalJSO
 	return nil
 }
 
+// MarshalJSON marshals a IOConnectivityLoSCapabilities object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (ioconnectivityloscapabilities *IOConnectivityLoSCapabilities) MarshalJSON() ([]byte, error) {
+	type temp IOConnectivityLoSCapabilities
+	return common.MarshalObject(ioconnectivityloscapabilities.rawData, new(IOConnectivityLoSCapabilities), temp(*ioconnectivityloscapabilities))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (ioconnectivityloscapabilities *IOConnectivityLoSCapabilities) Update() error {
+func (ioconnectivityloscapabilities *IOConnectivityLoSCapabilities) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(IOConnectivityLoSCapabilities)
@@ -84,54 +91,17 @@ func (ioconnectivityloscapabilities *IOConnectivityLoSCapabilities) Update() err
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(ioconnectivityloscapabilities).Elem()
 
-	return ioconnectivityloscapabilities.Entity.Update(originalElement, currentElement, readWriteFields)
+	return ioconnectivityloscapabilities.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetIOConnectivityLoSCapabilities will get a IOConnectivityLoSCapabilities
 // instance from the service.
 func GetIOConnectivityLoSCapabilities(c common.Client, uri string) (*IOConnectivityLoSCapabilities, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var ioconnectivityloscapabilities IOConnectivityLoSCapabilities
-	err = json.NewDecoder(resp.Body).Decode(&ioconnectivityloscapabilities)
-	if err != nil {
-		return nil, err
-	}
-
-	ioconnectivityloscapabilities.SetClient(c)
-	return &ioconnectivityloscapabilities, nil
+	return common.GetObject[IOConnectivityLoSCapabilities](c, uri)
 }
 
 // ListReferencedIOConnectivityLoSCapabilitiess gets the collection of
 // IOConnectivityLoSCapabilities from a provided reference.
-func ListReferencedIOConnectivityLoSCapabilitiess(c common.Client, link string) ([]*IOConnectivityLoSCapabilities, error) { //nolint:dupl
-	var result []*IOConnectivityLoSCapabilities
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, ioconnectivityloscapabilitiesLink := range links.ItemLinks {
-		ioconnectivityloscapabilities, err := GetIOConnectivityLoSCapabilities(c, ioconnectivityloscapabilitiesLink)
-		if err != nil {
-			collectionError.Failures[ioconnectivityloscapabilitiesLink] = err
-		} else {
-			result = append(result, ioconnectivityloscapabilities)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedIOConnectivityLoSCapabilitiess(c common.Client, link string) ([]*IOConnectivityLoSCapabilities, error) {
+	return common.ListReferenced[IOConnectivityLoSCapabilities](c, link)
 }
@@ -5,8 +5,6 @@
 package swordfish
 
 import (
-	"encoding/json"
-
 	"github.com/trungng1992/gofish/common"
 )
 
@@ -48,48 +46,11 @@ type IOPerformanceLineOfService struct {
 
 // GetIOPerformanceLineOfService will get a IOPerformanceLineOfService instance from the service.
 func GetIOPerformanceLineOfService(c common.Client, uri string) (*IOPerformanceLineOfService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var ioperformancelineofservice IOPerformanceLineOfService
-	err = json.NewDecoder(resp.Body).Decode(&ioperformancelineofservice)
-	if err != nil {
-		return nil, err
-	}
-
-	ioperformancelineofservice.SetClient(c)
-	return &ioperformancelineofservice, nil
+	return common.GetObject[IOPerformanceLineOfService](c, uri)
 }
 
 // ListReferencedIOPerformanceLineOfServices gets the collection of IOPerformanceLineOfService from
 // a provided reference.
-func ListReferencedIOPerformanceLineOfServices(c common.Client, link string) ([]*IOPerformanceLineOfService, error) { //nolint:dupl
-	var result []*IOPerformanceLineOfService
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, ioperformancelineofserviceLink := range links.ItemLinks {
-		ioperformancelineofservice, err := GetIOPerformanceLineOfService(c, ioperformancelineofserviceLink)
-		if err != nil {
-			collectionError.Failures[ioperformancelineofserviceLink] = err
-		} else {
-			result = append(result, ioperformancelineofservice)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedIOPerformanceLineOfServices(c common.Client, link string) ([]*IOPerformanceLineOfService, error) {
+	return common.ListReferenced[IOPerformanceLineOfService](c, link)
 }
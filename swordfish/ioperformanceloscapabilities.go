@@ -92,8 +92,15 @@ func (ioperformanceloscapabilities *IOPerformanceLoSCapabilities) UnmarshalJSON(
 	return nil
 }
 
+// MarshalJSON marshals a IOPerformanceLoSCapabilities object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (ioperformanceloscapabilities *IOPerformanceLoSCapabilities) MarshalJSON() ([]byte, error) {
+	type temp IOPerformanceLoSCapabilities
+	return common.MarshalObject(ioperformanceloscapabilities.rawData, new(IOPerformanceLoSCapabilities), temp(*ioperformanceloscapabilities))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (ioperformanceloscapabilities *IOPerformanceLoSCapabilities) Update() error {
+func (ioperformanceloscapabilities *IOPerformanceLoSCapabilities) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(IOPerformanceLoSCapabilities)
@@ -113,55 +120,18 @@ func (ioperformanceloscapabilities *IOPerformanceLoSCapabilities) Update() error
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(ioperformanceloscapabilities).Elem()
 
-	return ioperformanceloscapabilities.Entity.Update(originalElement, currentElement, readWriteFields)
+	return ioperformanceloscapabilities.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetIOPerformanceLoSCapabilities will get a IOPerformanceLoSCapabilities instance from the service.
 func GetIOPerformanceLoSCapabilities(c common.Client, uri string) (*IOPerformanceLoSCapabilities, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var ioperformanceloscapabilities IOPerformanceLoSCapabilities
-	err = json.NewDecoder(resp.Body).Decode(&ioperformanceloscapabilities)
-	if err != nil {
-		return nil, err
-	}
-
-	ioperformanceloscapabilities.SetClient(c)
-	return &ioperformanceloscapabilities, nil
+	return common.GetObject[IOPerformanceLoSCapabilities](c, uri)
 }
 
 // ListReferencedIOPerformanceLoSCapabilitiess gets the collection of IOPerformanceLoSCapabilities from
 // a provided reference.
-func ListReferencedIOPerformanceLoSCapabilitiess(c common.Client, link string) ([]*IOPerformanceLoSCapabilities, error) { //nolint:dupl
-	var result []*IOPerformanceLoSCapabilities
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, ioperformanceloscapabilitiesLink := range links.ItemLinks {
-		ioperformanceloscapabilities, err := GetIOPerformanceLoSCapabilities(c, ioperformanceloscapabilitiesLink)
-		if err != nil {
-			collectionError.Failures[ioperformanceloscapabilitiesLink] = err
-		} else {
-			result = append(result, ioperformanceloscapabilities)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedIOPerformanceLoSCapabilitiess(c common.Client, link string) ([]*IOPerformanceLoSCapabilities, error) {
+	return common.ListReferenced[IOPerformanceLoSCapabilities](c, link)
 }
 
 // IOWorkload is used to describe an IO Workload.
@@ -83,8 +83,15 @@ func (spareresourceset *SpareResourceSet) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a SpareResourceSet object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (spareresourceset *SpareResourceSet) MarshalJSON() ([]byte, error) {
+	type temp SpareResourceSet
+	return common.MarshalObject(spareresourceset.rawData, new(SpareResourceSet), temp(*spareresourceset))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (spareresourceset *SpareResourceSet) Update() error {
+func (spareresourceset *SpareResourceSet) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(SpareResourceSet)
@@ -103,55 +110,18 @@ func (spareresourceset *SpareResourceSet) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(spareresourceset).Elem()
 
-	return spareresourceset.Entity.Update(originalElement, currentElement, readWriteFields)
+	return spareresourceset.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetSpareResourceSet will get a SpareResourceSet instance from the service.
 func GetSpareResourceSet(c common.Client, uri string) (*SpareResourceSet, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var spareresourceset SpareResourceSet
-	err = json.NewDecoder(resp.Body).Decode(&spareresourceset)
-	if err != nil {
-		return nil, err
-	}
-
-	spareresourceset.SetClient(c)
-	return &spareresourceset, nil
+	return common.GetObject[SpareResourceSet](c, uri)
 }
 
 // ListReferencedSpareResourceSets gets the collection of SpareResourceSet from
 // a provided reference.
-func ListReferencedSpareResourceSets(c common.Client, link string) ([]*SpareResourceSet, error) { //nolint:dupl
-	var result []*SpareResourceSet
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, spareresourcesetLink := range links.ItemLinks {
-		spareresourceset, err := GetSpareResourceSet(c, spareresourcesetLink)
-		if err != nil {
-			collectionError.Failures[spareresourcesetLink] = err
-		} else {
-			result = append(result, spareresourceset)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedSpareResourceSets(c common.Client, link string) ([]*SpareResourceSet, error) {
+	return common.ListReferenced[SpareResourceSet](c, link)
 }
 
 // ReplacementSpareSets gets other spare sets that can be utilized to replenish
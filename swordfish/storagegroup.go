@@ -182,8 +182,15 @@ func (storagegroup *StorageGroup) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a StorageGroup object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (storagegroup *StorageGroup) MarshalJSON() ([]byte, error) {
+	type temp StorageGroup
+	return common.MarshalObject(storagegroup.rawData, new(StorageGroup), temp(*storagegroup))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (storagegroup *StorageGroup) Update() error {
+func (storagegroup *StorageGroup) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(StorageGroup)
@@ -203,97 +210,46 @@ func (storagegroup *StorageGroup) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(storagegroup).Elem()
 
-	return storagegroup.Entity.Update(originalElement, currentElement, readWriteFields)
+	return storagegroup.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetStorageGroup will get a StorageGroup instance from the service.
 func GetStorageGroup(c common.Client, uri string) (*StorageGroup, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var storagegroup StorageGroup
-	err = json.NewDecoder(resp.Body).Decode(&storagegroup)
-	if err != nil {
-		return nil, err
-	}
-
-	storagegroup.SetClient(c)
-	return &storagegroup, nil
+	return common.GetObject[StorageGroup](c, uri)
 }
 
 // ListReferencedStorageGroups gets the collection of StorageGroup from
 // a provided reference.
-func ListReferencedStorageGroups(c common.Client, link string) ([]*StorageGroup, error) { //nolint:dupl
-	var result []*StorageGroup
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, storagegroupLink := range links.ItemLinks {
-		storagegroup, err := GetStorageGroup(c, storagegroupLink)
-		if err != nil {
-			collectionError.Failures[storagegroupLink] = err
-		} else {
-			result = append(result, storagegroup)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedStorageGroups(c common.Client, link string) ([]*StorageGroup, error) {
+	return common.ListReferenced[StorageGroup](c, link)
 }
 
 // ChildStorageGroups gets child groups of this group.
 func (storagegroup *StorageGroup) ChildStorageGroups() ([]*StorageGroup, error) {
 	var result []*StorageGroup
 
-	collectionError := common.NewCollectionError()
-	for _, sgLink := range storagegroup.childStorageGroups {
-		sg, err := GetStorageGroup(storagegroup.Client, sgLink)
-		if err != nil {
-			collectionError.Failures[sgLink] = err
-		} else {
-			result = append(result, sg)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(storagegroup.Client, storagegroup.childStorageGroups, func(link string) (interface{}, error) {
+		return GetStorageGroup(storagegroup.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*StorageGroup))
 	}
 
-	return result, collectionError
+	return result, err
 }
 
 // ParentStorageGroups gets parent groups of this group.
 func (storagegroup *StorageGroup) ParentStorageGroups() ([]*StorageGroup, error) {
 	var result []*StorageGroup
 
-	collectionError := common.NewCollectionError()
-	for _, sgLink := range storagegroup.parentStorageGroups {
-		sg, err := GetStorageGroup(storagegroup.Client, sgLink)
-		if err != nil {
-			collectionError.Failures[sgLink] = err
-		} else {
-			result = append(result, sg)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(storagegroup.Client, storagegroup.parentStorageGroups, func(link string) (interface{}, error) {
+		return GetStorageGroup(storagegroup.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*StorageGroup))
 	}
 
-	return result, collectionError
+	return result, err
 }
 
 // ClassOfService gets the ClassOfService that all storage in this StorageGroup
@@ -317,8 +273,10 @@ type MappedVolume struct {
 // named in the ServerEndpointGroups to the initiator endpoints named in the
 // ClientEndpointGroups.  The property VolumesAreExposed shall be set to true
 // when this action is completed.
-func (storagegroup *StorageGroup) ExposeVolumes() error {
-	_, err := storagegroup.Client.Post(storagegroup.exposeVolumesTarget, nil)
+// The request carries the storage group ETag in If-Match unless
+// common.SkipETagMatch is given.
+func (storagegroup *StorageGroup) ExposeVolumes(opts ...common.UpdateOption) error {
+	err := storagegroup.Post(storagegroup.exposeVolumesTarget, nil, opts...)
 	if err == nil {
 		// Only set to exposed if no error. Calling expose when already exposed
 		// could fail so we don't want to indicate they are not exposed.
@@ -330,8 +288,10 @@ func (storagegroup *StorageGroup) ExposeVolumes() error {
 // HideVolumes hides the storage of this group from the initiator endpoints
 // named in the ClientEndpointGroups. The property VolumesAreExposed shall be
 // set to false when this action is completed.
-func (storagegroup *StorageGroup) HideVolumes() error {
-	_, err := storagegroup.Client.Post(storagegroup.hideVolumesTarget, nil)
+// The request carries the storage group ETag in If-Match unless
+// common.SkipETagMatch is given.
+func (storagegroup *StorageGroup) HideVolumes(opts ...common.UpdateOption) error {
+	err := storagegroup.Post(storagegroup.hideVolumesTarget, nil, opts...)
 	if err == nil {
 		storagegroup.VolumesAreExposed = false
 	}
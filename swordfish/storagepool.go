@@ -142,8 +142,15 @@ func (storagepool *StoragePool) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a StoragePool object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (storagepool *StoragePool) MarshalJSON() ([]byte, error) {
+	type temp StoragePool
+	return common.MarshalObject(storagepool.rawData, new(StoragePool), temp(*storagepool))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (storagepool *StoragePool) Update() error {
+func (storagepool *StoragePool) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(StoragePool)
@@ -167,55 +174,18 @@ func (storagepool *StoragePool) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(storagepool).Elem()
 
-	return storagepool.Entity.Update(originalElement, currentElement, readWriteFields)
+	return storagepool.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetStoragePool will get a StoragePool instance from the service.
 func GetStoragePool(c common.Client, uri string) (*StoragePool, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var storagepool StoragePool
-	err = json.NewDecoder(resp.Body).Decode(&storagepool)
-	if err != nil {
-		return nil, err
-	}
-
-	storagepool.SetClient(c)
-	return &storagepool, nil
+	return common.GetObject[StoragePool](c, uri)
 }
 
 // ListReferencedStoragePools gets the collection of StoragePool from
 // a provided reference.
-func ListReferencedStoragePools(c common.Client, link string) ([]*StoragePool, error) { //nolint:dupl
-	var result []*StoragePool
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, storagepoolLink := range links.ItemLinks {
-		storagepool, err := GetStoragePool(c, storagepoolLink)
-		if err != nil {
-			collectionError.Failures[storagepoolLink] = err
-		} else {
-			result = append(result, storagepool)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedStoragePools(c common.Client, link string) ([]*StoragePool, error) {
+	return common.ListReferenced[StoragePool](c, link)
 }
 
 // DedicatedSpareDrives gets the Drive entities which are currently assigned as
@@ -223,21 +193,14 @@ func ListReferencedStoragePools(c common.Client, link string) ([]*StoragePool, e
 func (storagepool *StoragePool) DedicatedSpareDrives() ([]*redfish.Drive, error) {
 	var result []*redfish.Drive
 
-	collectionError := common.NewCollectionError()
-	for _, driveLink := range storagepool.dedicatedSpareDrives {
-		drive, err := redfish.GetDrive(storagepool.Client, driveLink)
-		if err != nil {
-			collectionError.Failures[driveLink] = err
-		} else {
-			result = append(result, drive)
-		}
+	members, err := common.FetchCollectionMembers(storagepool.Client, storagepool.dedicatedSpareDrives, func(link string) (interface{}, error) {
+		return redfish.GetDrive(storagepool.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*redfish.Drive))
 	}
 
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return result, err
 }
 
 // SpareResourceSets gets resources that may be utilized to replace the capacity
@@ -245,21 +208,14 @@ func (storagepool *StoragePool) DedicatedSpareDrives() ([]*redfish.Drive, error)
 func (storagepool *StoragePool) SpareResourceSets() ([]*SpareResourceSet, error) {
 	var result []*SpareResourceSet
 
-	collectionError := common.NewCollectionError()
-	for _, srsLink := range storagepool.spareResourceSets {
-		srs, err := GetSpareResourceSet(storagepool.Client, srsLink)
-		if err != nil {
-			collectionError.Failures[srsLink] = err
-		} else {
-			result = append(result, srs)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(storagepool.Client, storagepool.spareResourceSets, func(link string) (interface{}, error) {
+		return GetSpareResourceSet(storagepool.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*SpareResourceSet))
 	}
 
-	return result, collectionError
+	return result, err
 }
 
 // AllocatedPools gets the storage pools allocated from this storage pool.
@@ -276,21 +232,14 @@ func (storagepool *StoragePool) AllocatedVolumes() ([]*Volume, error) {
 func (storagepool *StoragePool) CapacitySources() ([]*CapacitySource, error) {
 	var result []*CapacitySource
 
-	collectionError := common.NewCollectionError()
-	for _, capLink := range storagepool.capacitySources {
-		capacity, err := GetCapacitySource(storagepool.Client, capLink)
-		if err != nil {
-			collectionError.Failures[capLink] = err
-		} else {
-			result = append(result, capacity)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(storagepool.Client, storagepool.capacitySources, func(link string) (interface{}, error) {
+		return GetCapacitySource(storagepool.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*CapacitySource))
 	}
 
-	return result, collectionError
+	return result, err
 }
 
 // ClassesOfService gets references to all classes of service supported by this
@@ -407,6 +407,8 @@ type ReplicaInfo struct {
 	// Do not instantiate this property if implementation is not capable of
 	// providing this information.
 	WhenSynchronized string
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a ReplicaInfo object from the raw JSON.
@@ -428,9 +430,19 @@ func (replicainfo *ReplicaInfo) UnmarshalJSON(b []byte) error {
 	replicainfo.dataProtectionLineOfService = t.DataProtectionLineOfService.ToStrings()
 	replicainfo.replica = string(t.Replica)
 
+	// Save the raw object data so the object can be marshaled back
+	replicainfo.rawData = b
+
 	return nil
 }
 
+// MarshalJSON marshals a ReplicaInfo object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (replicainfo *ReplicaInfo) MarshalJSON() ([]byte, error) {
+	type temp ReplicaInfo
+	return common.MarshalObject(replicainfo.rawData, new(ReplicaInfo), temp(*replicainfo))
+}
+
 // StorageReplicaInfo is
 type StorageReplicaInfo struct {
 	common.Entity
@@ -445,48 +457,11 @@ type StorageReplicaInfo struct {
 
 // GetStorageReplicaInfo will get a StorageReplicaInfo instance from the service.
 func GetStorageReplicaInfo(c common.Client, uri string) (*StorageReplicaInfo, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var storagereplicainfo StorageReplicaInfo
-	err = json.NewDecoder(resp.Body).Decode(&storagereplicainfo)
-	if err != nil {
-		return nil, err
-	}
-
-	storagereplicainfo.SetClient(c)
-	return &storagereplicainfo, nil
+	return common.GetObject[StorageReplicaInfo](c, uri)
 }
 
 // ListReferencedStorageReplicaInfos gets the collection of StorageReplicaInfo from
 // a provided reference.
-func ListReferencedStorageReplicaInfos(c common.Client, link string) ([]*StorageReplicaInfo, error) { //nolint:dupl
-	var result []*StorageReplicaInfo
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, storagereplicainfoLink := range links.ItemLinks {
-		storagereplicainfo, err := GetStorageReplicaInfo(c, storagereplicainfoLink)
-		if err != nil {
-			collectionError.Failures[storagereplicainfoLink] = err
-		} else {
-			result = append(result, storagereplicainfo)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedStorageReplicaInfos(c common.Client, link string) ([]*StorageReplicaInfo, error) {
+	return common.ListReferenced[StorageReplicaInfo](c, link)
 }
@@ -92,6 +92,8 @@ type StorageService struct {
 	volumes string
 	// setEncryptionKeyTarget is the URL to send SetEncryptionKey requests.
 	setEncryptionKeyTarget string
+	// rawData holds the original serialized JSON so the object can be marshaled back.
+	rawData []byte
 }
 
 // UnmarshalJSON unmarshals a StorageService object from the raw JSON.
@@ -157,51 +159,28 @@ func (storageservice *StorageService) UnmarshalJSON(b []byte) error {
 	storageservice.volumes = string(t.Volumes)
 	storageservice.setEncryptionKeyTarget = t.Actions.SetEncryptionKey.Target
 
+	// Save the raw object data so the object can be marshaled back
+	storageservice.rawData = b
+
 	return nil
 }
 
+// MarshalJSON marshals a StorageService object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (storageservice *StorageService) MarshalJSON() ([]byte, error) {
+	type temp StorageService
+	return common.MarshalObject(storageservice.rawData, new(StorageService), temp(*storageservice))
+}
+
 // GetStorageService will get a StorageService instance from the service.
 func GetStorageService(c common.Client, uri string) (*StorageService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var storageservice StorageService
-	err = json.NewDecoder(resp.Body).Decode(&storageservice)
-	if err != nil {
-		return nil, err
-	}
-
-	storageservice.SetClient(c)
-	return &storageservice, nil
+	return common.GetObject[StorageService](c, uri)
 }
 
 // ListReferencedStorageServices gets the collection of StorageService from
 // a provided reference.
 func ListReferencedStorageServices(c common.Client, link string) ([]*StorageService, error) {
-	var result []*StorageService
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, storageserviceLink := range links.ItemLinks {
-		storageservice, err := GetStorageService(c, storageserviceLink)
-		if err != nil {
-			collectionError.Failures[storageserviceLink] = err
-		} else {
-			result = append(result, storageservice)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return common.ListReferenced[StorageService](c, link)
 }
 
 // ClassesOfService gets the storage service's classes of service.
@@ -284,21 +263,14 @@ func (storageservice *StorageService) IOPerformanceLoSCapabilities() (*IOPerform
 func (storageservice *StorageService) Redundancy() ([]*redfish.Redundancy, error) {
 	var result []*redfish.Redundancy
 
-	collectionError := common.NewCollectionError()
-	for _, redundancyLink := range storageservice.redundancy {
-		redundancy, err := redfish.GetRedundancy(storageservice.Client, redundancyLink)
-		if err != nil {
-			collectionError.Failures[redundancyLink] = err
-		} else {
-			result = append(result, redundancy)
-		}
+	members, err := common.FetchCollectionMembers(storageservice.Client, storageservice.redundancy, func(link string) (interface{}, error) {
+		return redfish.GetRedundancy(storageservice.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*redfish.Redundancy))
 	}
 
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return result, err
 }
 
 // SpareResourceSets gets resources that may be utilized to replace the capacity
@@ -306,42 +278,28 @@ func (storageservice *StorageService) Redundancy() ([]*redfish.Redundancy, error
 func (storageservice *StorageService) SpareResourceSets() ([]*SpareResourceSet, error) {
 	var result []*SpareResourceSet
 
-	collectionError := common.NewCollectionError()
-	for _, srsLink := range storageservice.spareResourceSets {
-		srs, err := GetSpareResourceSet(storageservice.Client, srsLink)
-		if err != nil {
-			collectionError.Failures[srsLink] = err
-		} else {
-			result = append(result, srs)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(storageservice.Client, storageservice.spareResourceSets, func(link string) (interface{}, error) {
+		return GetSpareResourceSet(storageservice.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*SpareResourceSet))
 	}
 
-	return result, collectionError
+	return result, err
 }
 
 // StorageGroups gets the storage groups that are a part of this storage service.
 func (storageservice *StorageService) StorageGroups() ([]*StorageGroup, error) {
 	var result []*StorageGroup
 
-	collectionError := common.NewCollectionError()
-	for _, sgLink := range storageservice.spareResourceSets {
-		sg, err := GetStorageGroup(storageservice.Client, sgLink)
-		if err != nil {
-			collectionError.Failures[sgLink] = err
-		} else {
-			result = append(result, sg)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(storageservice.Client, storageservice.spareResourceSets, func(link string) (interface{}, error) {
+		return GetStorageGroup(storageservice.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*StorageGroup))
 	}
 
-	return result, collectionError
+	return result, err
 }
 
 // Volumes gets the volumes that are a part of this storage service.
@@ -350,12 +308,13 @@ func (storageservice *StorageService) Volumes() ([]*Volume, error) {
 }
 
 // SetEncryptionKey shall set the encryption key for the storage subsystem.
-func (storageservice *StorageService) SetEncryptionKey(key string) error {
+// The request carries the storage service ETag in If-Match unless
+// common.SkipETagMatch is given.
+func (storageservice *StorageService) SetEncryptionKey(key string, opts ...common.UpdateOption) error {
 	type temp struct {
 		EncryptionKey string
 	}
 	t := temp{EncryptionKey: key}
 
-	_, err := storageservice.Client.Post(storageservice.setEncryptionKeyTarget, t)
-	return err
+	return storageservice.Post(storageservice.setEncryptionKeyTarget, t, opts...)
 }
@@ -5,8 +5,6 @@
 package swordfish
 
 import (
-	"encoding/json"
-
 	"github.com/trungng1992/gofish/common"
 	"github.com/trungng1992/gofish/redfish"
 )
@@ -18,43 +16,10 @@ type StorageSystem struct {
 
 // GetStorageSystem will get a StorageSystem instance from the Swordfish service.
 func GetStorageSystem(c common.Client, uri string) (*StorageSystem, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var storageSystem StorageSystem
-	err = json.NewDecoder(resp.Body).Decode(&storageSystem)
-	if err != nil {
-		return nil, err
-	}
-
-	storageSystem.SetClient(c)
-	return &storageSystem, nil
+	return common.GetObject[StorageSystem](c, uri)
 }
 
 // ListReferencedStorageSystems gets the collection of StorageSystems.
 func ListReferencedStorageSystems(c common.Client, link string) ([]*StorageSystem, error) {
-	var result []*StorageSystem
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, storageSystemLink := range links.ItemLinks {
-		storageSystem, err := GetStorageSystem(c, storageSystemLink)
-		if err != nil {
-			collectionError.Failures[storageSystemLink] = err
-		} else {
-			result = append(result, storageSystem)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return common.ListReferenced[StorageSystem](c, link)
 }
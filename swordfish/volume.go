@@ -512,8 +512,15 @@ func (volume *Volume) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals a Volume object into the Redfish JSON it was read
+// from, with the changes made to it since.
+func (volume *Volume) MarshalJSON() ([]byte, error) {
+	type temp Volume
+	return common.MarshalObject(volume.rawData, new(Volume), temp(*volume))
+}
+
 // Update commits updates to this object's properties to the running system.
-func (volume *Volume) Update() error {
+func (volume *Volume) Update(opts ...common.UpdateOption) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(Volume)
@@ -543,54 +550,17 @@ func (volume *Volume) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(volume).Elem()
 
-	return volume.Entity.Update(originalElement, currentElement, readWriteFields)
+	return volume.Entity.Update(originalElement, currentElement, readWriteFields, opts...)
 }
 
 // GetVolume will get a Volume instance from the service.
 func GetVolume(c common.Client, uri string) (*Volume, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var volume Volume
-	err = json.NewDecoder(resp.Body).Decode(&volume)
-	if err != nil {
-		return nil, err
-	}
-
-	volume.SetClient(c)
-	return &volume, nil
+	return common.GetObject[Volume](c, uri)
 }
 
 // ListReferencedVolumes gets the collection of Volume from a provided reference.
-func ListReferencedVolumes(c common.Client, link string) ([]*Volume, error) { //nolint:dupl
-	var result []*Volume
-	if link == "" {
-		return result, nil
-	}
-
-	links, err := common.GetCollection(c, link)
-	if err != nil {
-		return result, err
-	}
-
-	collectionError := common.NewCollectionError()
-	for _, volumeLink := range links.ItemLinks {
-		volume, err := GetVolume(c, volumeLink)
-		if err != nil {
-			collectionError.Failures[volumeLink] = err
-		} else {
-			result = append(result, volume)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+func ListReferencedVolumes(c common.Client, link string) ([]*Volume, error) {
+	return common.ListReferenced[Volume](c, link)
 }
 
 // ClassOfService gets the class of service that this storage volume conforms to.
@@ -606,21 +576,14 @@ func (volume *Volume) ClassOfService() (*ClassOfService, error) {
 func (volume *Volume) getDrives(links []string) ([]*redfish.Drive, error) {
 	var result []*redfish.Drive
 
-	collectionError := common.NewCollectionError()
-	for _, driveLink := range links {
-		drive, err := redfish.GetDrive(volume.Client, driveLink)
-		if err != nil {
-			collectionError.Failures[driveLink] = err
-		} else {
-			result = append(result, drive)
-		}
+	members, err := common.FetchCollectionMembers(volume.Client, links, func(link string) (interface{}, error) {
+		return redfish.GetDrive(volume.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*redfish.Drive))
 	}
 
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return result, err
 }
 
 // DedicatedSpareDrives references the Drives that are dedicated spares for this
@@ -638,69 +601,50 @@ func (volume *Volume) Drives() ([]*redfish.Drive, error) {
 func (volume *Volume) SpareResourceSets() ([]*SpareResourceSet, error) {
 	var result []*SpareResourceSet
 
-	collectionError := common.NewCollectionError()
-	for _, srsLink := range volume.spareResourceSets {
-		srs, err := GetSpareResourceSet(volume.Client, srsLink)
-		if err != nil {
-			collectionError.Failures[srsLink] = err
-		} else {
-			result = append(result, srs)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(volume.Client, volume.spareResourceSets, func(link string) (interface{}, error) {
+		return GetSpareResourceSet(volume.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*SpareResourceSet))
 	}
 
-	return result, collectionError
+	return result, err
 }
 
 // StorageGroups gets the storage groups that associated with this volume.
 func (volume *Volume) StorageGroups() ([]*StorageGroup, error) {
 	var result []*StorageGroup
 
-	collectionError := common.NewCollectionError()
-	for _, sgLink := range volume.storageGroups {
-		sg, err := GetStorageGroup(volume.Client, sgLink)
-		if err != nil {
-			collectionError.Failures[sgLink] = err
-		} else {
-			result = append(result, sg)
-		}
+	members, err := common.FetchCollectionMembers(volume.Client, volume.storageGroups, func(link string) (interface{}, error) {
+		return GetStorageGroup(volume.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*StorageGroup))
 	}
 
-	if collectionError.Empty() {
-		return result, nil
-	}
-
-	return result, collectionError
+	return result, err
 }
 
 // StoragePools gets the storage pools that associated with this volume.
 func (volume *Volume) StoragePools() ([]*StoragePool, error) {
 	var result []*StoragePool
 
-	collectionError := common.NewCollectionError()
-	for _, sgLink := range volume.allocatedPools {
-		sg, err := GetStoragePool(volume.Client, sgLink)
-		if err != nil {
-			collectionError.Failures[sgLink] = err
-		} else {
-			result = append(result, sg)
-		}
-	}
-
-	if collectionError.Empty() {
-		return result, nil
+	members, err := common.FetchCollectionMembers(volume.Client, volume.allocatedPools, func(link string) (interface{}, error) {
+		return GetStoragePool(volume.Client, link)
+	})
+	for _, member := range members {
+		result = append(result, member.(*StoragePool))
 	}
 
-	return result, collectionError
+	return result, err
 }
 
 // AssignReplicaTarget is used to establish a replication relationship by
 // assigning an existing volume to serve as a target replica for an existing
 // source volume.
-func (volume *Volume) AssignReplicaTarget(replicaType ReplicaType, updateMode ReplicaUpdateMode, targetVolumeODataID string) error {
+// The request carries the volume ETag in If-Match unless common.SkipETagMatch
+// is given.
+func (volume *Volume) AssignReplicaTarget(replicaType ReplicaType, updateMode ReplicaUpdateMode, targetVolumeODataID string, opts ...common.UpdateOption) error {
 	// This action wasn't added until later revisions
 	if volume.assignReplicaTargetTarget == "" {
 		return fmt.Errorf("AssignReplicaTarget action is not supported by this system")
@@ -720,25 +664,29 @@ func (volume *Volume) AssignReplicaTarget(replicaType ReplicaType, updateMode Re
 		TargetVolume:      targetVolumeODataID,
 	}
 
-	_, err := volume.Client.Post(volume.assignReplicaTargetTarget, t)
-	return err
+	return volume.Post(volume.assignReplicaTargetTarget, t, opts...)
 }
 
 // CheckConsistency is used to force a check of the Volume's parity or redundant
 // data to ensure it matches calculated values.
-func (volume *Volume) CheckConsistency() error {
+// The request carries the volume ETag in If-Match unless common.SkipETagMatch
+// is given.
+func (volume *Volume) CheckConsistency(opts ...common.UpdateOption) error {
 	if volume.checkConsistencyTarget == "" {
 		return fmt.Errorf("CheckConsistency action is not supported by this system")
 	}
 
-	_, err := volume.Client.Post(volume.checkConsistencyTarget, nil)
-	return err
+	return volume.Post(volume.checkConsistencyTarget, nil, opts...)
 }
 
 // Initialize is used to prepare the contents of the volume for use by the system.
-func (volume *Volume) Initialize(initType InitializeType) error {
+// A slow initialization usually completes after the service replied, the
+// returned operation can be waited on for its outcome.
+// The request carries the volume ETag in If-Match unless common.SkipETagMatch
+// is given.
+func (volume *Volume) Initialize(initType InitializeType, opts ...common.UpdateOption) (*redfish.AsyncOperation, error) {
 	if volume.initializeTarget == "" {
-		return fmt.Errorf("initialize action is not supported by this system")
+		return nil, fmt.Errorf("initialize action is not supported by this system")
 	}
 
 	// Define this action's parameters
@@ -749,14 +697,19 @@ func (volume *Volume) Initialize(initType InitializeType) error {
 	// Set the values for the action arguments
 	t := temp{InitializeType: initType}
 
-	_, err := volume.Client.Post(volume.initializeTarget, t)
-	return err
+	resp, err := volume.PostWithResponse(volume.initializeTarget, t, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return redfish.NewAsyncOperation(volume.Client, resp)
 }
 
 // RemoveReplicaRelationship is used to disable data synchronization between a
 // source and target volume, remove the replication relationship, and optionally
 // delete the target volume.
-func (volume *Volume) RemoveReplicaRelationship(deleteTarget bool, targetVolumeODataID string) error {
+// The request carries the volume ETag in If-Match unless common.SkipETagMatch
+// is given.
+func (volume *Volume) RemoveReplicaRelationship(deleteTarget bool, targetVolumeODataID string, opts ...common.UpdateOption) error {
 	// This action wasn't added until later revisions
 	if volume.removeReplicaRelationshipTarget == "" {
 		return fmt.Errorf("RemoveReplicaRelationship action is not supported by this system")
@@ -774,14 +727,15 @@ func (volume *Volume) RemoveReplicaRelationship(deleteTarget bool, targetVolumeO
 		TargetVolume:       targetVolumeODataID,
 	}
 
-	_, err := volume.Client.Post(volume.removeReplicaRelationshipTarget, t)
-	return err
+	return volume.Post(volume.removeReplicaRelationshipTarget, t, opts...)
 }
 
 // ResumeReplication is used to resume the active data synchronization between a
 // source and target volume, without otherwise altering the replication
 // relationship.
-func (volume *Volume) ResumeReplication(targetVolumeODataID string) error {
+// The request carries the volume ETag in If-Match unless common.SkipETagMatch
+// is given.
+func (volume *Volume) ResumeReplication(targetVolumeODataID string, opts ...common.UpdateOption) error {
 	// This action wasn't added until later revisions
 	if volume.resumeReplicationTarget == "" {
 		return fmt.Errorf("ResumeReplication action is not supported by this system")
@@ -795,13 +749,14 @@ func (volume *Volume) ResumeReplication(targetVolumeODataID string) error {
 	// Set the values for the action arguments
 	t := temp{TargetVolume: targetVolumeODataID}
 
-	_, err := volume.Client.Post(volume.resumeReplicationTarget, t)
-	return err
+	return volume.Post(volume.resumeReplicationTarget, t, opts...)
 }
 
 // ReverseReplicationRelationship is used to reverse the replication
 // relationship between a source and target volume.
-func (volume *Volume) ReverseReplicationRelationship(targetVolumeODataID string) error {
+// The request carries the volume ETag in If-Match unless common.SkipETagMatch
+// is given.
+func (volume *Volume) ReverseReplicationRelationship(targetVolumeODataID string, opts ...common.UpdateOption) error {
 	// This action wasn't added until later revisions
 	if volume.reverseReplicationRelationshipTarget == "" {
 		return fmt.Errorf("ReverseReplicationRelationship action is not supported by this system")
@@ -815,13 +770,14 @@ func (volume *Volume) ReverseReplicationRelationship(targetVolumeODataID string)
 	// Set the values for the action arguments
 	t := temp{TargetVolume: targetVolumeODataID}
 
-	_, err := volume.Client.Post(volume.reverseReplicationRelationshipTarget, t)
-	return err
+	return volume.Post(volume.reverseReplicationRelationshipTarget, t, opts...)
 }
 
 // SplitReplication is used to split the replication relationship and suspend
 // data synchronization between a source and target volume.
-func (volume *Volume) SplitReplication(targetVolumeODataID string) error {
+// The request carries the volume ETag in If-Match unless common.SkipETagMatch
+// is given.
+func (volume *Volume) SplitReplication(targetVolumeODataID string, opts ...common.UpdateOption) error {
 	// This action wasn't added until later revisions
 	if volume.splitReplicationTarget == "" {
 		return fmt.Errorf("SplitReplication action is not supported by this system")
@@ -835,14 +791,15 @@ func (volume *Volume) SplitReplication(targetVolumeODataID string) error {
 	// Set the values for the action arguments
 	t := temp{TargetVolume: targetVolumeODataID}
 
-	_, err := volume.Client.Post(volume.splitReplicationTarget, t)
-	return err
+	return volume.Post(volume.splitReplicationTarget, t, opts...)
 }
 
 // SuspendReplication is used to suspend active data synchronization between a
 // source and target volume, without otherwise altering the replication
 // relationship.
-func (volume *Volume) SuspendReplication(targetVolumeODataID string) error {
+// The request carries the volume ETag in If-Match unless common.SkipETagMatch
+// is given.
+func (volume *Volume) SuspendReplication(targetVolumeODataID string, opts ...common.UpdateOption) error {
 	// This action wasn't added until later revisions
 	if volume.suspendReplicationTarget == "" {
 		return fmt.Errorf("SuspendReplication action is not supported by this system")
@@ -856,6 +813,5 @@ func (volume *Volume) SuspendReplication(targetVolumeODataID string) error {
 	// Set the values for the action arguments
 	t := temp{TargetVolume: targetVolumeODataID}
 
-	_, err := volume.Client.Post(volume.suspendReplicationTarget, t)
-	return err
+	return volume.Post(volume.suspendReplicationTarget, t, opts...)
 }
@@ -6,6 +6,8 @@ package swordfish
 
 import (
 	"encoding/json"
+	"io"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -306,3 +308,68 @@ func TestVolumeUpdate(t *testing.T) {
 		t.Errorf("Unexpected WriteHoleProtectionPolicy update payload: %s", calls[0].Payload)
 	}
 }
+
+// TestActionsETag tests actions are sent with the ETag of their resource and
+// report conflicts.
+func TestActionsETag(t *testing.T) {
+	volume := &Volume{}
+	volume.checkConsistencyTarget = "/redfish/v1/Volumes/1/Actions/Volume.CheckConsistency"
+	volume.initializeTarget = "/redfish/v1/Volumes/1/Actions/Volume.Initialize"
+	volume.splitReplicationTarget = "/redfish/v1/Volumes/1/Actions/Volume.SplitReplication"
+	storageService := &StorageService{}
+	storageService.setEncryptionKeyTarget = "/redfish/v1/StorageServices/1/Actions/StorageService.SetEncryptionKey"
+	storageGroup := &StorageGroup{}
+	storageGroup.exposeVolumesTarget = "/redfish/v1/StorageGroups/1/Actions/StorageGroup.ExposeVolumes"
+
+	tests := []struct {
+		name   string
+		entity *common.Entity
+		action func(opts ...common.UpdateOption) error
+	}{
+		{"check consistency", &volume.Entity, volume.CheckConsistency},
+		{"initialize", &volume.Entity, func(opts ...common.UpdateOption) error {
+			_, err := volume.Initialize(FastInitializeType, opts...)
+			return err
+		}},
+		{"split replication", &volume.Entity, func(opts ...common.UpdateOption) error {
+			return volume.SplitReplication("/redfish/v1/Volumes/2", opts...)
+		}},
+		{"set encryption key", &storageService.Entity, func(opts ...common.UpdateOption) error {
+			return storageService.SetEncryptionKey("key", opts...)
+		}},
+		{"expose volumes", &storageGroup.Entity, storageGroup.ExposeVolumes},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testClient := &common.TestClient{
+				CustomReturnForActions: map[string][]interface{}{
+					http.MethodPost: {nil, nil, &http.Response{
+						StatusCode: http.StatusPreconditionFailed,
+						Body:       io.NopCloser(strings.NewReader(`{"error": {"code": "Base.1.8.PreconditionFailed"}}`)),
+					}},
+				},
+			}
+			test.entity.SetClient(testClient)
+			test.entity.SetETag(`W/"1"`)
+
+			if err := test.action(); err != nil {
+				t.Fatalf("Error running the action: %s", err)
+			}
+			if err := test.action(common.SkipETagMatch()); err != nil {
+				t.Fatalf("Error running the action: %s", err)
+			}
+			if err := test.action(); !common.IsConflict(err) {
+				t.Errorf("Expected a conflict error, got: %v", err)
+			}
+
+			calls := testClient.CapturedCalls()
+			if calls[0].CustomHeaders["If-Match"] != `W/"1"` {
+				t.Errorf("Expected the ETag to be sent, got: %v", calls[0].CustomHeaders)
+			}
+			if _, ok := calls[1].CustomHeaders["If-Match"]; ok {
+				t.Errorf("Expected no If-Match header, got: %v", calls[1].CustomHeaders)
+			}
+		})
+	}
+}
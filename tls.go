@@ -0,0 +1,225 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// The checks reported by TLSVerificationError.
+const (
+	// TLSCheckCertificate is the verification of the certificate chain
+	// against the trusted CAs and of the host name.
+	TLSCheckCertificate = "certificate chain"
+	// TLSCheckPublicKeyPin is the verification of the public key pins.
+	TLSCheckPublicKeyPin = "public key pin"
+)
+
+// TLSVerificationError is returned when the certificate of the service fails
+// one of the checks configured in ClientConfig.
+type TLSVerificationError struct {
+	// Check is the check that failed, such as TLSCheckCertificate.
+	Check string
+	// Err is the reason of the failure.
+	Err error
+}
+
+func (e *TLSVerificationError) Error() string {
+	return fmt.Sprintf("TLS %s verification failed: %v", e.Check, e.Err)
+}
+
+// Unwrap returns the reason of the failure.
+func (e *TLSVerificationError) Unwrap() error {
+	return e.Err
+}
+
+// PublicKeyPin returns the pin of the certificate's public key in the form
+// expected by ClientConfig.PinnedPublicKeys: the base64 encoded SHA-256 hash
+// of its DER encoded SubjectPublicKeyInfo.
+func PublicKeyPin(cert *x509.Certificate) string {
+	hash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+// parsePublicKeyPins decodes the configured pins. The "sha256//" prefix used
+// by curl is accepted.
+func parsePublicKeyPins(pins []string) ([][]byte, error) {
+	result := make([][]byte, 0, len(pins))
+	for _, pin := range pins {
+		hash, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(pin, "sha256//"))
+		if err != nil || len(hash) != sha256.Size {
+			return nil, fmt.Errorf("invalid public key pin %q: must be a base64 encoded SHA-256 hash", pin)
+		}
+		result = append(result, hash)
+	}
+	return result, nil
+}
+
+// loadCACerts returns the pool of CAs to trust, or nil to use the system's.
+func loadCACerts(config *ClientConfig) (*x509.CertPool, error) {
+	if config.CACertFile == "" {
+		return config.CACertPool, nil
+	}
+	if config.CACertPool != nil {
+		return nil, fmt.Errorf("only one of CACertFile and CACertPool can be set")
+	}
+
+	pem, err := os.ReadFile(config.CACertFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read CA bundle: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificate found in CA bundle %s", config.CACertFile)
+	}
+	return pool, nil
+}
+
+// loadClientCertificates returns the certificates used for mutual TLS.
+func loadClientCertificates(config *ClientConfig) ([]tls.Certificate, error) {
+	certificates := config.ClientCertificates
+	if config.ClientCertFile != "" || config.ClientKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(config.ClientCertFile, config.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate: %w", err)
+		}
+		certificates = append([]tls.Certificate{cert}, certificates...)
+	}
+	return certificates, nil
+}
+
+// certificateVerifier checks the certificate presented by the service. It
+// replaces the standard verification so failures report which check failed.
+type certificateVerifier struct {
+	roots    *x509.CertPool
+	insecure bool
+	pins     [][]byte
+	// host is the host of the endpoint, checked against the certificate
+	// when the connection has no server name, such as for IP addresses.
+	host string
+
+	// tofuStore, if set, replaces the chain verification by a comparison
+	// with the certificate recorded for the endpoint.
+	tofuStore CertificateStore
+	endpoint  string
+}
+
+// verifyChain checks the certificate chain and the host name.
+func (v *certificateVerifier) verifyChain(cs tls.ConnectionState) error {
+	if v.insecure {
+		return nil
+	}
+
+	opts := x509.VerifyOptions{
+		Roots:         v.roots,
+		DNSName:       cs.ServerName,
+		Intermediates: x509.NewCertPool(),
+	}
+	if opts.DNSName == "" {
+		opts.DNSName = v.host
+	}
+	for _, cert := range cs.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+
+	if _, err := cs.PeerCertificates[0].Verify(opts); err != nil {
+		return &TLSVerificationError{Check: TLSCheckCertificate, Err: err}
+	}
+	return nil
+}
+
+// verifyPins checks one of the certificates of the chain has a pinned
+// public key.
+func (v *certificateVerifier) verifyPins(cs tls.ConnectionState) error {
+	if len(v.pins) == 0 {
+		return nil
+	}
+
+	for _, cert := range cs.PeerCertificates {
+		hash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+		for _, pin := range v.pins {
+			if bytes.Equal(hash[:], pin) {
+				return nil
+			}
+		}
+	}
+
+	return &TLSVerificationError{
+		Check: TLSCheckPublicKeyPin,
+		Err:   fmt.Errorf("no certificate of %s matches the pinned public keys, server key is %s", cs.ServerName, PublicKeyPin(cs.PeerCertificates[0])),
+	}
+}
+
+// verifyConnection runs all the checks on a new connection.
+func (v *certificateVerifier) verifyConnection(cs tls.ConnectionState) error {
+	if len(cs.PeerCertificates) == 0 {
+		return &TLSVerificationError{Check: TLSCheckCertificate, Err: fmt.Errorf("no certificate presented by %s", cs.ServerName)}
+	}
+
+	if v.tofuStore != nil {
+		if err := verifyTrustOnFirstUse(v.tofuStore, v.endpoint, cs); err != nil {
+			return err
+		}
+	} else if err := v.verifyChain(cs); err != nil {
+		return err
+	}
+	return v.verifyPins(cs)
+}
+
+// buildTLSConfig creates the TLS settings of the default transport from the
+// client config.
+func buildTLSConfig(config *ClientConfig) (*tls.Config, error) {
+	roots, err := loadCACerts(config)
+	if err != nil {
+		return nil, err
+	}
+
+	certificates, err := loadClientCertificates(config)
+	if err != nil {
+		return nil, err
+	}
+
+	pins, err := parsePublicKeyPins(config.PinnedPublicKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	verifier := &certificateVerifier{
+		roots:    roots,
+		insecure: config.Insecure,
+		pins:     pins,
+		host:     endpoint.Hostname(),
+	}
+
+	if config.TrustOnFirstUse {
+		if verifier.tofuStore, err = trustOnFirstUseStore(config); err != nil {
+			return nil, err
+		}
+		if verifier.endpoint, err = certificateEndpoint(config.Endpoint); err != nil {
+			return nil, err
+		}
+	}
+
+	return &tls.Config{
+		Certificates: certificates,
+		// The verifier performs the standard checks itself
+		InsecureSkipVerify: true, // nolint:gosec
+		VerifyConnection:   verifier.verifyConnection,
+	}, nil
+}
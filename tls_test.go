@@ -0,0 +1,232 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTLSTestServer starts a TLS server returning the service root.
+func newTLSTestServer() *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(retryServiceRoot)) // nolint
+	}))
+}
+
+// newTestCertificate creates a self-signed client certificate.
+func newTestCertificate(t *testing.T) (tls.Certificate, *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Error generating key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "gofish"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Error creating certificate: %v", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Error parsing certificate: %v", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, cert
+}
+
+// TestTLSUnknownAuthority tests the failed check is reported.
+func TestTLSUnknownAuthority(t *testing.T) {
+	ts := newTLSTestServer()
+	defer ts.Close()
+
+	_, err := Connect(ClientConfig{Endpoint: ts.URL})
+
+	var tlsErr *TLSVerificationError
+	if !errors.As(err, &tlsErr) || tlsErr.Check != TLSCheckCertificate {
+		t.Errorf("Expected a certificate chain error, got: %v", err)
+	}
+}
+
+// TestTLSCACertPool tests a custom CA pool is trusted.
+func TestTLSCACertPool(t *testing.T) {
+	ts := newTLSTestServer()
+	defer ts.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ts.Certificate())
+
+	if _, err := Connect(ClientConfig{Endpoint: ts.URL, CACertPool: pool}); err != nil {
+		t.Errorf("Connect failed: %v", err)
+	}
+}
+
+// TestTLSCACertFile tests a CA bundle file is trusted.
+func TestTLSCACertFile(t *testing.T) {
+	ts := newTLSTestServer()
+	defer ts.Close()
+
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	err := os.WriteFile(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw}), 0600)
+	if err != nil {
+		t.Fatalf("Error writing bundle: %v", err)
+	}
+
+	if _, err := Connect(ClientConfig{Endpoint: ts.URL, CACertFile: bundle}); err != nil {
+		t.Errorf("Connect failed: %v", err)
+	}
+
+	if _, err := Connect(ClientConfig{Endpoint: ts.URL, CACertFile: filepath.Join(t.TempDir(), "missing.pem")}); err == nil {
+		t.Error("Connect should fail with a missing bundle")
+	}
+}
+
+// TestTLSPublicKeyPinning tests connections are restricted to the pinned
+// keys, even with Insecure set.
+func TestTLSPublicKeyPinning(t *testing.T) {
+	ts := newTLSTestServer()
+	defer ts.Close()
+
+	pin := PublicKeyPin(ts.Certificate())
+	if _, err := Connect(ClientConfig{Endpoint: ts.URL, Insecure: true, PinnedPublicKeys: []string{"sha256//" + pin}}); err != nil {
+		t.Errorf("Connect with the right pin failed: %v", err)
+	}
+
+	_, other := newTestCertificate(t)
+	_, err := Connect(ClientConfig{Endpoint: ts.URL, Insecure: true, PinnedPublicKeys: []string{PublicKeyPin(other)}})
+
+	var tlsErr *TLSVerificationError
+	if !errors.As(err, &tlsErr) || tlsErr.Check != TLSCheckPublicKeyPin {
+		t.Errorf("Expected a public key pin error, got: %v", err)
+	}
+
+	if _, err := Connect(ClientConfig{Endpoint: ts.URL, PinnedPublicKeys: []string{"not a pin"}}); err == nil {
+		t.Error("Connect should fail with an invalid pin")
+	}
+}
+
+// TestTLSClientCertificate tests the client certificate is sent to services
+// requiring mutual TLS.
+func TestTLSClientCertificate(t *testing.T) {
+	clientCert, clientX509 := newTestCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientX509)
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(retryServiceRoot)) // nolint
+	}))
+	ts.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	ts.StartTLS()
+	defer ts.Close()
+
+	if _, err := Connect(ClientConfig{Endpoint: ts.URL, Insecure: true}); err == nil {
+		t.Error("Connect without a client certificate should fail")
+	}
+
+	if _, err := Connect(ClientConfig{Endpoint: ts.URL, Insecure: true, ClientCertificates: []tls.Certificate{clientCert}}); err != nil {
+		t.Errorf("Connect with a client certificate failed: %v", err)
+	}
+}
+
+// newServerCertificate creates a CA and a server certificate it signs for the
+// given host names and IP addresses.
+func newServerCertificate(t *testing.T, dnsNames []string, ips []net.IP) (tls.Certificate, *x509.Certificate) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Error generating key: %v", err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "gofish CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("Error creating CA certificate: %v", err)
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatalf("Error parsing CA certificate: %v", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Error generating key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "gofish"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     dnsNames,
+		IPAddresses:  ips,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("Error creating certificate: %v", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, ca
+}
+
+// TestTLSIPAddressEndpoint tests the certificate of an endpoint given by IP
+// address must be issued for that address.
+func TestTLSIPAddressEndpoint(t *testing.T) {
+	tests := map[string]struct {
+		dnsNames []string
+		ips      []net.IP
+		valid    bool
+	}{
+		"matching IP SAN":  {ips: []net.IP{net.IPv4(127, 0, 0, 1)}, valid: true},
+		"other host name":  {dnsNames: []string{"other.example"}},
+		"other IP address": {ips: []net.IP{net.IPv4(192, 0, 2, 1)}},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cert, ca := newServerCertificate(t, test.dnsNames, test.ips)
+			ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(retryServiceRoot)) // nolint
+			}))
+			ts.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+			ts.StartTLS()
+			defer ts.Close()
+
+			pool := x509.NewCertPool()
+			pool.AddCert(ca)
+			_, err := Connect(ClientConfig{Endpoint: ts.URL, CACertPool: pool})
+
+			var tlsErr *TLSVerificationError
+			switch {
+			case test.valid && err != nil:
+				t.Errorf("Connect failed: %v", err)
+			case !test.valid && (!errors.As(err, &tlsErr) || tlsErr.Check != TLSCheckCertificate):
+				t.Errorf("Expected a certificate error, got: %v", err)
+			}
+		})
+	}
+}
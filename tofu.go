@@ -0,0 +1,231 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// TLSCheckTrustOnFirstUse is the comparison of the service certificate with
+// the one recorded on first use.
+const TLSCheckTrustOnFirstUse = "trust on first use"
+
+// CertificateStore records the certificate trusted for each endpoint when
+// ClientConfig.TrustOnFirstUse is set.
+type CertificateStore interface {
+	// Fingerprint returns the fingerprint recorded for the endpoint, or an
+	// empty string if there is none.
+	Fingerprint(endpoint string) (string, error)
+	// SetFingerprint records the fingerprint of the endpoint's certificate,
+	// replacing any previous one.
+	SetFingerprint(endpoint, fingerprint string) error
+}
+
+// CertificateFingerprint returns the fingerprint of a certificate as recorded
+// in a CertificateStore: the hex encoded SHA-256 hash of its DER encoding.
+func CertificateFingerprint(cert *x509.Certificate) string {
+	hash := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(hash[:])
+}
+
+// CertificateChangedError is returned when the certificate of an endpoint
+// differs from the one recorded on first use. This is expected when the
+// certificate was renewed, but can also mean the connection is intercepted.
+type CertificateChangedError struct {
+	// Endpoint is the host and port of the service.
+	Endpoint string
+	// Expected is the recorded fingerprint.
+	Expected string
+	// Fingerprint is the fingerprint of the certificate presented.
+	Fingerprint string
+
+	store CertificateStore
+}
+
+func (e *CertificateChangedError) Error() string {
+	return fmt.Sprintf("certificate of %s changed to %s, expected %s", e.Endpoint, e.Fingerprint, e.Expected)
+}
+
+// Accept records the new certificate as trusted for the endpoint, once it was
+// confirmed that the certificate was rotated on purpose.
+func (e *CertificateChangedError) Accept() error {
+	return e.store.SetFingerprint(e.Endpoint, e.Fingerprint)
+}
+
+// certificateEndpoint returns the key of the endpoint in certificate stores,
+// its host and port.
+func certificateEndpoint(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	host := strings.ToLower(u.Host)
+	if u.Port() == "" {
+		port := "443"
+		if u.Scheme == "http" {
+			port = "80"
+		}
+		host = net.JoinHostPort(strings.ToLower(u.Hostname()), port)
+	}
+	return host, nil
+}
+
+// AcceptCertificate records the fingerprint as trusted for the endpoint URL in
+// the store, for example to accept a rotated certificate ahead of time.
+func AcceptCertificate(store CertificateStore, endpoint, fingerprint string) error {
+	key, err := certificateEndpoint(endpoint)
+	if err != nil {
+		return err
+	}
+	return store.SetFingerprint(key, strings.ToLower(fingerprint))
+}
+
+// verifyTrustOnFirstUse compares the certificate with the recorded one, or
+// records it if the endpoint was never seen.
+func verifyTrustOnFirstUse(store CertificateStore, endpoint string, cs tls.ConnectionState) error {
+	fingerprint := CertificateFingerprint(cs.PeerCertificates[0])
+
+	expected, err := store.Fingerprint(endpoint)
+	if err != nil {
+		return &TLSVerificationError{Check: TLSCheckTrustOnFirstUse, Err: err}
+	}
+
+	if expected == "" {
+		if err := store.SetFingerprint(endpoint, fingerprint); err != nil {
+			return &TLSVerificationError{Check: TLSCheckTrustOnFirstUse, Err: err}
+		}
+		return nil
+	}
+
+	if expected != fingerprint {
+		return &TLSVerificationError{
+			Check: TLSCheckTrustOnFirstUse,
+			Err: &CertificateChangedError{
+				Endpoint:    endpoint,
+				Expected:    expected,
+				Fingerprint: fingerprint,
+				store:       store,
+			},
+		}
+	}
+
+	return nil
+}
+
+// FileCertificateStore is a CertificateStore saving the fingerprints in a
+// JSON file. It is safe for concurrent use within a process.
+type FileCertificateStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileCertificateStore creates a store saving the fingerprints in the file
+// at path. The file is created on the first fingerprint recorded.
+func NewFileCertificateStore(path string) *FileCertificateStore {
+	return &FileCertificateStore{path: path}
+}
+
+// DefaultCertificateStorePath returns the path of the file used when
+// TrustOnFirstUse is set without a CertificateStore.
+func DefaultCertificateStorePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gofish", "known_certificates.json"), nil
+}
+
+// load reads the fingerprints. The lock must be held.
+func (s *FileCertificateStore) load() (map[string]string, error) {
+	fingerprints := make(map[string]string)
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fingerprints, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &fingerprints); err != nil {
+		return nil, fmt.Errorf("invalid certificate store %s: %w", s.path, err)
+	}
+	return fingerprints, nil
+}
+
+// Fingerprint returns the fingerprint recorded for the endpoint.
+func (s *FileCertificateStore) Fingerprint(endpoint string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fingerprints, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	return fingerprints[endpoint], nil
+}
+
+// SetFingerprint records the fingerprint of the endpoint's certificate. The
+// file is replaced atomically.
+func (s *FileCertificateStore) SetFingerprint(endpoint, fingerprint string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fingerprints, err := s.load()
+	if err != nil {
+		return err
+	}
+	fingerprints[endpoint] = fingerprint
+
+	data, err := json.MarshalIndent(fingerprints, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// trustOnFirstUseStore returns the store to use for the client config.
+func trustOnFirstUseStore(config *ClientConfig) (CertificateStore, error) {
+	if config.CertificateStore != nil {
+		return config.CertificateStore, nil
+	}
+
+	path, err := DefaultCertificateStorePath()
+	if err != nil {
+		return nil, fmt.Errorf("unable to locate the certificate store: %w", err)
+	}
+	return NewFileCertificateStore(path), nil
+}
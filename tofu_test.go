@@ -0,0 +1,93 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// TestTrustOnFirstUse tests the certificate is recorded on first use and
+// checked on later connections.
+func TestTrustOnFirstUse(t *testing.T) {
+	ts := newTLSTestServer()
+	defer ts.Close()
+
+	store := NewFileCertificateStore(filepath.Join(t.TempDir(), "certificates.json"))
+	config := ClientConfig{Endpoint: ts.URL, TrustOnFirstUse: true, CertificateStore: store}
+
+	if _, err := Connect(config); err != nil {
+		t.Fatalf("First connection failed: %v", err)
+	}
+
+	endpoint, _ := certificateEndpoint(ts.URL)
+	fingerprint, err := store.Fingerprint(endpoint)
+	if err != nil {
+		t.Fatalf("Error reading store: %v", err)
+	}
+	if fingerprint != CertificateFingerprint(ts.Certificate()) {
+		t.Errorf("Wrong fingerprint recorded: %s", fingerprint)
+	}
+
+	// A new store reading the same file trusts the certificate
+	config.CertificateStore = NewFileCertificateStore(store.path)
+	if _, err := Connect(config); err != nil {
+		t.Errorf("Second connection failed: %v", err)
+	}
+}
+
+// TestTrustOnFirstUseChanged tests a changed certificate is rejected until it
+// is accepted.
+func TestTrustOnFirstUseChanged(t *testing.T) {
+	ts := newTLSTestServer()
+	defer ts.Close()
+
+	store := NewFileCertificateStore(filepath.Join(t.TempDir(), "certificates.json"))
+	if err := AcceptCertificate(store, ts.URL, "00ff"); err != nil {
+		t.Fatalf("Error recording fingerprint: %v", err)
+	}
+
+	config := ClientConfig{Endpoint: ts.URL, TrustOnFirstUse: true, CertificateStore: store}
+	_, err := Connect(config)
+
+	var tlsErr *TLSVerificationError
+	if !errors.As(err, &tlsErr) || tlsErr.Check != TLSCheckTrustOnFirstUse {
+		t.Fatalf("Expected a trust on first use error, got: %v", err)
+	}
+
+	var changed *CertificateChangedError
+	if !errors.As(err, &changed) {
+		t.Fatalf("Expected a certificate changed error, got: %v", err)
+	}
+	if changed.Expected != "00ff" || changed.Fingerprint != CertificateFingerprint(ts.Certificate()) {
+		t.Errorf("Wrong fingerprints reported: %v", changed)
+	}
+
+	if err := changed.Accept(); err != nil {
+		t.Fatalf("Error accepting certificate: %v", err)
+	}
+	if _, err := Connect(config); err != nil {
+		t.Errorf("Connection after accepting the certificate failed: %v", err)
+	}
+}
+
+// TestCertificateEndpoint tests endpoints are keyed by host and port.
+func TestCertificateEndpoint(t *testing.T) {
+	tests := map[string]string{
+		"https://BMC.example.com":           "bmc.example.com:443",
+		"https://bmc.example.com:8443/":     "bmc.example.com:8443",
+		"http://10.0.0.1":                   "10.0.0.1:80",
+		"https://[fe80::1]/redfish/v1":      "[fe80::1]:443",
+		"https://[fe80::1]:8443/redfish/v1": "[fe80::1]:8443",
+	}
+
+	for endpoint, expected := range tests {
+		key, err := certificateEndpoint(endpoint)
+		if err != nil || key != expected {
+			t.Errorf("Expected %s for %s, got %s (%v)", expected, endpoint, key, err)
+		}
+	}
+}
@@ -0,0 +1,366 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/trungng1992/gofish/common"
+)
+
+// ProgressFunc is called while a request body is sent with the number of
+// bytes sent so far and the total size, or -1 if the size is not known.
+type ProgressFunc = common.ProgressFunc
+
+// MultipartPart is a part of a multipart/form-data upload.
+type MultipartPart = common.MultipartPart
+
+// readerSize returns the number of bytes left in the reader, or -1 if it is
+// not known.
+func readerSize(r io.Reader) int64 {
+	switch v := r.(type) {
+	case interface{ Len() int }:
+		return int64(v.Len())
+	case io.Seeker:
+		current, err := v.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+		end, err := v.Seek(0, io.SeekEnd)
+		if err != nil {
+			return -1
+		}
+		if _, err := v.Seek(current, io.SeekStart); err != nil {
+			return -1
+		}
+		return end - current
+	}
+	return -1
+}
+
+// rewinder returns a function seeking the reader back to where it is now, or
+// one failing if the reader cannot seek.
+func rewinder(r io.Reader) func() error {
+	seeker, ok := r.(io.Seeker)
+	if !ok {
+		return func() error {
+			return fmt.Errorf("%T cannot be read again", r)
+		}
+	}
+
+	start, err := seeker.Seek(0, io.SeekCurrent)
+	return func() error {
+		if err != nil {
+			return err
+		}
+		_, err := seeker.Seek(start, io.SeekStart)
+		return err
+	}
+}
+
+// streamBody is a request body produced while it is sent. It implements
+// io.Seeker so sendRequest can start it over for a retry.
+type streamBody struct {
+	// mu guards against the transport closing the body of a previous
+	// attempt while it is started over.
+	mu       sync.Mutex
+	open     func() io.ReadCloser
+	rewind   func() error
+	current  io.ReadCloser
+	started  bool
+	sent     int64
+	total    int64
+	progress ProgressFunc
+}
+
+// Read reads the body, reporting progress.
+func (b *streamBody) Read(p []byte) (int, error) {
+	b.mu.Lock()
+	if b.current == nil {
+		b.current = b.open()
+		b.started = true
+	}
+	current := b.current
+	b.mu.Unlock()
+
+	n, err := current.Read(p)
+	if n > 0 {
+		b.mu.Lock()
+		b.sent += int64(n)
+		sent := b.sent
+		b.mu.Unlock()
+
+		if b.progress != nil {
+			b.progress(sent, b.total)
+		}
+	}
+	return n, err
+}
+
+// Seek starts the body over. Only seeking back to the start is supported.
+func (b *streamBody) Seek(offset int64, whence int) (int64, error) {
+	if offset != 0 || whence != io.SeekStart {
+		return 0, fmt.Errorf("stream body can only be rewound")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.close()
+	if b.started {
+		if err := b.rewind(); err != nil {
+			return 0, err
+		}
+	}
+
+	b.sent = 0
+	b.started = false
+	return 0, nil
+}
+
+// Close stops producing the body. It can still be started over with Seek.
+func (b *streamBody) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.close()
+	return nil
+}
+
+// close closes the current reader. The lock must be held.
+func (b *streamBody) close() {
+	if b.current != nil {
+		b.current.Close()
+		b.current = nil
+	}
+}
+
+// escapeQuotes escapes a value of a Content-Disposition header.
+var escapeQuotes = strings.NewReplacer("\\", "\\\\", `"`, "\\\"").Replace
+
+// partHeader returns the MIME header of a part.
+func partHeader(part *MultipartPart) textproto.MIMEHeader {
+	header := make(textproto.MIMEHeader)
+	disposition := fmt.Sprintf(`form-data; name="%s"`, escapeQuotes(part.Name))
+	if part.FileName != "" {
+		disposition += fmt.Sprintf(`; filename="%s"`, escapeQuotes(part.FileName))
+	}
+	header.Set("Content-Disposition", disposition)
+
+	contentType := part.ContentType
+	if contentType == "" && part.FileName != "" {
+		contentType = "application/octet-stream"
+	}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	return header
+}
+
+// countingWriter counts the bytes written to it.
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// multipartSize returns the size of the multipart body, or -1 if the size of
+// one of the parts is not known.
+func multipartSize(parts []MultipartPart, sizes []int64, boundary string) int64 {
+	var framing countingWriter
+	w := multipart.NewWriter(&framing)
+	if err := w.SetBoundary(boundary); err != nil {
+		return -1
+	}
+
+	var total int64
+	for i := range parts {
+		if sizes[i] < 0 {
+			return -1
+		}
+		if _, err := w.CreatePart(partHeader(&parts[i])); err != nil {
+			return -1
+		}
+		total += sizes[i]
+	}
+	if err := w.Close(); err != nil {
+		return -1
+	}
+
+	return total + framing.n
+}
+
+// pipeBody is the reading end of a pipe fed by a goroutine.
+type pipeBody struct {
+	*io.PipeReader
+	done chan struct{}
+}
+
+// Close closes the pipe and waits for the goroutine to stop, so the readers
+// it used can be rewound.
+func (b *pipeBody) Close() error {
+	err := b.PipeReader.Close()
+	<-b.done
+	return err
+}
+
+// newMultipartBody creates a body streaming the parts, and returns its
+// content type.
+func newMultipartBody(parts []MultipartPart, progress ProgressFunc) (*streamBody, string) {
+	boundary := multipart.NewWriter(io.Discard).Boundary()
+
+	sizes := make([]int64, len(parts))
+	rewinders := make([]func() error, len(parts))
+	for i := range parts {
+		sizes[i] = parts[i].Size
+		if sizes[i] == 0 {
+			sizes[i] = readerSize(parts[i].Reader)
+		}
+		rewinders[i] = rewinder(parts[i].Reader)
+	}
+
+	body := &streamBody{
+		total:    multipartSize(parts, sizes, boundary),
+		progress: progress,
+		rewind: func() error {
+			for _, rewind := range rewinders {
+				if err := rewind(); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		open: func() io.ReadCloser {
+			reader, writer := io.Pipe()
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				w := multipart.NewWriter(writer)
+				_ = w.SetBoundary(boundary)
+				for i := range parts {
+					partWriter, err := w.CreatePart(partHeader(&parts[i]))
+					if err == nil {
+						_, err = io.Copy(partWriter, parts[i].Reader)
+					}
+					if err != nil {
+						writer.CloseWithError(err)
+						return
+					}
+				}
+				writer.CloseWithError(w.Close())
+			}()
+			return &pipeBody{PipeReader: reader, done: done}
+		},
+	}
+
+	return body, "multipart/form-data; boundary=" + boundary
+}
+
+// newBinaryBody creates a body streaming the reader.
+func newBinaryBody(r io.Reader, progress ProgressFunc) *streamBody {
+	return &streamBody{
+		total:    readerSize(r),
+		progress: progress,
+		rewind:   rewinder(r),
+		open: func() io.ReadCloser {
+			return io.NopCloser(r)
+		},
+	}
+}
+
+// withContentLength returns the custom headers with the Content-Length of the
+// body, if it is known and not set already.
+func withContentLength(customHeaders map[string]string, size int64) map[string]string {
+	if size < 0 || hasHeader(customHeaders, "Content-Length") {
+		return customHeaders
+	}
+
+	headers := make(map[string]string, len(customHeaders)+1)
+	for k, v := range customHeaders {
+		headers[k] = v
+	}
+	headers["Content-Length"] = strconv.FormatInt(size, 10)
+	return headers
+}
+
+// multipartParts converts the payload of PostMultipart into parts. Files are
+// sent as file parts. UpdateParameters is sent first, as services read it
+// before the image, and the other parts follow sorted by name.
+func multipartParts(payload map[string]io.Reader) []MultipartPart {
+	parts := make([]MultipartPart, 0, len(payload))
+	for key, reader := range payload {
+		part := MultipartPart{Name: key, Reader: reader}
+		if file, ok := reader.(*os.File); ok {
+			part.FileName = filepath.Base(file.Name())
+		}
+		parts = append(parts, part)
+	}
+
+	sort.Slice(parts, func(i, j int) bool {
+		if (parts[i].Name == "UpdateParameters") != (parts[j].Name == "UpdateParameters") {
+			return parts[i].Name == "UpdateParameters"
+		}
+		return parts[i].Name < parts[j].Name
+	})
+	return parts
+}
+
+// PostMultipartParts performs a multipart/form-data Post request against the
+// Redfish service, such as an upload to the MultipartHttpPushUri of the
+// UpdateService. The parts are sent in order, streamed from their readers
+// without being held in memory. The Content-Length is set when the sizes of
+// all the parts are known. progress, if not nil, is called as the body is
+// sent.
+func (c *APIClient) PostMultipartParts(ctx context.Context, url string, parts []MultipartPart, progress ProgressFunc, customHeaders map[string]string) (*http.Response, error) {
+	return c.runMultipartRequest(ctx, http.MethodPost, url, parts, progress, customHeaders)
+}
+
+// runMultipartRequest performs REST calls with a streamed multipart payload.
+func (c *APIClient) runMultipartRequest(ctx context.Context, method, url string, parts []MultipartPart, progress ProgressFunc, customHeaders map[string]string) (*http.Response, error) {
+	if url == "" {
+		return nil, fmt.Errorf("unable to execute request, no target provided")
+	}
+
+	body, contentType := newMultipartBody(parts, progress)
+	defer body.Close()
+
+	return c.runRawRequestWithHeaders(ctx, method, url, body, contentType, withContentLength(customHeaders, body.total))
+}
+
+// PostBinary performs a Post request against the Redfish service with a raw
+// body, such as a firmware image pushed to the HttpPushUri of the
+// UpdateService. The body is streamed from r without being held in memory.
+// The Content-Length is set when the size of r is known, or can be given in
+// the custom headers. progress, if not nil, is called as the body is sent.
+func (c *APIClient) PostBinary(ctx context.Context, url string, r io.Reader, contentType string, progress ProgressFunc, customHeaders map[string]string) (*http.Response, error) {
+	if url == "" {
+		return nil, fmt.Errorf("unable to execute request, no target provided")
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	body := newBinaryBody(r, progress)
+	defer body.Close()
+
+	if length, ok := customHeaders["Content-Length"]; ok && body.total < 0 {
+		body.total, _ = strconv.ParseInt(length, 10, 64)
+	}
+
+	return c.runRawRequestWithHeaders(ctx, http.MethodPost, url, body, contentType, withContentLength(customHeaders, body.total))
+}
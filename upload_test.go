@@ -0,0 +1,238 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/trungng1992/gofish/redfish"
+)
+
+// receivedPart is a part read by the upload test server.
+type receivedPart struct {
+	name        string
+	fileName    string
+	contentType string
+	size        int
+}
+
+// uploadServer records the uploads it receives.
+type uploadServer struct {
+	*httptest.Server
+	contentLength int64
+	parts         []receivedPart
+	body          []byte
+	calls         int
+	failFirst     bool
+}
+
+func newUploadServer() *uploadServer {
+	s := &uploadServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.calls++
+		s.contentLength = r.ContentLength
+		s.parts = nil
+		s.body = nil
+
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/") {
+			reader, err := r.MultipartReader()
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			for {
+				part, err := reader.NextPart()
+				if err != nil {
+					break
+				}
+				data, _ := io.ReadAll(part)
+				s.parts = append(s.parts, receivedPart{
+					name:        part.FormName(),
+					fileName:    part.FileName(),
+					contentType: part.Header.Get("Content-Type"),
+					size:        len(data),
+				})
+			}
+		} else {
+			s.body, _ = io.ReadAll(r.Body)
+		}
+
+		if s.failFirst && s.calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Location", "/redfish/v1/TaskMonitors/1")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	return s
+}
+
+// TestPostMultipartParts tests the parts are streamed in order with their
+// content types, the Content-Length and progress.
+func TestPostMultipartParts(t *testing.T) {
+	ts := newUploadServer()
+	defer ts.Close()
+
+	image := bytes.Repeat([]byte{0xff}, 256*1024)
+	parts := []MultipartPart{
+		{Name: "UpdateParameters", ContentType: "application/json", Reader: strings.NewReader(`{"Targets": []}`)},
+		{Name: "UpdateFile", FileName: "bios.bin", Reader: bytes.NewReader(image)},
+	}
+
+	var lastSent, lastTotal int64
+	client := newRetryTestClient(ts.Server, nil)
+	resp, err := client.PostMultipartParts(context.Background(), "/redfish/v1/UpdateService/upload", parts, func(sent, total int64) {
+		lastSent, lastTotal = sent, total
+	}, nil)
+	if err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+	resp.Body.Close()
+
+	expected := []receivedPart{
+		{name: "UpdateParameters", contentType: "application/json", size: 15},
+		{name: "UpdateFile", fileName: "bios.bin", contentType: "application/octet-stream", size: len(image)},
+	}
+	if len(ts.parts) != len(expected) {
+		t.Fatalf("Expected %d parts, got: %+v", len(expected), ts.parts)
+	}
+	for i := range expected {
+		if ts.parts[i] != expected[i] {
+			t.Errorf("Expected part %+v, got %+v", expected[i], ts.parts[i])
+		}
+	}
+
+	if ts.contentLength <= int64(len(image)) || lastTotal != ts.contentLength || lastSent != lastTotal {
+		t.Errorf("Wrong sizes: Content-Length %d, progress %d/%d", ts.contentLength, lastSent, lastTotal)
+	}
+}
+
+// TestPostMultipartFile tests the map based API sends files as file parts,
+// after the UpdateParameters and in a stable order.
+func TestPostMultipartFile(t *testing.T) {
+	ts := newUploadServer()
+	defer ts.Close()
+
+	path := filepath.Join(t.TempDir(), "firmware.bin")
+	if err := os.WriteFile(path, []byte("firmware"), 0600); err != nil {
+		t.Fatalf("Error writing file: %v", err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Error opening file: %v", err)
+	}
+	defer file.Close()
+
+	client := newRetryTestClient(ts.Server, nil)
+	resp, err := client.PostMultipart("/redfish/v1/UpdateService/upload", map[string]io.Reader{
+		"UpdateFile":       file,
+		"UpdateParameters": strings.NewReader("{}"),
+		"OemParameters":    strings.NewReader("{}"),
+	})
+	if err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+	resp.Body.Close()
+
+	expected := []receivedPart{
+		{name: "UpdateParameters", size: 2},
+		{name: "OemParameters", size: 2},
+		{name: "UpdateFile", fileName: "firmware.bin", contentType: "application/octet-stream", size: 8},
+	}
+	if len(ts.parts) != len(expected) {
+		t.Fatalf("Expected %d parts, got: %+v", len(expected), ts.parts)
+	}
+	for i := range expected {
+		if ts.parts[i] != expected[i] {
+			t.Errorf("Expected part %+v, got %+v", expected[i], ts.parts[i])
+		}
+	}
+	if ts.contentLength < 0 {
+		t.Error("Content-Length should be set for files")
+	}
+}
+
+// TestPostBinary tests raw bodies are streamed, with a Content-Length only
+// when the size is known.
+func TestPostBinary(t *testing.T) {
+	ts := newUploadServer()
+	defer ts.Close()
+
+	client := newRetryTestClient(ts.Server, nil)
+	image := bytes.Repeat([]byte{0x5a}, 64*1024)
+
+	resp, err := client.PostBinary(context.Background(), "/redfish/v1/UpdateService/push", bytes.NewReader(image), "", nil, nil)
+	if err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+	resp.Body.Close()
+	if !bytes.Equal(ts.body, image) || ts.contentLength != int64(len(image)) {
+		t.Errorf("Unexpected upload: %d bytes, Content-Length %d", len(ts.body), ts.contentLength)
+	}
+
+	// A reader of unknown size is sent chunked
+	resp, err = client.PostBinary(context.Background(), "/redfish/v1/UpdateService/push", io.MultiReader(bytes.NewReader(image)), "", nil, nil)
+	if err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+	resp.Body.Close()
+	if !bytes.Equal(ts.body, image) || ts.contentLength != -1 {
+		t.Errorf("Unexpected upload: %d bytes, Content-Length %d", len(ts.body), ts.contentLength)
+	}
+}
+
+// TestPostBinaryRetry tests a streamed body is sent again in full on retry.
+func TestPostBinaryRetry(t *testing.T) {
+	ts := newUploadServer()
+	ts.failFirst = true
+	defer ts.Close()
+
+	policy := fastRetryPolicy()
+	policy.RetryNonIdempotent = true
+	client := newRetryTestClient(ts.Server, policy)
+
+	image := bytes.Repeat([]byte{0x5a}, 64*1024)
+	resp, err := client.PostBinary(context.Background(), "/redfish/v1/UpdateService/push", bytes.NewReader(image), "", nil, nil)
+	if err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if ts.calls != 2 || !bytes.Equal(ts.body, image) {
+		t.Errorf("Expected the body to be sent again, got %d calls and %d bytes", ts.calls, len(ts.body))
+	}
+}
+
+// TestMultipartPushUpdate tests the UpdateService streams updates through
+// the client and returns the task monitor.
+func TestMultipartPushUpdate(t *testing.T) {
+	ts := newUploadServer()
+	defer ts.Close()
+
+	client := newRetryTestClient(ts.Server, nil)
+	updateService := &redfish.UpdateService{MultipartHTTPPushURI: "/redfish/v1/UpdateService/upload"}
+	updateService.SetClient(client)
+
+	op, err := updateService.MultipartPushUpdate(context.Background(), &redfish.UpdateParameters{Targets: []string{"/redfish/v1/Managers/1"}},
+		"bmc.bin", strings.NewReader("image"), nil)
+	if err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+
+	if len(ts.parts) != 2 || ts.parts[0].name != "UpdateParameters" || ts.parts[1].fileName != "bmc.bin" {
+		t.Errorf("Unexpected parts: %+v", ts.parts)
+	}
+	if op.Done() || op.Monitor != "/redfish/v1/TaskMonitors/1" {
+		t.Errorf("Expected the operation to be monitored: %+v", op)
+	}
+}